@@ -30,6 +30,7 @@ genenum -typename=AchieveType -packagename=achievetype -basedir=enum -vectortype
 genenum -typename=AIPlan -packagename=aiplan -basedir=enum -vectortype=int
 genenum -typename=ActiveObjType -packagename=aotype -basedir=enum -vectortype=int
 genenum -typename=CarryingObjectType -packagename=carryingobjecttype -basedir=enum -vectortype=int
+genenum -typename=ChatType -packagename=chattype -basedir=enum
 genenum -typename=ClientControlType -packagename=clientcontroltype -basedir=enum 
 genenum -typename=Condition -packagename=condition -basedir=enum -flagtype=uint16 -vectortype=int
 genenum -typename=DangerType -packagename=dangertype -basedir=enum -vectortype=int
@@ -38,13 +39,18 @@ genenum -typename=EquipSlotType -packagename=equipslottype -basedir=enum -vector
 genenum -typename=FactionType -packagename=factiontype -basedir=enum -vectortype=int
 genenum -typename=FieldObjActType -packagename=fieldobjacttype -basedir=enum -vectortype=int
 genenum -typename=FieldObjDisplayType -packagename=fieldobjdisplaytype -basedir=enum
+genenum -typename=NoiseType -packagename=noisetype -basedir=enum
+genenum -typename=PetOrder -packagename=petorder -basedir=enum
 genenum -typename=PotionType -packagename=potiontype -basedir=enum -vectortype=int
+genenum -typename=Relation -packagename=relation -basedir=enum
 genenum -typename=ResourceType -packagename=resourcetype -basedir=enum -vectortype=int
 genenum -typename=ScrollType -packagename=scrolltype -basedir=enum -vectortype=int
+genenum -typename=SkillType -packagename=skilltype -basedir=enum -vectortype=int
 genenum -typename=StatusOpType -packagename=statusoptype -basedir=enum
 genenum -typename=TerrainCmd -packagename=terraincmd -basedir=enum -vectortype=int
 genenum -typename=Tile -packagename=tile -basedir=enum -flagtype=uint16 -vectortype=int
 genenum -typename=TowerAchieve -packagename=towerachieve -basedir=enum -vectortype=float64
+genenum -typename=TradeState -packagename=tradestate -basedir=enum
 genenum -typename=TurnResultType -packagename=turnresulttype -basedir=enum
 genenum -typename=Way9Type -packagename=way9type -basedir=enum 
 
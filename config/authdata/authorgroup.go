@@ -42,6 +42,13 @@ var allAuthorizationSet = map[string]*c2t_authorize.AuthorizedCmds{
 		c2t_idcmd.Heartbeat,
 		c2t_idcmd.Chat,
 		c2t_idcmd.AchieveInfo,
+		c2t_idcmd.AckObjectList,
+		c2t_idcmd.TradePropose,
+		c2t_idcmd.TradeAmend,
+		c2t_idcmd.TradeAccept,
+		c2t_idcmd.TradeCancel,
+		c2t_idcmd.ListShop,
+		c2t_idcmd.ListQuest,
 		c2t_idcmd.Rebirth,
 		c2t_idcmd.Meditate,
 		c2t_idcmd.KillSelf,
@@ -49,6 +56,8 @@ var allAuthorizationSet = map[string]*c2t_authorize.AuthorizedCmds{
 		c2t_idcmd.Attack,
 		c2t_idcmd.AttackWide,
 		c2t_idcmd.AttackLong,
+		c2t_idcmd.Shoot,
+		c2t_idcmd.Cast,
 		c2t_idcmd.Pickup,
 		c2t_idcmd.Drop,
 		c2t_idcmd.Equip,
@@ -56,12 +65,25 @@ var allAuthorizationSet = map[string]*c2t_authorize.AuthorizedCmds{
 		c2t_idcmd.DrinkPotion,
 		c2t_idcmd.ReadScroll,
 		c2t_idcmd.Recycle,
+		c2t_idcmd.Craft,
+		c2t_idcmd.Buy,
+		c2t_idcmd.Repair,
+		c2t_idcmd.AcceptQuest,
+		c2t_idcmd.CompleteQuest,
 		c2t_idcmd.EnterPortal,
 		c2t_idcmd.MoveFloor,
 		c2t_idcmd.ActTeleport,
+		c2t_idcmd.OpenDoor,
+		c2t_idcmd.CloseDoor,
+		c2t_idcmd.CommandPet,
 
 		c2t_idcmd.AIPlay,
 	}),
+	// read only, no ao
+	"Spectator": c2t_authorize.NewByCmdIDList([]c2t_idcmd.CommandID{
+		c2t_idcmd.Heartbeat,
+		c2t_idcmd.Spectate,
+	}),
 	"Admin": c2t_authorize.NewByCmdIDList([]c2t_idcmd.CommandID{
 		c2t_idcmd.AdminTowerCmd,
 		c2t_idcmd.AdminFloorCmd,
@@ -108,3 +130,9 @@ func UpdateByAuthKey(acicl *c2t_authorize.AuthorizedCmds, key string) error {
 	}
 	return nil
 }
+
+// UpdateBySpectator replace login cmds to spectator cmds, authkey not used
+func UpdateBySpectator(acicl *c2t_authorize.AuthorizedCmds) {
+	acicl.Union(allAuthorizationSet["Spectator"])
+	acicl.SubIntersection(allAuthorizationSet["DelAfterLogin"])
+}
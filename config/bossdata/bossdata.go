@@ -0,0 +1,140 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bossdata named boss ao defined by AddBoss terrain cmd
+// aiplan : AIPlan[:Weight] list, plan selected Weight times more, empty is default plan
+// equip : EquipSlotType list, made with boss faction, equipped on spawn and rebirth
+// loot : CarryingObjectType[:SubType][*Count] list, dropped on death
+package bossdata
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kasworld/goguelike/config/craftdata"
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/aiplan"
+	"github.com/kasworld/goguelike/enum/equipslottype"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/lib/scriptparse"
+)
+
+// DefaultAIPlan plan of boss without aiplan, boss not leave floor by portal
+var DefaultAIPlan = []aiplan.AIPlan{
+	aiplan.StrollAround,
+	aiplan.Move2Dest,
+	aiplan.Revenge,
+	aiplan.RechargeSafe,
+	aiplan.RechargeCan,
+	aiplan.PickupCarryObj,
+	aiplan.Equip,
+	aiplan.UsePotion,
+	aiplan.Attack,
+	aiplan.Investigate,
+	aiplan.Attack,
+	aiplan.CastSkill,
+}
+
+func (bs Boss) String() string {
+	return fmt.Sprintf("Boss[%v Lv%v %v]", bs.Name, bs.Level, bs.Faction)
+}
+
+type Boss struct {
+	Name    string
+	InRoom  bool // rand pos in room on spawn, rebirth
+	X, Y    int  // spawn pos if not InRoom
+	Level   int
+	Faction factiontype.FactionType
+	AIPlan  []aiplan.AIPlan // repeated by weight
+	Equip   []equipslottype.EquipSlotType
+	Loot    []craftdata.Material
+	Respawn int // turn to rebirth after death
+}
+
+// New parse string args of AddBoss terrain cmd
+func New(name string, level int, ft factiontype.FactionType,
+	aiplanStr, equipStr, lootStr string, respawn int) (*Boss, error) {
+
+	if name == "" {
+		return nil, fmt.Errorf("empty boss name")
+	}
+	if level < 1 || level > gameconst.MaxLevel {
+		return nil, fmt.Errorf("invalid boss level %v %v", name, level)
+	}
+	if respawn <= 0 {
+		return nil, fmt.Errorf("invalid boss respawn %v %v", name, respawn)
+	}
+	bs := &Boss{
+		Name:    name,
+		Level:   level,
+		Faction: ft,
+		Respawn: respawn,
+	}
+	var err error
+	if bs.AIPlan, err = ParseAIPlan(aiplanStr); err != nil {
+		return nil, err
+	}
+	if bs.Equip, err = ParseEquip(equipStr); err != nil {
+		return nil, err
+	}
+	if bs.Loot, err = craftdata.ParseMaterialList(lootStr); err != nil {
+		return nil, err
+	}
+	return bs, nil
+}
+
+// ParseAIPlan return DefaultAIPlan if empty
+func ParseAIPlan(str string) ([]aiplan.AIPlan, error) {
+	var rtn []aiplan.AIPlan
+	for _, v := range scriptparse.SplitTrim(str, ",") {
+		planStr, weightStr := scriptparse.SplitCmdArgstr(v, ":")
+		pl, exist := aiplan.String2AIPlan(planStr)
+		if !exist {
+			return nil, fmt.Errorf("unknown AIPlan %v", v)
+		}
+		switch pl {
+		case aiplan.None, aiplan.UsePortal:
+			return nil, fmt.Errorf("AIPlan not for boss %v", v)
+		}
+		weight := 1
+		if weightStr != "" {
+			n, err := strconv.Atoi(weightStr)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid weight %v", v)
+			}
+			weight = n
+		}
+		for ; weight > 0; weight-- {
+			rtn = append(rtn, pl)
+		}
+	}
+	if len(rtn) == 0 {
+		rtn = append(rtn, DefaultAIPlan...)
+	}
+	return rtn, nil
+}
+
+func ParseEquip(str string) ([]equipslottype.EquipSlotType, error) {
+	var rtn []equipslottype.EquipSlotType
+	used := make(map[equipslottype.EquipSlotType]bool)
+	for _, v := range scriptparse.SplitTrim(str, ",") {
+		eqt, exist := equipslottype.String2EquipSlotType(v)
+		if !exist {
+			return nil, fmt.Errorf("unknown EquipSlotType %v", v)
+		}
+		if used[eqt] {
+			return nil, fmt.Errorf("duplicate equip slot %v", v)
+		}
+		used[eqt] = true
+		rtn = append(rtn, eqt)
+	}
+	return rtn, nil
+}
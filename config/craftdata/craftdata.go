@@ -0,0 +1,212 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package craftdata recipe of Crafter fieldobj
+// recipe line format : Name Input[,Input...] Output
+// Input, Output : CarryingObjectType[:SubType][*Count]
+// Equip input must be same equipslottype and faction, SubType not used
+// Potion, Scroll input without SubType match any type
+package craftdata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/lib/scriptparse"
+)
+
+func (mt Material) String() string {
+	switch mt.CarryingObjectType {
+	case carryingobjecttype.Potion:
+		if !mt.AnyType {
+			return fmt.Sprintf("%v:%v*%v", mt.CarryingObjectType, mt.PotionType, mt.Count)
+		}
+	case carryingobjecttype.Scroll:
+		if !mt.AnyType {
+			return fmt.Sprintf("%v:%v*%v", mt.CarryingObjectType, mt.ScrollType, mt.Count)
+		}
+	}
+	return fmt.Sprintf("%v*%v", mt.CarryingObjectType, mt.Count)
+}
+
+// Material input or output of recipe
+type Material struct {
+	CarryingObjectType carryingobjecttype.CarryingObjectType
+	PotionType         potiontype.PotionType // if Potion
+	ScrollType         scrolltype.ScrollType // if Scroll
+	AnyType            bool                  // Potion, Scroll of any type
+	Count              int
+}
+
+func (rcp Recipe) String() string {
+	return fmt.Sprintf("Recipe[%v %v %v]", rcp.Name, rcp.Input, rcp.Output)
+}
+
+type Recipe struct {
+	Name   string
+	Input  []Material
+	Output Material
+}
+
+// EquipInput return equip material of recipe, nil if not exist
+func (rcp *Recipe) EquipInput() *Material {
+	for i, v := range rcp.Input {
+		if v.CarryingObjectType == carryingobjecttype.Equip {
+			return &rcp.Input[i]
+		}
+	}
+	return nil
+}
+
+// FindByName return nil if not found
+func FindByName(rcpList []*Recipe, name string) *Recipe {
+	for _, v := range rcpList {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// ParseRecipeList skip empty and # comment line
+func ParseRecipeList(lines []string) ([]*Recipe, error) {
+	var rtn []*Recipe
+	name2Recipe := make(map[string]bool)
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rcp, err := ParseRecipe(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %v %v", i+1, err)
+		}
+		if name2Recipe[rcp.Name] {
+			return nil, fmt.Errorf("line %v duplicate recipe %v", i+1, rcp.Name)
+		}
+		name2Recipe[rcp.Name] = true
+		rtn = append(rtn, rcp)
+	}
+	return rtn, nil
+}
+
+func ParseRecipe(line string) (*Recipe, error) {
+	fields := scriptparse.SplitTrim(line, " ")
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("need Name Input Output %v", line)
+	}
+	rcp := &Recipe{
+		Name: fields[0],
+	}
+	inList, err := ParseMaterialList(fields[1])
+	if err != nil {
+		return nil, err
+	}
+	equipCount := 0
+	for _, mt := range inList {
+		if mt.CarryingObjectType == carryingobjecttype.Equip {
+			equipCount++
+		}
+	}
+	rcp.Input = inList
+	if len(rcp.Input) == 0 {
+		return nil, fmt.Errorf("no input %v", line)
+	}
+	if equipCount > 1 {
+		return nil, fmt.Errorf("equip input must be one %v", line)
+	}
+	out, err := parseMaterial(fields[2])
+	if err != nil {
+		return nil, err
+	}
+	switch out.CarryingObjectType {
+	case carryingobjecttype.Equip:
+		if equipCount == 0 || out.Count != 1 {
+			return nil, fmt.Errorf("equip output need equip input, count 1 %v", line)
+		}
+	default:
+		if out.AnyType {
+			return nil, fmt.Errorf("output need type %v", line)
+		}
+	}
+	rcp.Output = out
+	return rcp, nil
+}
+
+// ParseMaterialList comma separated material, used in Shop stock too
+func ParseMaterialList(str string) ([]Material, error) {
+	var rtn []Material
+	for _, v := range scriptparse.SplitTrim(str, ",") {
+		mt, err := parseMaterial(v)
+		if err != nil {
+			return nil, err
+		}
+		rtn = append(rtn, mt)
+	}
+	return rtn, nil
+}
+
+// parseMaterial CarryingObjectType[:SubType][*Count]
+func parseMaterial(str string) (Material, error) {
+	mt := Material{
+		Count: 1,
+	}
+	typeStr, countStr := scriptparse.SplitCmdArgstr(str, "*")
+	if countStr != "" {
+		n, err := strconv.Atoi(countStr)
+		if err != nil {
+			return mt, fmt.Errorf("invalid count %v %v", str, err)
+		}
+		if n <= 0 {
+			return mt, fmt.Errorf("invalid count %v", str)
+		}
+		mt.Count = n
+	}
+	coStr, subStr := scriptparse.SplitCmdArgstr(typeStr, ":")
+	co, exist := carryingobjecttype.String2CarryingObjectType(coStr)
+	if !exist {
+		return mt, fmt.Errorf("unknown CarryingObjectType %v", str)
+	}
+	mt.CarryingObjectType = co
+	switch co {
+	default:
+		return mt, fmt.Errorf("not craftable %v", str)
+	case carryingobjecttype.Equip:
+		if subStr != "" {
+			return mt, fmt.Errorf("equip not need subtype %v", str)
+		}
+	case carryingobjecttype.Potion:
+		if subStr == "" {
+			mt.AnyType = true
+			break
+		}
+		pt, exist := potiontype.String2PotionType(subStr)
+		if !exist {
+			return mt, fmt.Errorf("unknown PotionType %v", str)
+		}
+		mt.PotionType = pt
+	case carryingobjecttype.Scroll:
+		if subStr == "" {
+			mt.AnyType = true
+			break
+		}
+		st, exist := scrolltype.String2ScrollType(subStr)
+		if !exist {
+			return mt, fmt.Errorf("unknown ScrollType %v", str)
+		}
+		mt.ScrollType = st
+	}
+	return mt, nil
+}
@@ -74,14 +74,46 @@ const (
 	PotionValue   = 100.0
 	ScrollGram    = 100.0
 	ScrollValue   = 100.0
+	AmmoGram      = 10.0
+	AmmoValue     = 5.0
+	KeyGram       = 10.0
 
 	LvGram = ActiveObjBaseBiasLen/4*EquipABSGram + PotionGram*2 + ScrollGram*1 + MoneyGram*10000
 
 	CarryObjRecycleRate = 0.5
 
+	// crafted equip biaslen = sum of material biaslen * rate
+	CraftEquipBiasLenRate = 0.7
+
+	// equip durability dec by 1 on each hit given(attack slot) or taken(defence slot)
+	// broken at 0, worn equip bias = BiasLen * (rate + (1-rate)*durability/max)
+	EquipDurabilityMax  = 500
+	EquipWornBiasRate   = 0.5
+	EquipRepairCostRate = 0.3 // of value, to repair from 0 to max
+
 	MaxChatLen = 80
 
 	AttackLongLen = 4
+
+	// projectile made by Shoot
+	ProjectileSpeed = 2 // tile per turn
+	ProjectileRange = 8 // tile to move before drop
+
+	// boulder pushed to ao against wall, damage rate of HPMax
+	BoulderCrushRate = 0.2
+
+	NoiseHeardMax = 8 // max heard noise of ao in a turn
+
+	// light level 0 dark ~ 1 full lit, tile darker than LightSeeMin not seen
+	LightSeeMin       = 0.3
+	LightDarkSight    = 1.5     // tile in this len seen without light
+	LightFireResource = 1000000 // fire resource glow over this amount
+	LightFireRadius   = 2.0
+
+	// pet bound to owner ao
+	PetCountMax  = 3
+	PetTameRate  = 0.3 // tame success rate of feeding hp full ao
+	PetFollowLen = 2   // pet stay in this len from owner
 )
 
 // activeobject experience constant
@@ -39,6 +39,8 @@ type GroundConfig struct {
 	TowerServicePortBase int `default:"14100" argname:""`
 	// TowerAdminWebPortBase + towernum
 	TowerAdminWebPortBase int `default:"14200" argname:""`
+	// TowerServiceTCPPortBase + towernum
+	TowerServiceTCPPortBase int `default:"14300" argname:""`
 
 	WebAdminID   string `default:"root" argname:""`
 	WebAdminPass string `default:"password" argname:"" prettystring:"hidevalue"`
@@ -46,6 +48,8 @@ type GroundConfig struct {
 
 	TowerDataFile        string `default:"towerdata.json" argname:""`
 	HighScoreFile        string `default:"highscore.json" argname:""`
+	ScoreLogFile         string `default:"highscore.log" argname:""` // append only score record
+	ScoreSeasonMonth     int    `default:"1" argname:""`             // month count of score season
 	TowerBin             string `default:"towerserver" argname:""`
 	TowerAdminHostBase   string `default:"http://localhost" argname:""`
 	TowerServiceHostBase string `default:"http://localhost" argname:""`
@@ -79,6 +83,7 @@ func (config *GroundConfig) MakeTowerConfig(
 
 	tconfig.ServicePort = config.TowerServicePortBase + portIndex
 	tconfig.AdminPort = config.TowerAdminWebPortBase + portIndex
+	tconfig.ServiceTCPPort = config.TowerServiceTCPPortBase + portIndex
 
 	tconfig.StandAlone = false
 	tconfig.WebAdminID = config.WebAdminID
@@ -123,6 +128,18 @@ func (config *GroundConfig) MakeHighScoreFileFullpath() string {
 	return rtn
 }
 
+func (config *GroundConfig) MakeScoreLogFileFullpath() string {
+	rstr := filepath.Join(config.DataFolder,
+		config.ScoreLogFile,
+	)
+	rtn, err := filepath.Abs(rstr)
+	if err != nil {
+		fmt.Println(rstr, rtn, err.Error())
+		return rstr
+	}
+	return rtn
+}
+
 func (config *GroundConfig) MakeTowerDataFileFullpath() string {
 	rstr := filepath.Join(config.DataFolder,
 		config.TowerDataFile,
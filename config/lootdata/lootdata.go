@@ -0,0 +1,188 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lootdata weighted carryobj table of floor by LootTable terrain cmd
+// type, equip, potion, scroll : Name[:Weight] list, weight default 1
+// empty list is default weight, potion, scroll by MakeRate, other all 1
+// Key is not loot, placed by floor for locked door
+// money mean, stddev and deathdrop multiplied by 1 + depthscale * floor depth
+// deathdrop : mean count of extra carryobj dropped on ao death
+package lootdata
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/enum/equipslottype"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/lib/scriptparse"
+)
+
+func (lt LootTable) String() string {
+	return fmt.Sprintf("LootTable[Money %v/%v DepthScale %v DeathDrop %v]",
+		lt.MoneyMean, lt.MoneyStdDev, lt.DepthScale, lt.DeathDrop)
+}
+
+type LootTable struct {
+	TypeWeight   [carryingobjecttype.CarryingObjectType_Count]int
+	EquipWeight  [equipslottype.EquipSlotType_Count]int
+	PotionWeight [potiontype.PotionType_Count]int
+	ScrollWeight [scrolltype.ScrollType_Count]int
+
+	// sum of weight, rand n for ByWeight fn must in [0,total)
+	TypeTotal   int
+	EquipTotal  int
+	PotionTotal int
+	ScrollTotal int
+
+	MoneyMean   float64
+	MoneyStdDev float64
+	DepthScale  float64
+	DeathDrop   float64
+}
+
+// New parse string args of LootTable terrain cmd
+func New(typeStr, equipStr, potionStr, scrollStr string,
+	moneyMean, moneyStdDev, depthScale, deathDrop float64) (*LootTable, error) {
+
+	if moneyMean < 1 || moneyStdDev < 0 {
+		return nil, fmt.Errorf("invalid money %v %v", moneyMean, moneyStdDev)
+	}
+	if depthScale < 0 {
+		return nil, fmt.Errorf("invalid depthscale %v", depthScale)
+	}
+	if deathDrop < 0 {
+		return nil, fmt.Errorf("invalid deathdrop %v", deathDrop)
+	}
+	lt := &LootTable{
+		MoneyMean:   moneyMean,
+		MoneyStdDev: moneyStdDev,
+		DepthScale:  depthScale,
+		DeathDrop:   deathDrop,
+	}
+	var err error
+	if lt.TypeTotal, err = parseWeight(typeStr, lt.TypeWeight[:],
+		func(s string) (int, bool) {
+			v, exist := carryingobjecttype.String2CarryingObjectType(s)
+			return int(v), exist
+		},
+		func(i int) int {
+			if carryingobjecttype.CarryingObjectType(i) == carryingobjecttype.Key {
+				return 0
+			}
+			return 1
+		},
+	); err != nil {
+		return nil, err
+	}
+	if lt.TypeWeight[carryingobjecttype.Key] > 0 {
+		return nil, fmt.Errorf("Key not allowed in loot %v", typeStr)
+	}
+	if lt.EquipTotal, err = parseWeight(equipStr, lt.EquipWeight[:],
+		func(s string) (int, bool) {
+			v, exist := equipslottype.String2EquipSlotType(s)
+			return int(v), exist
+		},
+		func(i int) int { return 1 },
+	); err != nil {
+		return nil, err
+	}
+	if lt.PotionTotal, err = parseWeight(potionStr, lt.PotionWeight[:],
+		func(s string) (int, bool) {
+			v, exist := potiontype.String2PotionType(s)
+			return int(v), exist
+		},
+		func(i int) int { return potiontype.PotionType(i).MakeRate() },
+	); err != nil {
+		return nil, err
+	}
+	if lt.ScrollTotal, err = parseWeight(scrollStr, lt.ScrollWeight[:],
+		func(s string) (int, bool) {
+			v, exist := scrolltype.String2ScrollType(s)
+			return int(v), exist
+		},
+		func(i int) int { return scrolltype.ScrollType(i).MakeRate() },
+	); err != nil {
+		return nil, err
+	}
+	return lt, nil
+}
+
+// parseWeight fill weight, return sum of weight
+// not listed name is weight 0, default weight if empty list
+func parseWeight(str string, weight []int,
+	str2index func(string) (int, bool),
+	defaultWeight func(int) int) (int, error) {
+
+	list := scriptparse.SplitTrim(str, ",")
+	if len(list) == 0 {
+		for i := range weight {
+			weight[i] = defaultWeight(i)
+		}
+	}
+	for _, v := range list {
+		nameStr, weightStr := scriptparse.SplitCmdArgstr(v, ":")
+		i, exist := str2index(nameStr)
+		if !exist {
+			return 0, fmt.Errorf("unknown name %v", v)
+		}
+		n := 1
+		if weightStr != "" {
+			var err error
+			n, err = strconv.Atoi(weightStr)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid weight %v", v)
+			}
+		}
+		weight[i] += n
+	}
+	sum := 0
+	for _, v := range weight {
+		sum += v
+	}
+	if sum <= 0 {
+		return 0, fmt.Errorf("no weight in %v", str)
+	}
+	return sum, nil
+}
+
+func byWeight(weight []int, n int) int {
+	for i, v := range weight {
+		n -= v
+		if n < 0 {
+			return i
+		}
+	}
+	return len(weight) - 1
+}
+
+func (lt *LootTable) TypeByWeight(n int) carryingobjecttype.CarryingObjectType {
+	return carryingobjecttype.CarryingObjectType(byWeight(lt.TypeWeight[:], n))
+}
+
+func (lt *LootTable) EquipByWeight(n int) equipslottype.EquipSlotType {
+	return equipslottype.EquipSlotType(byWeight(lt.EquipWeight[:], n))
+}
+
+func (lt *LootTable) PotionByWeight(n int) potiontype.PotionType {
+	return potiontype.PotionType(byWeight(lt.PotionWeight[:], n))
+}
+
+func (lt *LootTable) ScrollByWeight(n int) scrolltype.ScrollType {
+	return scrolltype.ScrollType(byWeight(lt.ScrollWeight[:], n))
+}
+
+// DepthRate multiplier by floor depth, 0 is top floor
+func (lt *LootTable) DepthRate(depth int) float64 {
+	return 1 + lt.DepthScale*float64(depth)
+}
@@ -24,7 +24,7 @@ type MultiClientConfig struct {
 	LogLevel      g2log.LL_Type `argname:""`
 	SplitLogLevel g2log.LL_Type `argname:""`
 
-	Net               string `default:"web" argname:""`
+	Net               string `default:"web" argname:""` // web or tcp, for tcp set ConnectToTower to tower ServiceTCPPort (default localhost:14301)
 	ConnectToTower    string `default:"localhost:14101" argname:""`
 	ListenWebInfoPort string `default:":14011" argname:""`
 	PlayerNameBase    string `default:"MC_" argname:""`
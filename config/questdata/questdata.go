@@ -0,0 +1,316 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package questdata quest definition loaded from tower data folder
+// quest line format : Name Giver Objective[,Objective...] Reward[,Reward...]
+// Giver : Floor:FloorName (QuestGiver fieldobj in floor) or NPC:FactionType (near ai ao)
+// Objective : Kill:FactionType*Count, Reach:FloorName, Visit:FloorName(complete)
+// Objective : Deliver:CarryingObjectType:SubType[*Count] (Potion, Scroll only)
+// Reward : Exp:Value, Money:Value, Item:CarryingObjectType:SubType[*Count], Buff:PotionType
+package questdata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kasworld/goguelike/config/craftdata"
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/lib/scriptparse"
+)
+
+type ObjectiveType uint8
+
+const (
+	Kill    ObjectiveType = iota // kill ao of faction
+	Reach                        // enter floor
+	Visit                        // complete visitarea of floor
+	Deliver                      // give carryobj to giver on complete
+)
+
+var objectiveTypeName = [...]string{"Kill", "Reach", "Visit", "Deliver"}
+
+func (ot ObjectiveType) String() string {
+	return objectiveTypeName[ot]
+}
+
+type RewardType uint8
+
+const (
+	Exp   RewardType = iota // battle exp
+	Money                   // to wallet
+	Item                    // potion, scroll to bag
+	Buff                    // buff of potion
+)
+
+var rewardTypeName = [...]string{"Exp", "Money", "Item", "Buff"}
+
+func (rt RewardType) String() string {
+	return rewardTypeName[rt]
+}
+
+func (ob Objective) String() string {
+	switch ob.Type {
+	case Kill:
+		return fmt.Sprintf("%v:%v*%v", ob.Type, ob.Faction, ob.Count)
+	case Reach, Visit:
+		return fmt.Sprintf("%v:%v", ob.Type, ob.FloorName)
+	case Deliver:
+		return fmt.Sprintf("%v:%v", ob.Type, ob.Material)
+	}
+	return fmt.Sprintf("Objective[%v]", ob.Type)
+}
+
+type Objective struct {
+	Type      ObjectiveType
+	Faction   factiontype.FactionType // Kill
+	FloorName string                  // Reach, Visit
+	Material  craftdata.Material      // Deliver
+	Count     int                     // need count to complete
+}
+
+func (rw Reward) String() string {
+	switch rw.Type {
+	case Exp, Money:
+		return fmt.Sprintf("%v:%v", rw.Type, rw.Value)
+	case Item:
+		return fmt.Sprintf("%v:%v", rw.Type, rw.Material)
+	case Buff:
+		return fmt.Sprintf("%v:%v", rw.Type, rw.PotionType)
+	}
+	return fmt.Sprintf("Reward[%v]", rw.Type)
+}
+
+type Reward struct {
+	Type       RewardType
+	Value      float64               // Exp, Money
+	Material   craftdata.Material    // Item
+	PotionType potiontype.PotionType // Buff
+}
+
+func (q Quest) String() string {
+	return fmt.Sprintf("Quest[%v %v %v %v]", q.Name, q.GiverString(), q.Objective, q.Reward)
+}
+
+type Quest struct {
+	Name string
+
+	// one of giver
+	GiverFloor   string                  // QuestGiver fieldobj in floor
+	GiverFaction factiontype.FactionType // near ai ao of faction, if GiverFloor empty
+
+	Objective []Objective
+	Reward    []Reward
+}
+
+func (q *Quest) GiverString() string {
+	if q.GiverFloor != "" {
+		return "Floor:" + q.GiverFloor
+	}
+	return "NPC:" + q.GiverFaction.String()
+}
+
+// FloorNameList floor referenced by quest, must exist in tower
+func (q *Quest) FloorNameList() []string {
+	var rtn []string
+	if q.GiverFloor != "" {
+		rtn = append(rtn, q.GiverFloor)
+	}
+	for _, v := range q.Objective {
+		if v.FloorName != "" {
+			rtn = append(rtn, v.FloorName)
+		}
+	}
+	return rtn
+}
+
+// FindByName return nil if not found
+func FindByName(qList []*Quest, name string) *Quest {
+	for _, v := range qList {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// ParseQuestList skip empty and # comment line
+func ParseQuestList(lines []string) ([]*Quest, error) {
+	var rtn []*Quest
+	name2Quest := make(map[string]bool)
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		q, err := ParseQuest(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %v %v", i+1, err)
+		}
+		if name2Quest[q.Name] {
+			return nil, fmt.Errorf("line %v duplicate quest %v", i+1, q.Name)
+		}
+		name2Quest[q.Name] = true
+		rtn = append(rtn, q)
+	}
+	return rtn, nil
+}
+
+func ParseQuest(line string) (*Quest, error) {
+	fields := scriptparse.SplitTrim(line, " ")
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("need Name Giver Objective Reward %v", line)
+	}
+	q := &Quest{
+		Name: fields[0],
+	}
+	giverType, giverArg := scriptparse.SplitCmdArgstr(fields[1], ":")
+	switch giverType {
+	default:
+		return nil, fmt.Errorf("unknown giver %v", fields[1])
+	case "Floor":
+		if giverArg == "" {
+			return nil, fmt.Errorf("need floor name %v", fields[1])
+		}
+		q.GiverFloor = giverArg
+	case "NPC":
+		ft, exist := factiontype.String2FactionType(giverArg)
+		if !exist {
+			return nil, fmt.Errorf("unknown FactionType %v", fields[1])
+		}
+		q.GiverFaction = ft
+	}
+	for _, v := range scriptparse.SplitTrim(fields[2], ",") {
+		ob, err := parseObjective(v)
+		if err != nil {
+			return nil, err
+		}
+		q.Objective = append(q.Objective, ob)
+	}
+	if len(q.Objective) == 0 {
+		return nil, fmt.Errorf("no objective %v", line)
+	}
+	for _, v := range scriptparse.SplitTrim(fields[3], ",") {
+		rw, err := parseReward(v)
+		if err != nil {
+			return nil, err
+		}
+		q.Reward = append(q.Reward, rw)
+	}
+	if len(q.Reward) == 0 {
+		return nil, fmt.Errorf("no reward %v", line)
+	}
+	return q, nil
+}
+
+func parseObjective(str string) (Objective, error) {
+	ob := Objective{
+		Count: 1,
+	}
+	typeStr, argStr := scriptparse.SplitCmdArgstr(str, ":")
+	switch typeStr {
+	default:
+		return ob, fmt.Errorf("unknown objective %v", str)
+	case "Kill":
+		ob.Type = Kill
+		ftStr, countStr := scriptparse.SplitCmdArgstr(argStr, "*")
+		ft, exist := factiontype.String2FactionType(ftStr)
+		if !exist {
+			return ob, fmt.Errorf("unknown FactionType %v", str)
+		}
+		ob.Faction = ft
+		if countStr != "" {
+			n, err := strconv.Atoi(countStr)
+			if err != nil || n <= 0 {
+				return ob, fmt.Errorf("invalid count %v", str)
+			}
+			ob.Count = n
+		}
+	case "Reach", "Visit":
+		ob.Type = Reach
+		if typeStr == "Visit" {
+			ob.Type = Visit
+		}
+		if argStr == "" {
+			return ob, fmt.Errorf("need floor name %v", str)
+		}
+		ob.FloorName = argStr
+	case "Deliver":
+		ob.Type = Deliver
+		mt, err := parseTypedMaterial(argStr)
+		if err != nil {
+			return ob, err
+		}
+		ob.Material = mt
+		ob.Count = mt.Count
+	}
+	return ob, nil
+}
+
+func parseReward(str string) (Reward, error) {
+	var rw Reward
+	typeStr, argStr := scriptparse.SplitCmdArgstr(str, ":")
+	switch typeStr {
+	default:
+		return rw, fmt.Errorf("unknown reward %v", str)
+	case "Exp", "Money":
+		rw.Type = Exp
+		if typeStr == "Money" {
+			rw.Type = Money
+		}
+		v, err := strconv.ParseFloat(argStr, 64)
+		if err != nil || v <= 0 {
+			return rw, fmt.Errorf("invalid value %v", str)
+		}
+		rw.Value = v
+	case "Item":
+		rw.Type = Item
+		mt, err := parseTypedMaterial(argStr)
+		if err != nil {
+			return rw, err
+		}
+		rw.Material = mt
+	case "Buff":
+		rw.Type = Buff
+		pt, exist := potiontype.String2PotionType(argStr)
+		if !exist {
+			return rw, fmt.Errorf("unknown PotionType %v", str)
+		}
+		if potiontype.GetBuffByPotionType(pt) == nil {
+			return rw, fmt.Errorf("no buff of PotionType %v", str)
+		}
+		rw.PotionType = pt
+	}
+	return rw, nil
+}
+
+// parseTypedMaterial Potion, Scroll with SubType
+func parseTypedMaterial(str string) (craftdata.Material, error) {
+	mtList, err := craftdata.ParseMaterialList(str)
+	if err != nil {
+		return craftdata.Material{}, err
+	}
+	if len(mtList) != 1 {
+		return craftdata.Material{}, fmt.Errorf("need one material %v", str)
+	}
+	mt := mtList[0]
+	switch mt.CarryingObjectType {
+	default:
+		return mt, fmt.Errorf("need Potion or Scroll %v", str)
+	case carryingobjecttype.Potion, carryingobjecttype.Scroll:
+	}
+	if mt.AnyType {
+		return mt, fmt.Errorf("need SubType %v", str)
+	}
+	return mt, nil
+}
@@ -0,0 +1,263 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package skilldata attribute of skilltype loaded from tower data folder
+// skill line format : SkillType Name=Value ...
+// sp, cool, level : sp cost, turn to cast again, level to learn
+// target : Self, Dir(near tile to direction), Pos(tile in range)
+// range : max distance of target Pos, move range of Projectile
+// area : Center, Around, 3x3 tile offset from target tile
+// danger : DangerType of dangerobj at area, None for self buff only
+// selfbuff, targetbuff : StatusOpType[:Arg][*Turn][,...]
+// recover : true if ai use on low hp
+package skilldata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kasworld/goguelike/enum/condition"
+	"github.com/kasworld/goguelike/enum/dangertype"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/skilltype"
+	"github.com/kasworld/goguelike/enum/statusoptype"
+	"github.com/kasworld/goguelike/lib/scriptparse"
+)
+
+// TargetType how skill select center tile
+type TargetType uint8
+
+const (
+	TargetSelf TargetType = iota // tile of caster
+	TargetDir                    // near tile to direction
+	TargetPos                    // tile in CastRange
+)
+
+var targetTypeName = [...]string{"Self", "Dir", "Pos"}
+
+func (tt TargetType) String() string {
+	return targetTypeName[tt]
+}
+
+var areaCenter = [][2]int{{0, 0}}
+
+var areaAround = [][2]int{
+	{-1, -1}, {0, -1}, {1, -1},
+	{-1, 0}, {1, 0},
+	{-1, 1}, {0, 1}, {1, 1},
+}
+
+var area3x3 = append([][2]int{{0, 0}}, areaAround...)
+
+var name2Area = map[string][][2]int{
+	"Center": areaCenter,
+	"Around": areaAround,
+	"3x3":    area3x3,
+}
+
+func (sk Skill) String() string {
+	return fmt.Sprintf("Skill[%v sp:%v cool:%v level:%v %v %v]",
+		sk.SkillType, sk.SPCost, sk.CoolTurn, sk.LearnLevel, sk.Target, sk.DangerType)
+}
+
+type Skill struct {
+	SkillType  skilltype.SkillType
+	SPCost     float64
+	CoolTurn   int // turn to wait before cast again
+	LearnLevel int // ao learn skill at level
+	Target     TargetType
+	CastRange  int                   // max distance of TargetPos
+	Area       [][2]int              // tile offset from center tile to add dangerobj
+	DangerType dangertype.DangerType // None : no dangerobj, only self buff
+	SelfBuff   []statusoptype.OpArg  // applied to caster
+	TargetBuff []statusoptype.OpArg  // applied to ao affected by dangerobj of skill
+	ForRecover bool                  // ai use when hp low, else use to enemy
+}
+
+// SkillList index by skilltype, None not used
+type SkillList [skilltype.SkillType_Count]Skill
+
+// LearnedByLevel skill list can use at level, skip not loaded skill
+func (sl *SkillList) LearnedByLevel(level int) []skilltype.SkillType {
+	var rtn []skilltype.SkillType
+	for i := skilltype.SkillType(1); i < skilltype.SkillType_Count; i++ {
+		if sl[i].SkillType == i && sl[i].LearnLevel <= level {
+			rtn = append(rtn, i)
+		}
+	}
+	return rtn
+}
+
+// ParseSkillList skip empty and # comment line
+// all skilltype except None must be defined once
+func ParseSkillList(lines []string) (SkillList, error) {
+	var rtn SkillList
+	var defined [skilltype.SkillType_Count]bool
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sk, err := ParseSkill(line)
+		if err != nil {
+			return rtn, fmt.Errorf("line %v %v", i+1, err)
+		}
+		if defined[sk.SkillType] {
+			return rtn, fmt.Errorf("line %v duplicate skill %v", i+1, sk.SkillType)
+		}
+		defined[sk.SkillType] = true
+		rtn[sk.SkillType] = sk
+	}
+	for i := skilltype.SkillType(1); i < skilltype.SkillType_Count; i++ {
+		if !defined[i] {
+			return rtn, fmt.Errorf("skill not defined %v", i)
+		}
+	}
+	return rtn, nil
+}
+
+func ParseSkill(line string) (Skill, error) {
+	sk := Skill{}
+	stStr, argStr := scriptparse.SplitCmdArgstr(line, " ")
+	st, exist := skilltype.String2SkillType(stStr)
+	if !exist || st == skilltype.None {
+		return sk, fmt.Errorf("unknown SkillType %v", line)
+	}
+	sk.SkillType = st
+	nameList, name2value, err := scriptparse.Split2ListMap(argStr, " ", "=")
+	if err != nil {
+		return sk, err
+	}
+	for _, name := range nameList {
+		value := name2value[name]
+		switch name {
+		default:
+			return sk, fmt.Errorf("unknown arg %v %v", name, line)
+		case "sp":
+			sk.SPCost, err = strconv.ParseFloat(value, 64)
+		case "cool":
+			sk.CoolTurn, err = strconv.Atoi(value)
+		case "level":
+			sk.LearnLevel, err = strconv.Atoi(value)
+		case "range":
+			sk.CastRange, err = strconv.Atoi(value)
+		case "recover":
+			sk.ForRecover, err = strconv.ParseBool(value)
+		case "target":
+			sk.Target, err = parseTargetType(value)
+		case "area":
+			area, exist := name2Area[value]
+			if !exist {
+				err = fmt.Errorf("unknown area %v", value)
+			}
+			sk.Area = area
+		case "danger":
+			dt, exist := dangertype.String2DangerType(value)
+			if !exist {
+				err = fmt.Errorf("unknown DangerType %v", value)
+			}
+			sk.DangerType = dt
+		case "selfbuff":
+			sk.SelfBuff, err = ParseOpArgList(value)
+		case "targetbuff":
+			sk.TargetBuff, err = ParseOpArgList(value)
+		}
+		if err != nil {
+			return sk, fmt.Errorf("invalid %v %v", name, err)
+		}
+	}
+	if sk.DangerType != dangertype.None && len(sk.Area) == 0 {
+		return sk, fmt.Errorf("dangerobj need area %v", line)
+	}
+	if sk.Target == TargetPos && sk.CastRange <= 0 {
+		return sk, fmt.Errorf("target Pos need range %v", line)
+	}
+	if sk.DangerType == dangertype.Projectile && (sk.Target != TargetDir || sk.CastRange <= 0) {
+		return sk, fmt.Errorf("projectile need target Dir, range %v", line)
+	}
+	return sk, nil
+}
+
+func parseTargetType(str string) (TargetType, error) {
+	for i, v := range targetTypeName {
+		if v == str {
+			return TargetType(i), nil
+		}
+	}
+	return TargetSelf, fmt.Errorf("unknown target %v", str)
+}
+
+// ParseOpArgList comma separated StatusOpType[:Arg][*Turn]
+// Turn is count of buff turn, default 1
+func ParseOpArgList(str string) ([]statusoptype.OpArg, error) {
+	var rtn []statusoptype.OpArg
+	for _, v := range scriptparse.SplitTrim(str, ",") {
+		opStr, turnStr := scriptparse.SplitCmdArgstr(v, "*")
+		turn := 1
+		if turnStr != "" {
+			n, err := strconv.Atoi(turnStr)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid turn %v", v)
+			}
+			turn = n
+		}
+		oa, err := parseOpArg(opStr)
+		if err != nil {
+			return nil, err
+		}
+		rtn = append(rtn, statusoptype.Repeat(turn, oa)...)
+	}
+	return rtn, nil
+}
+
+// parseOpArg arg type is same to buff effect of activeobject
+func parseOpArg(str string) (statusoptype.OpArg, error) {
+	opStr, argStr := scriptparse.SplitCmdArgstr(str, ":")
+	op, exist := statusoptype.String2StatusOpType(opStr)
+	if !exist {
+		return statusoptype.OpArg{}, fmt.Errorf("unknown StatusOpType %v", str)
+	}
+	oa := statusoptype.OpArg{Op: op}
+	switch op {
+	default:
+		if argStr != "" {
+			return oa, fmt.Errorf("arg not used %v", str)
+		}
+	case statusoptype.AddHP, statusoptype.AddSP,
+		statusoptype.AddHPRate, statusoptype.AddSPRate,
+		statusoptype.ModSight:
+		v, err := strconv.ParseFloat(argStr, 64)
+		if err != nil {
+			return oa, fmt.Errorf("invalid arg %v %v", str, err)
+		}
+		oa.Arg = v
+	case statusoptype.IncFaction:
+		v, err := strconv.Atoi(argStr)
+		if err != nil {
+			return oa, fmt.Errorf("invalid arg %v %v", str, err)
+		}
+		oa.Arg = v
+	case statusoptype.SetFaction:
+		v, exist := factiontype.String2FactionType(argStr)
+		if !exist {
+			return oa, fmt.Errorf("unknown FactionType %v", str)
+		}
+		oa.Arg = v
+	case statusoptype.SetCondition:
+		v, exist := condition.String2Condition(argStr)
+		if !exist {
+			return oa, fmt.Errorf("unknown Condition %v", str)
+		}
+		oa.Arg = v
+	}
+	return oa, nil
+}
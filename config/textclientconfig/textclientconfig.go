@@ -22,6 +22,7 @@ type TextClientConfig struct {
 	SplitLogLevel g2log.LL_Type `argname:""`
 	PidFilename   string        `default:"/tmp/textclient.pid" argname:""`
 
+	Net            string `default:"web" argname:""` // web or tcp, for tcp set ConnectToTower to tower ServiceTCPPort (default localhost:14301)
 	ConnectToTower string `default:"localhost:14101" argname:""`
 	PlayerName     string `default:"Player" argname:""`
 }
@@ -26,6 +26,7 @@ type TowerConfig struct {
 	BaseLogDir       string        `default:"/tmp/"  argname:""`
 	DataFolder       string        `default:"./serverdata" argname:""`
 	ClientDataFolder string        `default:"./clientdata" argname:""`
+	AOPersistentDir  string        `default:"./aopersistent" argname:""` // empty to disable save user ao
 	GroundRPC        string        `default:"localhost:14002"  argname:""`
 	WebAdminID       string        `default:"root" argname:""`
 	WebAdminPass     string        `default:"password" argname:"" prettystring:"hidevalue"`
@@ -33,13 +34,21 @@ type TowerConfig struct {
 
 	// config for each tower
 	ServicePort           int     `default:"14101"  argname:""`
+	ServiceTCPPort        int     `default:"14301"  argname:""` // raw tcp for bot, 0 to disable
 	AdminPort             int     `default:"14201"  argname:""`
 	ScriptFilename        string  `default:"start" argname:""`
 	TowerName             string  `default:"Default" argname:""`
 	ConcurrentConnections int     `default:"10000" argname:""`
 	TurnPerSec            float64 `default:"2.0" argname:""`
 	StandAlone            bool    `default:"true" argname:""`
-	ServiceHostBase       string  `default:"http://localhost" argname:""` // for StandAlone mode
+	UseSnapshot           bool    `default:"false" argname:""` // load floors from snapshot at start, save at end
+	RecordTurn            bool    `default:"false" argname:""` // record floor turn to replay
+	ObjectListKeyframe    int     `default:"20" argname:""`    // turn between full ObjectList noti, 0 to disable delta
+	CmdRatePerSec         float64 `default:"20" argname:""`    // token bucket of each command per connection, 0 to disable
+	CmdRateBurst          float64 `default:"40" argname:""`
+	CmdRateBudget         string  `default:"Chat=0.5/3,AchieveInfo=0.2/2" argname:""` // override, CmdName=RatePerSec/Burst,...
+	CmdRateKickCount      int     `default:"100" argname:""`                          // kick connection over rate limit in a minute, 0 to disable
+	ServiceHostBase       string  `default:"http://localhost" argname:""`             // for StandAlone mode
 }
 
 func (config *TowerConfig) MakeLogDir() string {
@@ -79,6 +88,42 @@ func (config *TowerConfig) MakeTowerFileFullpath() string {
 	return rtn
 }
 
+func (config *TowerConfig) MakeSnapshotFileFullpath() string {
+	rstr := filepath.Join(config.BaseLogDir,
+		fmt.Sprintf("goguelike_tower_%v.snapshot",
+			config.TowerName),
+	)
+	rtn, err := filepath.Abs(rstr)
+	if err != nil {
+		fmt.Println(rstr, rtn, err.Error())
+		return rstr
+	}
+	return rtn
+}
+
+func (config *TowerConfig) MakeTurnRecordFileFullpath() string {
+	rstr := filepath.Join(config.BaseLogDir,
+		fmt.Sprintf("goguelike_tower_%v.turnrecord",
+			config.TowerName),
+	)
+	rtn, err := filepath.Abs(rstr)
+	if err != nil {
+		fmt.Println(rstr, rtn, err.Error())
+		return rstr
+	}
+	return rtn
+}
+
+func (config *TowerConfig) MakeAOPersistentDirFullpath() string {
+	rstr := filepath.Join(config.AOPersistentDir, config.TowerName)
+	rtn, err := filepath.Abs(rstr)
+	if err != nil {
+		fmt.Println(rstr, rtn, err.Error())
+		return rstr
+	}
+	return rtn
+}
+
 func (config *TowerConfig) MakeOutfileFullpath() string {
 	rstr := fmt.Sprintf("goguelike_tower_%v.out",
 		config.TowerName)
@@ -20,6 +20,15 @@ import (
 var ViewportXYLenList = findnear.NewXYLenList(
 	gameconst.ClientViewPortW, gameconst.ClientViewPortH)[:gameconst.ViewPortWH]
 
+// ViewportXY2Index viewport x,y to index of ViewportXYLenList
+var ViewportXY2Index = func() map[[2]int]int {
+	rtn := make(map[[2]int]int, len(ViewportXYLenList))
+	for i, v := range ViewportXYLenList {
+		rtn[[2]int{v.X, v.Y}] = i
+	}
+	return rtn
+}()
+
 // same order with ViewportXYLenList
 type ViewportSight2 [gameconst.ViewPortWH]float32
 type ViewportTileArea2 [gameconst.ViewPortWH]tile_flag.TileFlag
@@ -35,4 +35,9 @@ var attrib = [AIPlan_Count]struct {
 	Attack:         {htmlcolors.Yellow},
 	MoveStraight3:  {htmlcolors.Yellow},
 	MoveStraight5:  {htmlcolors.Yellow},
+	CastSkill:      {htmlcolors.Yellow},
+	Investigate:    {htmlcolors.Yellow},
+	OpenDoor:       {htmlcolors.Yellow},
+	FollowOwner:    {htmlcolors.Yellow},
+	GuardOwner:     {htmlcolors.Yellow},
 }
@@ -0,0 +1,34 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chattype
+
+import "time"
+
+// IsValid check client sent chattype before use as index
+func (ct ChatType) IsValid() bool {
+	return ct >= 0 && ct < ChatType_Count
+}
+
+// MinInterval min time between chat of same type by an ao
+func (ct ChatType) MinInterval() time.Duration {
+	return attrib[ct].MinInterval
+}
+
+var attrib = [ChatType_Count]struct {
+	MinInterval time.Duration
+}{
+	Near:    {time.Second},
+	Whisper: {time.Second},
+	Floor:   {time.Second * 3},
+	Tower:   {time.Second * 10},
+	Faction: {time.Second * 5},
+}
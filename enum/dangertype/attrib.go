@@ -32,4 +32,6 @@ var attrib = [DangerType_Count]struct {
 	LongAttack:       {1, htmlcolors.FireBrick},
 	RotateLineAttack: {1, htmlcolors.DeepPink},
 	MineExplode:      {1, htmlcolors.Orange},
+	Projectile:       {1, htmlcolors.Gold},
+	SkillArea:        {1, htmlcolors.MediumPurple},
 }
@@ -39,6 +39,17 @@ func (et EquipSlotType) BaseLen() float64 {
 	return float64(gameconst.ActiveObjBaseBiasLen) * attrib[et].CreateBiasRate
 }
 
+// MaterialLightRadius light emitted by equip made of material, 0 if not light source
+func MaterialLightRadius(material string) float64 {
+	return materialLightRadius[material]
+}
+
+var materialLightRadius = map[string]float64{
+	"Crystal":   2,
+	"Diamond":   3,
+	"Glowstone": 5,
+}
+
 var attrib = [EquipSlotType_Count]struct {
 	Attack         bool
 	Defence        bool
@@ -77,7 +88,7 @@ var attrib = [EquipSlotType_Count]struct {
 		},
 		[]string{
 			"Wood", "Bone", "Hide", "Silk",
-			"Stone",
+			"Stone", "Glowstone",
 			"Copper", "Brass", "Bronze", "Iron", "Steel", "Duralumin", "Silver", "Gold", "Platinum", "Titanium",
 		},
 	},
@@ -128,7 +139,7 @@ var attrib = [EquipSlotType_Count]struct {
 		},
 		[]string{
 			"Wood", "Bone",
-			"Stone", "Coral", "Crystal", "Ruby", "Diamond",
+			"Stone", "Glowstone", "Coral", "Crystal", "Ruby", "Diamond",
 			"Copper", "Brass", "Bronze", "Iron", "Steel", "Duralumin", "Silver", "Gold", "Platinum", "Titanium",
 		},
 	},
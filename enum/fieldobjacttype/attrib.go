@@ -44,6 +44,10 @@ func (v FieldObjActType) NeedTANoti() bool {
 	return attrib[v].NeedTANoti
 }
 
+func (v FieldObjActType) LightRadius() float64 {
+	return lightRadius[v]
+}
+
 var attrib = [FieldObjActType_Count]struct {
 	Rune        string
 	TrapNoti    bool // send noti on step
@@ -60,6 +64,12 @@ var attrib = [FieldObjActType_Count]struct {
 	PortalOut:       {"?", false, false, 0.0, false, false, htmlcolors.MediumVioletRed},
 	PortalAutoIn:    {"?", false, true, 1.0, true, true, htmlcolors.MediumVioletRed},
 	RecycleCarryObj: {"?", false, false, 0.0, false, false, htmlcolors.Green},
+	CraftCarryObj:   {"?", false, false, 0.0, false, false, htmlcolors.DarkGoldenrod},
+	Shop:            {"?", false, false, 0.0, false, false, htmlcolors.Gold},
+	RepairEquip:     {"?", false, false, 0.0, false, false, htmlcolors.SteelBlue},
+	QuestGiver:      {"?", false, false, 0.0, false, false, htmlcolors.MediumOrchid},
+	Door:            {"?", false, false, 0.0, false, false, htmlcolors.SaddleBrown},
+	Boulder:         {"?", false, false, 0.0, false, false, htmlcolors.DimGray},
 	Teleport:        {"?", true, true, 0.1, true, true, htmlcolors.Red},
 
 	ForgetFloor:    {"?", true, true, 0.2, false, true, htmlcolors.OrangeRed},
@@ -84,6 +94,19 @@ var attrib = [FieldObjActType_Count]struct {
 	Mine:             {"?", true, true, 1.0, false, false, htmlcolors.Orange},
 }
 
+// light emitted by fieldobj
+var lightRadius = [FieldObjActType_Count]float64{
+	PortalInOut:     3,
+	PortalIn:        3,
+	PortalOut:       3,
+	PortalAutoIn:    3,
+	RecycleCarryObj: 2,
+	CraftCarryObj:   4,
+	Shop:            4,
+	RepairEquip:     3,
+	QuestGiver:      3,
+}
+
 // try act on fieldobj
 var ClientData = [FieldObjActType_Count]struct {
 	ActOn bool
@@ -95,6 +118,12 @@ var ClientData = [FieldObjActType_Count]struct {
 	PortalOut:        {true, "portal out only"},
 	PortalAutoIn:     {false, "portal auto in oneway"},
 	RecycleCarryObj:  {true, "recycle carryobj to money"},
+	CraftCarryObj:    {true, "craft carryobj by recipe"},
+	Shop:             {true, "buy carryobj with money"},
+	RepairEquip:      {true, "repair equip durability with money"},
+	QuestGiver:       {true, "accept and complete quest"},
+	Door:             {true, "open close door, locked need key"},
+	Boulder:          {false, "push by move, crush ao against wall"},
 	Teleport:         {false, "teleport somewhere"},
 	ForgetFloor:      {false, "forget current floor"},
 	ForgetOneFloor:   {false, "forget some floor you visited"},
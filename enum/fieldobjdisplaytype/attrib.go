@@ -32,5 +32,11 @@ var attrib = [FieldObjDisplayType_Count]struct {
 	PortalAutoIn:     {"{+}", htmlcolors.Black},
 	PortalOut:        {"[-]", htmlcolors.Black},
 	Recycler:         {"*", htmlcolors.Black},
+	Crafter:          {"&", htmlcolors.Black},
+	Shop:             {"$", htmlcolors.Black},
+	Repairer:         {"%", htmlcolors.Black},
+	QuestGiver:       {"!", htmlcolors.Black},
+	Door:             {"+", htmlcolors.Black},
+	Boulder:          {"0", htmlcolors.Black},
 	RotateLineAttack: {"-|-", htmlcolors.Black},
 }
@@ -0,0 +1,28 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noisetype
+
+// Radius default noise radius in tile, damped by distance and BlockNoise of tile between
+// Move has no default, use MoveNoise of tile moved onto
+func (nt NoiseType) Radius() float64 {
+	return attrib[nt].Radius
+}
+
+var attrib = [NoiseType_Count]struct {
+	Radius float64
+}{
+	Attack:  {8},
+	Explode: {20},
+	Door:    {10},
+	Move:    {0},
+	Rumble:  {15},
+}
@@ -43,6 +43,8 @@ var attrib = [ScrollType_Count]struct {
 	Empty:                  {"#", htmlcolors.PaleGreen, 10},
 	FloorMap:               {"#", htmlcolors.LimeGreen, 1},
 	Teleport:               {"#", htmlcolors.DarkSeaGreen, 5},
+	Identify:               {"#", htmlcolors.SeaGreen, 5},
+	SummonPet:              {"#", htmlcolors.MediumSeaGreen, 2},
 	FactionRnd:             {"#", htmlcolors.Green, 5},
 	FactionNext:            {"#", htmlcolors.Green, 5},
 	FactionBorn:            {"#", htmlcolors.Green, 5},
@@ -193,6 +195,7 @@ var AIRecycleMap = map[ScrollType]bool{
 	Empty:    true,
 	FloorMap: false,
 	Teleport: false,
+	Identify: false,
 
 	FactionRnd:             true,
 	FactionNext:            true,
@@ -108,3 +108,24 @@ var TileScrollAttrib = [Tile_Count]struct {
 	Window: {false, 0, 0, 0, 0},
 	Door:   {false, 0, 0, 0, 0},
 }
+
+// TileNoiseAttrib noise radius of Move onto tile, noise damp of tile between noise and listener
+var TileNoiseAttrib = [Tile_Count]struct {
+	MoveNoise  float64
+	BlockNoise float64
+}{
+	Swamp: {3, 0},
+	Sea:   {4, 0},
+	Ice:   {4, 0},
+	Stone: {2, 0},
+	Tree:  {2, 1},
+	Door:  {6, 2},
+
+	Window: {0, 3},
+	Wall:   {0, 6},
+}
+
+// TileLightRadius light emitted by tile
+var TileLightRadius = [Tile_Count]float64{
+	Magma: 4,
+}
@@ -60,3 +60,39 @@ var overrideList = []TileFlag{
 	tile.Window: WallFlag,
 	tile.Door:   WallFlag | WindowFlag,
 }
+
+// MoveNoise max noise radius of tiles, by Move onto
+func (t TileFlag) MoveNoise() float64 {
+	rtn := 0.0
+	for i := 0; i < tile.Tile_Count; i++ {
+		tlt := tile.Tile(i)
+		if t.TestByTile(tlt) && tile.TileNoiseAttrib[tlt].MoveNoise > rtn {
+			rtn = tile.TileNoiseAttrib[tlt].MoveNoise
+		}
+	}
+	return rtn
+}
+
+// BlockNoise sum of noise damp of tiles
+func (t TileFlag) BlockNoise() float64 {
+	rtn := 0.0
+	for i := 0; i < tile.Tile_Count; i++ {
+		tlt := tile.Tile(i)
+		if t.TestByTile(tlt) {
+			rtn += tile.TileNoiseAttrib[tlt].BlockNoise
+		}
+	}
+	return rtn
+}
+
+// LightRadius max light radius of tiles
+func (t TileFlag) LightRadius() float64 {
+	rtn := 0.0
+	for i := 0; i < tile.Tile_Count; i++ {
+		tlt := tile.Tile(i)
+		if t.TestByTile(tlt) && tile.TileLightRadius[tlt] > rtn {
+			rtn = tile.TileLightRadius[tlt]
+		}
+	}
+	return rtn
+}
@@ -111,3 +111,28 @@ func (bm *BuffManager) ToPacket_ActiveObjBuffList() []*c2t_obj.ActiveObjBuff {
 	}
 	return rtn
 }
+
+// GetBuffListCopy for turn record
+func (bm *BuffManager) GetBuffListCopy() []ActiveBuff {
+	bm.Mutex.RLock()
+	defer bm.Mutex.RUnlock()
+	rtn := make([]ActiveBuff, 0, len(bm.BuffList))
+	for _, v := range bm.BuffList {
+		if v == nil {
+			continue
+		}
+		rtn = append(rtn, *v)
+	}
+	return rtn
+}
+
+// SetBuffList replace all buff, for turn replay
+func (bm *BuffManager) SetBuffList(buffList []ActiveBuff) {
+	bm.Mutex.Lock()
+	defer bm.Mutex.Unlock()
+	bm.BuffList = make([]*ActiveBuff, len(buffList))
+	for i := range buffList {
+		v := buffList[i]
+		bm.BuffList[i] = &v
+	}
+}
@@ -14,32 +14,43 @@ package activeobject
 
 import (
 	"fmt"
+	"sync"
 	"time"
 	"unsafe"
 
 	"github.com/kasworld/g2rand"
+	"github.com/kasworld/goguelike/config/bossdata"
 	"github.com/kasworld/goguelike/config/gameconst"
 	"github.com/kasworld/goguelike/config/gamedata"
+	"github.com/kasworld/goguelike/config/viewportdata"
 	"github.com/kasworld/goguelike/enum/achievetype"
 	"github.com/kasworld/goguelike/enum/achievetype_vector"
 	"github.com/kasworld/goguelike/enum/aotype"
+	"github.com/kasworld/goguelike/enum/chattype"
 	"github.com/kasworld/goguelike/enum/condition_vector"
 	"github.com/kasworld/goguelike/enum/factiontype"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype_vector"
+	"github.com/kasworld/goguelike/enum/petorder"
 	"github.com/kasworld/goguelike/enum/potiontype"
 	"github.com/kasworld/goguelike/enum/potiontype_vector"
 	"github.com/kasworld/goguelike/enum/scrolltype"
 	"github.com/kasworld/goguelike/enum/scrolltype_vector"
+	"github.com/kasworld/goguelike/enum/skilltype"
+	"github.com/kasworld/goguelike/enum/skilltype_vector"
 	"github.com/kasworld/goguelike/enum/towerachieve_vector"
 	"github.com/kasworld/goguelike/game/activeobject/activebuff"
 	"github.com/kasworld/goguelike/game/activeobject/aoturndata"
 	"github.com/kasworld/goguelike/game/activeobject/serverai2"
 	"github.com/kasworld/goguelike/game/activeobject/turnresult"
 	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/aoquest"
 	"github.com/kasworld/goguelike/game/bias"
 	"github.com/kasworld/goguelike/game/carryingobject"
 	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/identify"
 	"github.com/kasworld/goguelike/game/inventory"
+	"github.com/kasworld/goguelike/game/noise"
+	"github.com/kasworld/goguelike/game/objlistdelta"
 	"github.com/kasworld/goguelike/game/visitarea"
 	"github.com/kasworld/goguelike/lib/g2log"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd_stats"
@@ -65,13 +76,19 @@ type ActiveObject struct {
 	log *g2log.LogBase `prettystring:"hide"`
 
 	uuid        string // aouuid
+	sessionUUID string // user ao only, key of aopersistent store
 	nickName    string
 	homefloor   gamei.FloorI
 	aoType      aotype.ActiveObjType
 	bornFaction factiontype.FactionType          `prettystring:"simple"`
 	clientConn  *c2t_serveconnbyte.ServeConnByte // for clientConn conn
 	ai          *serverai2.ServerAI              // for server side ai
+	boss        *bossdata.Boss                   // nil if not boss
 	isAIInUse   bool
+	createTime  time.Time `prettystring:"simple"` // first made, kept in aopersistent
+
+	// ObjectList sent to clientConn, for delta noti
+	objListSender objlistdelta.Sender `prettystring:"simple"`
 
 	towerAchieveStat *towerachieve_vector.TowerAchieveVector      `prettystring:"simple"`
 	achieveStat      achievetype_vector.AchieveTypeVector         `prettystring:"simple"`
@@ -80,6 +97,29 @@ type ActiveObject struct {
 	foActStat        fieldobjacttype_vector.FieldObjActTypeVector `prettystring:"simple"`
 	aoActionStat     c2t_idcmd_stats.CommandIDStat                `prettystring:"simple"`
 	conditionStat    condition_vector.ConditionVector             `prettystring:"simple"`
+	identified       identify.Knowledge                           `prettystring:"simple"`
+
+	// accepted quest in accept order, kept in aopersistent
+	questList []*aoquest.Progress `prettystring:"simple"`
+
+	// faction, diplomacy version of last Diplomacy noti, used in floor goroutine
+	diplomacyNotiSent    bool
+	diplomacyNotiFaction factiontype.FactionType
+	diplomacyNotiVersion int
+
+	// pet relation, used in floor, tower goroutine
+	petMutex  sync.RWMutex        `prettystring:"hide"`
+	petOwner  gamei.ActiveObjectI `prettystring:"simple"` // nil if not pet
+	petOrder  petorder.PetOrder
+	petTarget string                // ao uuid of petorder.Attack
+	petList   []gamei.ActiveObjectI `prettystring:"simple"`
+	// made by SummonPet scroll, removed from tower when owner leave
+	petSummoned bool
+
+	// noise heard in last turn, set by floor, used by serverai
+	heardNoise []noise.Heard `prettystring:"simple"`
+	// sight line with unlit tile blocked, set by floor, used by serverai
+	sightMat *viewportdata.ViewportSight2 `prettystring:"hide"`
 
 	uuid2VisitArea     *visitarea.ID2VisitArea `prettystring:"simple"`
 	currrentFloor      gamei.FloorI
@@ -87,6 +127,8 @@ type ActiveObject struct {
 
 	chat     string
 	chatTime time.Time `prettystring:"simple"`
+	// last chat time of each chattype, for rate limit
+	chatTypeTime [chattype.ChatType_Count]time.Time `prettystring:"hide"`
 
 	ap float64 // action point to use,  -inf ~ 1
 	// battle relate
@@ -98,6 +140,9 @@ type ActiveObject struct {
 	buffManager  *activebuff.BuffManager
 	expCopy4Sort float64
 
+	learnedSkill  [skilltype.SkillType_Count]bool  `prettystring:"simple"`
+	skillCoolTurn skilltype_vector.SkillTypeVector `prettystring:"simple"` // remain turn to cast again
+
 	// valid in a Turn
 	needTANoti     bool // ao move, sight change , floor change, floor aged .. etc
 	turnResultList []turnresult.TurnResult
@@ -121,6 +166,7 @@ func newActiveObj(
 		towerAchieveStat: towerAchieveStat,
 		uuid:             uuidstr.New(),
 		homefloor:        homefloor,
+		createTime:       time.Now(),
 
 		// battle
 		hp:             100,
@@ -169,12 +215,28 @@ func NewSystemActiveObj(seed int64, homefloor gamei.FloorI,
 	return ao
 }
 
+// NewReplayActiveObj make ao with recorded uuid for turn replay
+// no ai run, no client, state is set by SetStateForReplay every turn
+func NewReplayActiveObj(uuid string, nickname string, homefloor gamei.FloorI,
+	l *g2log.LogBase) *ActiveObject {
+	ao := newActiveObj(0, homefloor, l, new(towerachieve_vector.TowerAchieveVector))
+	ao.uuid = uuid
+	ao.nickName = nickname
+	ao.isAIInUse = false
+	ao.aoType = aotype.System
+	ao.ai = serverai2.New(ao.rnd.Int63(), ao, ao.log)
+	return ao
+}
+
 func (ao *ActiveObject) Cleanup() {
 	ao.isAIInUse = false
 	ao.ai.Cleanup()
 }
 
 func (ao *ActiveObject) Noti_Rebirth() {
+	if ao.boss != nil {
+		ao.resetBoss()
+	}
 	ao.hp = ao.AOTurnData.HPMax * gameconst.RebirthHPRate
 	ao.sp = ao.AOTurnData.SPMax * gameconst.RebirthSPRate
 	ao.SetNeedTANoti()
@@ -211,6 +273,8 @@ func (ao *ActiveObject) Suspend() {
 
 func (ao *ActiveObject) Resume(conn *c2t_serveconnbyte.ServeConnByte) {
 	ao.clientConn = conn
+	ao.objListSender.Reset()
+	ao.diplomacyNotiSent = false
 }
 
 /////////////
@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/kasworld/goguelike/config/craftdata"
 	"github.com/kasworld/goguelike/config/leveldata"
 	"github.com/kasworld/goguelike/enum/achievetype"
 	"github.com/kasworld/goguelike/enum/aotype"
@@ -109,6 +110,8 @@ func (ao *ActiveObject) updateActiveObjTurnData() {
 // can die ao
 func (ao *ActiveObject) ApplyTurnAct() {
 	ao.updateActiveObjTurnData()
+	ao.updateLearnedSkill()
+	ao.coolSkill()
 	intLv := int(ao.AOTurnData.Level)
 	if ao.IsAlive() {
 		hpLvMax := leveldata.MaxHP(intLv)
@@ -187,6 +190,10 @@ func (ao *ActiveObject) DoPickup(po gamei.CarryingObjectI) error {
 		if err == nil {
 			ao.GetAchieveStat().Add(achievetype.MoneyGet, float64(po.GetValue()))
 		}
+	case gamei.AmmoI:
+		ao.GetInven().AddAmmo(po.(gamei.AmmoI).GetCount())
+	case gamei.KeyI:
+		ao.GetInven().AddKey(po.(gamei.KeyI).GetKeyID())
 	case gamei.EquipObjI:
 		err = ao.GetInven().AddToBag(po)
 	case gamei.PotionI:
@@ -231,6 +238,7 @@ func (ao *ActiveObject) DoUseCarryObj(poid string) error {
 	case gamei.PotionI:
 		ao.achieveStat.Inc(achievetype.UseCarryObj)
 		ao.potionStat.Inc(o.GetPotionType())
+		ao.identified.IdentifyPotion(o.GetPotionType())
 		tb := potiontype.GetBuffByPotionType(o.GetPotionType())
 		if tb != nil { // potion data exist
 			ao.buffManager.Add(o.GetPotionType().String(), false, false, tb)
@@ -240,6 +248,7 @@ func (ao *ActiveObject) DoUseCarryObj(poid string) error {
 	case gamei.ScrollI:
 		ao.achieveStat.Inc(achievetype.UseCarryObj)
 		ao.scrollStat.Inc(o.GetScrollType())
+		ao.identified.IdentifyScroll(o.GetScrollType())
 		tb := scrolltype.GetBuffByScrollType(o.GetScrollType())
 		if tb != nil { // scroll data exist
 			ao.buffManager.Add(o.GetScrollType().String(), false, false, tb)
@@ -253,11 +262,25 @@ func (ao *ActiveObject) DoUseCarryObj(poid string) error {
 			return ao.MakeFloorComplete(ao.currrentFloor)
 		case scrolltype.Teleport:
 			ao.log.Fatal("Scroll_Teleport must processed in floor %v", ao)
+		case scrolltype.SummonPet:
+			ao.log.Fatal("Scroll_SummonPet must processed in floor %v", ao)
+		case scrolltype.Identify:
+			ao.identifyBag()
 		}
 		return nil
 	}
 }
 
+// identifyBag identify all potion, scroll in bag
+func (ao *ActiveObject) identifyBag() {
+	for _, po := range ao.inven.GetPotionList() {
+		ao.identified.IdentifyPotion(po.GetPotionType())
+	}
+	for _, po := range ao.inven.GetScrollList() {
+		ao.identified.IdentifyScroll(po.GetScrollType())
+	}
+}
+
 func (ao *ActiveObject) DoRecycleCarryObj(poid string) error {
 	v, err := ao.GetInven().RecycleCarryObjByID(poid)
 	if err == nil {
@@ -269,6 +292,40 @@ func (ao *ActiveObject) DoRecycleCarryObj(poid string) error {
 	}
 }
 
+func (ao *ActiveObject) DoCraftCarryObj(rcp *craftdata.Recipe) ([]gamei.CarryingObjectI, error) {
+	madeList, err := ao.GetInven().Craft(rcp)
+	if err != nil {
+		return nil, fmt.Errorf("fail to craft %v %v", ao, err)
+	}
+	// recipe tell what is made
+	for _, v := range madeList {
+		switch po := v.(type) {
+		case gamei.PotionI:
+			ao.identified.IdentifyPotion(po.GetPotionType())
+		case gamei.ScrollI:
+			ao.identified.IdentifyScroll(po.GetScrollType())
+		}
+	}
+	ao.foActStat.Inc(fieldobjacttype.CraftCarryObj)
+	return madeList, nil
+}
+
+func (ao *ActiveObject) DoBuyCarryObj(po gamei.CarryingObjectI, price float64) error {
+	if err := ao.GetInven().BuyCarryObj(po, price); err != nil {
+		return fmt.Errorf("fail to buy %v %v", ao, err)
+	}
+	ao.foActStat.Inc(fieldobjacttype.Shop)
+	return nil
+}
+
+func (ao *ActiveObject) DoRepairEquip(eq gamei.EquipObjI) error {
+	if _, err := ao.GetInven().RepairEquip(eq); err != nil {
+		return fmt.Errorf("fail to repair %v %v", ao, err)
+	}
+	ao.foActStat.Inc(fieldobjacttype.RepairEquip)
+	return nil
+}
+
 func (ao *ActiveObject) DoAIOnOff(onoff bool) error {
 	if ao.aoType == aotype.User {
 		ao.SetUseAI(onoff)
@@ -27,7 +27,11 @@ func (ao *ActiveObject) Noti_Death(f gamei.FloorI) {
 	newBias := ao.currentBias.Add(envBias.Idiv(10)).MakeAbsSumTo(newActiveObjBiasLen)
 	ao.currentBias = newBias
 	ao.battleExp *= gameconst.ActiveObjExp_DieRate
-	ao.remainTurn2Rebirth += gameconst.ActiveObjRebirthWaitTurn
+	if ao.boss != nil {
+		ao.remainTurn2Rebirth += ao.boss.Respawn
+	} else {
+		ao.remainTurn2Rebirth += gameconst.ActiveObjRebirthWaitTurn
+	}
 	ao.ap = 0
 	ao.achieveStat.Inc(achievetype.Death)
 	if ao.ai != nil {
@@ -35,10 +39,21 @@ func (ao *ActiveObject) Noti_Death(f gamei.FloorI) {
 	}
 }
 
+// WearEquipByHit wear equip by hit give(attack) or take
+func (ao *ActiveObject) WearEquipByHit(attack bool) {
+	for _, eq := range ao.inven.WearEquipByHit(attack) {
+		ao.AppendTurnResult(turnresult.New(turnresulttype.EquipBroken, eq, float64(eq.GetEquipType())))
+	}
+}
+
 // Kill other ao, inc exp
 func (ao *ActiveObject) Kill(dst gamei.ActiveObjectI) {
 	ao.AddBattleExp(dst.GetTurnData().Level * gameconst.ActiveObjExp_KillLevel)
 	ao.achieveStat.Inc(achievetype.Kill)
+	if dst.GetBoss() != nil {
+		ao.achieveStat.Inc(achievetype.BossKill)
+	}
+	ao.questOnKill(dst.GetBias().NearFaction())
 	ao.AppendTurnResult(turnresult.New(turnresulttype.Kill, dst, 0))
 	dst.AppendTurnResult(turnresult.New(turnresulttype.KilledBy, ao, 0))
 }
@@ -0,0 +1,76 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activeobject
+
+import (
+	"github.com/kasworld/goguelike/config/bossdata"
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/config/leveldata"
+	"github.com/kasworld/goguelike/enum/aotype"
+	"github.com/kasworld/goguelike/enum/towerachieve_vector"
+	"github.com/kasworld/goguelike/game/activeobject/serverai2"
+	"github.com/kasworld/goguelike/game/bias"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/lib/g2log"
+)
+
+// NewBossActiveObj make system ao defined by AddBoss terrain cmd
+func NewBossActiveObj(seed int64, homefloor gamei.FloorI,
+	bs *bossdata.Boss,
+	l *g2log.LogBase,
+	towerAchieveStat *towerachieve_vector.TowerAchieveVector,
+) *ActiveObject {
+	ao := newActiveObj(seed, homefloor, l, towerAchieveStat)
+	ao.nickName = bs.Name
+	ao.isAIInUse = true
+	ao.aoType = aotype.System
+	ao.boss = bs
+	ao.bornFaction = bs.Faction
+	ao.ai = serverai2.NewWithPlanList(ao.rnd.Int63(), ao, ao.log, bs.AIPlan)
+	ao.addRandPotion(gameconst.InitPotionCount)
+	ao.addRandScroll(gameconst.InitScrollCount)
+	ao.addInitGold()
+	ao.resetBoss()
+	ao.hp = ao.AOTurnData.HPMax
+	ao.sp = ao.AOTurnData.SPMax
+	return ao
+}
+
+// GetBoss nil if not boss
+func (ao *ActiveObject) GetBoss() *bossdata.Boss {
+	return ao.boss
+}
+
+// resetBoss restore faction, level, equip of boss on spawn and rebirth
+func (ao *ActiveObject) resetBoss() {
+	bs := ao.boss
+	ao.currentBias = bias.Bias(bs.Faction.FactorBase()).MakeAbsSumTo(gameconst.ActiveObjBaseBiasLen)
+	if exp := leveldata.BaseExp(bs.Level); ao.battleExp < exp {
+		ao.battleExp = exp
+	}
+	eqSlot := ao.inven.GetEquipSlot()
+	for _, eqt := range bs.Equip {
+		if eqSlot[eqt] != nil {
+			continue
+		}
+		eq := carryingobject.NewEquipByFactionSlot(bs.Name, bs.Faction, eqt, ao.rnd)
+		if err := ao.inven.AddToBag(eq); err != nil {
+			ao.log.Error("fail to add boss equip %v %v", ao, err)
+			continue
+		}
+		if err := ao.inven.EquipFromBagByUUID(eq.GetUUID()); err != nil {
+			ao.log.Error("fail to equip boss equip %v %v", ao, err)
+		}
+	}
+	ao.updateActiveObjTurnData()
+}
@@ -26,6 +26,7 @@ func (ao *ActiveObject) Noti_EnterFloor(f gamei.FloorI) {
 	if _, exist := ao.uuid2VisitArea.GetByID(f.GetName()); !exist {
 		ao.uuid2VisitArea.Add(f)
 	}
+	ao.questOnEnterFloor(f)
 	if aio := ao.ai; aio != nil {
 		aio.ResetPlan()
 	}
@@ -44,6 +45,7 @@ func (ao *ActiveObject) UpdateVisitAreaBySightMat2(
 		vpCenterX, vpCenterY,
 		sightMat,
 		sight)
+	ao.questOnVisit(va)
 }
 
 func (ao *ActiveObject) forgetAnyFloor() error {
@@ -71,6 +73,7 @@ func (ao *ActiveObject) ForgetFloorByName(floorName string) error {
 func (ao *ActiveObject) MakeFloorComplete(f gamei.FloorI) error {
 	va, _ := ao.uuid2VisitArea.GetByID(f.GetName())
 	va.MakeComplete()
+	ao.questOnVisit(va)
 
 	fi := f.ToPacket_FloorInfo()
 	if aoconn := ao.clientConn; aoconn != nil {
@@ -16,8 +16,10 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/kasworld/goguelike/config/viewportdata"
 	"github.com/kasworld/goguelike/enum/achievetype_vector"
 	"github.com/kasworld/goguelike/enum/aotype"
+	"github.com/kasworld/goguelike/enum/chattype"
 	"github.com/kasworld/goguelike/enum/condition_vector"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype_vector"
 	"github.com/kasworld/goguelike/enum/potiontype_vector"
@@ -28,6 +30,9 @@ import (
 	"github.com/kasworld/goguelike/game/aoactreqrsp"
 	"github.com/kasworld/goguelike/game/bias"
 	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/identify"
+	"github.com/kasworld/goguelike/game/noise"
+	"github.com/kasworld/goguelike/game/objlistdelta"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd_stats"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_serveconnbyte"
 )
@@ -52,13 +57,69 @@ func (ao *ActiveObject) SetChat(c string) {
 	ao.chatTime = time.Now()
 }
 
+// NeedDiplomacyNoti true if faction or diplomacy changed after last true
+func (ao *ActiveObject) NeedDiplomacyNoti(version int) bool {
+	ft := ao.currentBias.NearFaction()
+	if ao.diplomacyNotiSent &&
+		ao.diplomacyNotiFaction == ft &&
+		ao.diplomacyNotiVersion == version {
+		return false
+	}
+	ao.diplomacyNotiSent = true
+	ao.diplomacyNotiFaction = ft
+	ao.diplomacyNotiVersion = version
+	return true
+}
+
+// GetLightRadius max light radius of equipped
+func (ao *ActiveObject) GetLightRadius() float64 {
+	rtn := 0.0
+	for _, po := range ao.inven.GetEquipSlot() {
+		if po != nil && po.GetLightRadius() > rtn {
+			rtn = po.GetLightRadius()
+		}
+	}
+	return rtn
+}
+
+// SetHeardNoiseList set by floor at end of turn, loud first
+func (ao *ActiveObject) SetHeardNoiseList(hl []noise.Heard) {
+	ao.heardNoise = hl
+}
+
+func (ao *ActiveObject) GetHeardNoiseList() []noise.Heard {
+	return ao.heardNoise
+}
+
+// SetSightMat set by floor at end of turn, same index with ViewportXYLenList
+func (ao *ActiveObject) SetSightMat(sightMat *viewportdata.ViewportSight2) {
+	ao.sightMat = sightMat
+}
+
+func (ao *ActiveObject) GetSightMat() *viewportdata.ViewportSight2 {
+	return ao.sightMat
+}
+
+// CheckChatInterval return false if chat of ct too frequent or invalid ct,
+// else update chat time
+func (ao *ActiveObject) CheckChatInterval(ct chattype.ChatType, now time.Time) bool {
+	if !ct.IsValid() {
+		return false
+	}
+	if now.Sub(ao.chatTypeTime[ct]) < ct.MinInterval() {
+		return false
+	}
+	ao.chatTypeTime[ct] = now
+	return true
+}
+
 // func (ao *ActiveObject) SetNickName(nickname string) {
 // 	ao.nickName = nickname
 // }
 
-// func (ao *ActiveObject) GetNickName() string {
-// 	return ao.nickName
-// }
+func (ao *ActiveObject) GetNickName() string {
+	return ao.nickName
+}
 
 func (ao *ActiveObject) SetNeedTANoti() {
 	ao.needTANoti = true
@@ -70,6 +131,10 @@ func (ao *ActiveObject) GetAndClearNeedTANoti() bool {
 }
 
 // clients conn interface
+func (ao *ActiveObject) GetObjListSender() *objlistdelta.Sender {
+	return &ao.objListSender
+}
+
 func (ao *ActiveObject) GetClientConn() *c2t_serveconnbyte.ServeConnByte {
 	return ao.clientConn
 }
@@ -117,6 +182,10 @@ func (ao *ActiveObject) GetPotionStat() *potiontype_vector.PotionTypeVector {
 	return &ao.potionStat
 }
 
+func (ao *ActiveObject) GetIdentifyKnowledge() *identify.Knowledge {
+	return &ao.identified
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // battle relate
 
@@ -0,0 +1,221 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activeobject
+
+import (
+	"time"
+
+	"github.com/kasworld/goguelike/config/questdata"
+	"github.com/kasworld/goguelike/enum/aotype"
+	"github.com/kasworld/goguelike/enum/towerachieve_vector"
+	"github.com/kasworld/goguelike/game/activeobject/serverai2"
+	"github.com/kasworld/goguelike/game/aopersistent"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/lib/g2log"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_serveconnbyte"
+)
+
+// NewUserActiveObjFromPersistent make user ao from saved data
+// floor not exist in tower (changed tower script) is skipped
+func NewUserActiveObjFromPersistent(seed int64,
+	fm gamei.FloorManagerI,
+	aop *aopersistent.AOPersistent,
+	l *g2log.LogBase,
+	towerAchieveStat *towerachieve_vector.TowerAchieveVector,
+	conn *c2t_serveconnbyte.ServeConnByte) *ActiveObject {
+
+	homefloor := fm.GetFloorByName(aop.HomeFloor)
+	if homefloor == nil {
+		homefloor = fm.GetStartFloor()
+	}
+	ao := newActiveObj(seed, homefloor, l, towerAchieveStat)
+	ao.nickName = aop.NickName
+	ao.isAIInUse = false
+	ao.aoType = aotype.User
+	ao.ai = serverai2.New(ao.rnd.Int63(), ao, ao.log)
+	ao.clientConn = conn
+
+	ao.createTime = aop.Create
+	ao.bornFaction = aop.Faction
+	ao.currentBias = aop.Bias
+	ao.battleExp = aop.BattleExp
+	if aop.HP > 0 {
+		ao.hp = aop.HP
+	}
+	if aop.SP > 0 {
+		ao.sp = aop.SP
+	}
+
+	ao.achieveStat = aop.AchieveStat
+	ao.potionStat = aop.PotionStat
+	ao.scrollStat = aop.ScrollStat
+	ao.identified = aop.Identified
+	for _, v := range aop.QuestList {
+		q := questdata.FindByName(homefloor.GetTower().GetQuestList(), v.Name)
+		if q == nil {
+			ao.log.Warn("skip progress of unknown quest %v %v", ao, v.Name)
+			continue
+		}
+		p := v
+		p.FitTo(q)
+		ao.questList = append(ao.questList, &p)
+	}
+	ao.foActStat = aop.FoActStat
+	ao.aoActionStat = aop.AOActionStat
+	ao.conditionStat = aop.ConditionStat
+	ao.setLearnedSkill(aop.LearnedSkill, aop.SkillCoolTurn)
+
+	for _, v := range aop.EquipList {
+		eq := carryingobject.NewEquipObj(v.Name, v.Material, v.Faction, v.EquipType, v.BiasLen,
+			v.Durability)
+		if err := ao.inven.AddToBag(eq); err != nil {
+			ao.log.Error("fail to restore equip %v %v", ao, err)
+			continue
+		}
+		if v.Equipped {
+			if err := ao.inven.EquipFromBagByUUID(eq.GetUUID()); err != nil {
+				ao.log.Error("fail to restore equipped %v %v", ao, err)
+			}
+		}
+	}
+	for _, v := range aop.PotionList {
+		if err := ao.inven.AddToBag(carryingobject.NewPotion(v)); err != nil {
+			ao.log.Error("fail to restore potion %v %v", ao, err)
+		}
+	}
+	for _, v := range aop.ScrollList {
+		if err := ao.inven.AddToBag(carryingobject.NewScroll(v)); err != nil {
+			ao.log.Error("fail to restore scroll %v %v", ao, err)
+		}
+	}
+	if aop.Wallet > 0 {
+		ao.inven.AddToWallet(carryingobject.NewMoney(aop.Wallet))
+	}
+	ao.inven.AddAmmo(aop.Ammo)
+
+	for _, v := range aop.VisitAreaList {
+		f := fm.GetFloorByName(v.FloorName)
+		if f == nil {
+			ao.log.Warn("skip visitarea of unknown floor %v %v", ao, v.FloorName)
+			continue
+		}
+		if f.GetWidth() != v.W || f.GetHeight() != v.H {
+			ao.log.Warn("skip visitarea of changed floor %v %v", ao, v.FloorName)
+			continue
+		}
+		if _, err := ao.uuid2VisitArea.Restore(f, v.DiscoveredTileCount, v.BitsList); err != nil {
+			ao.log.Error("fail to restore visitarea %v %v", ao, err)
+		}
+	}
+	ao.updateActiveObjTurnData()
+	return ao
+}
+
+// SetSessionUUID bind user ao to owner session, saved with this key
+func (ao *ActiveObject) SetSessionUUID(sessionuuid string) {
+	ao.sessionUUID = sessionuuid
+}
+
+func (ao *ActiveObject) GetSessionUUID() string {
+	return ao.sessionUUID
+}
+
+// ToPersistent make data to save
+func (ao *ActiveObject) ToPersistent() *aopersistent.AOPersistent {
+	aop := &aopersistent.AOPersistent{
+		UUID:        ao.uuid,
+		SessionUUID: ao.sessionUUID,
+		Create:      ao.createTime,
+		NickName:    ao.nickName,
+		Faction:     ao.bornFaction,
+
+		Update: time.Now(),
+		Exp:    ao.AOTurnData.TotalExp,
+		Wealth: ao.inven.GetTotalValue(),
+		Bias:   ao.currentBias,
+
+		BattleExp: ao.battleExp,
+		HP:        ao.hp,
+		SP:        ao.sp,
+
+		LearnedSkill:  ao.GetLearnedSkillList(),
+		SkillCoolTurn: ao.skillCoolTurn,
+
+		Wallet: ao.inven.GetWalletValue(),
+		Ammo:   ao.inven.GetAmmoCount(),
+
+		AchieveStat:   ao.achieveStat,
+		PotionStat:    ao.potionStat,
+		ScrollStat:    ao.scrollStat,
+		Identified:    ao.identified,
+		FoActStat:     ao.foActStat,
+		AOActionStat:  ao.aoActionStat,
+		ConditionStat: ao.conditionStat,
+	}
+	if f := ao.currrentFloor; f != nil {
+		aop.HomeFloor = f.GetName()
+	} else if f := ao.homefloor; f != nil {
+		aop.HomeFloor = f.GetName()
+	}
+
+	for _, v := range ao.inven.GetEquipSlot() {
+		if v == nil {
+			continue
+		}
+		ec := v.ToPacket_EquipClient()
+		aop.EquipList = append(aop.EquipList, aopersistent.EquipPersistent{
+			Name:       ec.Name,
+			Material:   v.GetMaterial(),
+			EquipType:  ec.EquipType,
+			Faction:    ec.Faction,
+			BiasLen:    ec.BiasLen,
+			Durability: ec.Durability,
+			Equipped:   true,
+		})
+	}
+	eqList, potionList, scrollList := ao.inven.GetTypeList()
+	for _, v := range eqList {
+		ec := v.ToPacket_EquipClient()
+		aop.EquipList = append(aop.EquipList, aopersistent.EquipPersistent{
+			Name:       ec.Name,
+			Material:   v.GetMaterial(),
+			EquipType:  ec.EquipType,
+			Faction:    ec.Faction,
+			BiasLen:    ec.BiasLen,
+			Durability: ec.Durability,
+		})
+	}
+	for _, v := range potionList {
+		aop.PotionList = append(aop.PotionList, v.GetPotionType())
+	}
+	for _, v := range scrollList {
+		aop.ScrollList = append(aop.ScrollList, v.GetScrollType())
+	}
+
+	for _, v := range ao.questList {
+		p := *v
+		p.Count = append([]int(nil), v.Count...)
+		aop.QuestList = append(aop.QuestList, p)
+	}
+
+	for _, v := range ao.uuid2VisitArea.GetList() {
+		aop.VisitAreaList = append(aop.VisitAreaList, aopersistent.VisitAreaPersistent{
+			FloorName:           v.GetName(),
+			W:                   v.GetWidth(),
+			H:                   v.GetHeight(),
+			DiscoveredTileCount: v.GetDiscoveredTileCount(),
+			BitsList:            v.GetBitsListCopy(),
+		})
+	}
+	return aop
+}
@@ -0,0 +1,179 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activeobject
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/petorder"
+	"github.com/kasworld/goguelike/enum/towerachieve_vector"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/lib/g2log"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+// NewSummonedActiveObj system ao made by SummonPet scroll
+func NewSummonedActiveObj(seed int64, homefloor gamei.FloorI,
+	l *g2log.LogBase,
+	towerAchieveStat *towerachieve_vector.TowerAchieveVector,
+) *ActiveObject {
+	ao := NewSystemActiveObj(seed, homefloor, l, towerAchieveStat)
+	ao.petSummoned = true
+	return ao
+}
+
+// IsSummoned made by SummonPet scroll, not exist without owner
+func (ao *ActiveObject) IsSummoned() bool {
+	return ao.petSummoned
+}
+
+// GetPetOwner nil if not pet
+func (ao *ActiveObject) GetPetOwner() gamei.ActiveObjectI {
+	ao.petMutex.RLock()
+	defer ao.petMutex.RUnlock()
+	return ao.petOwner
+}
+
+// SetPetOwner nil to release, order reset to Follow
+func (ao *ActiveObject) SetPetOwner(owner gamei.ActiveObjectI) {
+	ao.petMutex.Lock()
+	defer ao.petMutex.Unlock()
+	ao.petOwner = owner
+	ao.petOrder = petorder.Follow
+	ao.petTarget = ""
+}
+
+// GetPetOrder order and target ao uuid of petorder.Attack
+func (ao *ActiveObject) GetPetOrder() (petorder.PetOrder, string) {
+	ao.petMutex.RLock()
+	defer ao.petMutex.RUnlock()
+	return ao.petOrder, ao.petTarget
+}
+
+func (ao *ActiveObject) SetPetOrder(order petorder.PetOrder, target string) {
+	ao.petMutex.Lock()
+	defer ao.petMutex.Unlock()
+	ao.petOrder = order
+	ao.petTarget = target
+}
+
+// AddPet bind pet to ao
+func (ao *ActiveObject) AddPet(pet gamei.ActiveObjectI) error {
+	if pet.GetUUID() == ao.uuid {
+		return fmt.Errorf("can not be pet of self %v", ao)
+	}
+	if pet.GetPetOwner() != nil {
+		return fmt.Errorf("pet has owner %v", pet)
+	}
+	ao.petMutex.Lock()
+	defer ao.petMutex.Unlock()
+	if ao.petOwner != nil {
+		return fmt.Errorf("pet can not have pet %v", ao)
+	}
+	if len(ao.petList) >= gameconst.PetCountMax {
+		return fmt.Errorf("pet count over %v", ao)
+	}
+	pet.SetPetOwner(ao)
+	ao.petList = append(ao.petList, pet)
+	return nil
+}
+
+// RemovePet unbind pet from ao
+func (ao *ActiveObject) RemovePet(pet gamei.ActiveObjectI) error {
+	ao.petMutex.Lock()
+	defer ao.petMutex.Unlock()
+	for i, v := range ao.petList {
+		if v.GetUUID() != pet.GetUUID() {
+			continue
+		}
+		ao.petList = append(ao.petList[:i], ao.petList[i+1:]...)
+		pet.SetPetOwner(nil)
+		return nil
+	}
+	return fmt.Errorf("not pet of %v %v", ao, pet)
+}
+
+// ReleaseAllPet unbind all pet, when ao leave tower
+func (ao *ActiveObject) ReleaseAllPet() {
+	ao.petMutex.Lock()
+	defer ao.petMutex.Unlock()
+	for _, v := range ao.petList {
+		v.SetPetOwner(nil)
+	}
+	ao.petList = nil
+}
+
+func (ao *ActiveObject) GetPetList() []gamei.ActiveObjectI {
+	ao.petMutex.RLock()
+	defer ao.petMutex.RUnlock()
+	rtn := make([]gamei.ActiveObjectI, len(ao.petList))
+	copy(rtn, ao.petList)
+	return rtn
+}
+
+// GetPetByUUID nil if not pet of ao
+func (ao *ActiveObject) GetPetByUUID(id string) gamei.ActiveObjectI {
+	ao.petMutex.RLock()
+	defer ao.petMutex.RUnlock()
+	for _, v := range ao.petList {
+		if v.GetUUID() == id {
+			return v
+		}
+	}
+	return nil
+}
+
+// GetBattleFaction pet fight as owner faction
+func (ao *ActiveObject) GetBattleFaction() factiontype.FactionType {
+	if owner := ao.GetPetOwner(); owner != nil {
+		return owner.GetBias().NearFaction()
+	}
+	return ao.currentBias.NearFaction()
+}
+
+// IsPartyOf true if same ao, owner, pet or pet of same owner
+func (ao *ActiveObject) IsPartyOf(dst gamei.ActiveObjectI) bool {
+	leader := gamei.ActiveObjectI(ao)
+	if owner := ao.GetPetOwner(); owner != nil {
+		leader = owner
+	}
+	dstLeader := dst
+	if owner := dst.GetPetOwner(); owner != nil {
+		dstLeader = owner
+	}
+	return leader.GetUUID() == dstLeader.GetUUID()
+}
+
+func (ao *ActiveObject) ToPacket_PetClientList() []*c2t_obj.PetClient {
+	petList := ao.GetPetList()
+	rtn := make([]*c2t_obj.PetClient, 0, len(petList))
+	for _, v := range petList {
+		order, _ := v.GetPetOrder()
+		pc := &c2t_obj.PetClient{
+			UUID:     v.GetUUID(),
+			NickName: v.GetNickName(),
+			Order:    order,
+			Level:    int(v.GetTurnData().Level),
+			Exp:      int(v.GetTurnData().TotalExp),
+			HP:       int(v.GetHP()),
+			HPMax:    int(v.GetTurnData().HPMax),
+			Alive:    v.IsAlive(),
+		}
+		if f := v.GetCurrentFloor(); f != nil {
+			pc.FloorName = f.GetName()
+		}
+		rtn = append(rtn, pc)
+	}
+	return rtn
+}
@@ -0,0 +1,102 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activeobject
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/petorder"
+)
+
+func TestAddRemovePet(t *testing.T) {
+	owner := &ActiveObject{uuid: "owner"}
+	if err := owner.AddPet(owner); err == nil {
+		t.Error("pet of self added")
+	}
+	var petList []*ActiveObject
+	for i := 0; i < gameconst.PetCountMax; i++ {
+		pet := &ActiveObject{uuid: string(rune('a' + i))}
+		if err := owner.AddPet(pet); err != nil {
+			t.Fatal(err)
+		}
+		if pet.GetPetOwner() != owner {
+			t.Errorf("owner not set %v", pet.uuid)
+		}
+		petList = append(petList, pet)
+	}
+	if err := owner.AddPet(&ActiveObject{uuid: "over"}); err == nil {
+		t.Error("pet count over max added")
+	}
+	other := &ActiveObject{uuid: "other"}
+	if err := other.AddPet(petList[0]); err == nil {
+		t.Error("pet of other added")
+	}
+	if err := petList[0].AddPet(other); err == nil {
+		t.Error("pet of pet added")
+	}
+
+	petList[1].SetPetOrder(petorder.Stay, "")
+	if err := owner.RemovePet(petList[1]); err != nil {
+		t.Fatal(err)
+	}
+	if petList[1].GetPetOwner() != nil || owner.GetPetByUUID(petList[1].uuid) != nil {
+		t.Error("pet not removed")
+	}
+	if order, _ := petList[1].GetPetOrder(); order != petorder.Follow {
+		t.Errorf("order not reset %v", order)
+	}
+	if err := owner.RemovePet(petList[1]); err == nil {
+		t.Error("not pet removed")
+	}
+
+	owner.ReleaseAllPet()
+	if len(owner.GetPetList()) != 0 {
+		t.Errorf("pet remain %v", owner.GetPetList())
+	}
+	for _, v := range petList {
+		if v.GetPetOwner() != nil {
+			t.Errorf("pet not released %v", v.uuid)
+		}
+	}
+}
+
+func TestIsPartyOf(t *testing.T) {
+	owner := &ActiveObject{uuid: "owner"}
+	pet1 := &ActiveObject{uuid: "pet1"}
+	pet2 := &ActiveObject{uuid: "pet2"}
+	other := &ActiveObject{uuid: "other"}
+	otherPet := &ActiveObject{uuid: "otherpet"}
+	for _, v := range []struct{ owner, pet *ActiveObject }{
+		{owner, pet1}, {owner, pet2}, {other, otherPet},
+	} {
+		if err := v.owner.AddPet(v.pet); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, v := range []struct {
+		src, dst *ActiveObject
+		party    bool
+	}{
+		{owner, owner, true},
+		{owner, pet1, true},
+		{pet1, owner, true},
+		{pet1, pet2, true},
+		{owner, other, false},
+		{pet1, otherPet, false},
+		{otherPet, owner, false},
+	} {
+		if got := v.src.IsPartyOf(v.dst); got != v.party {
+			t.Errorf("%v IsPartyOf %v %v, want %v", v.src.uuid, v.dst.uuid, got, v.party)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activeobject
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/config/craftdata"
+	"github.com/kasworld/goguelike/config/questdata"
+	"github.com/kasworld/goguelike/enum/achievetype"
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/game/aoquest"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/visitarea"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+func (ao *ActiveObject) getTowerQuestList() []*questdata.Quest {
+	return ao.homefloor.GetTower().GetQuestList()
+}
+
+func (ao *ActiveObject) GetQuestProgressList() []*aoquest.Progress {
+	return ao.questList
+}
+
+// DoAcceptQuest giver checked by floor
+func (ao *ActiveObject) DoAcceptQuest(q *questdata.Quest) error {
+	if aoquest.Find(ao.questList, q.Name) != nil {
+		return fmt.Errorf("already accepted %v %v", ao, q.Name)
+	}
+	p := aoquest.New(q)
+	ao.questList = append(ao.questList, p)
+	// objective already done before accept
+	for i, ob := range q.Objective {
+		switch ob.Type {
+		case questdata.Reach:
+			if ao.currrentFloor != nil && ao.currrentFloor.GetName() == ob.FloorName {
+				p.AddCount(q, i, 1)
+			}
+		case questdata.Visit:
+			if va, exist := ao.uuid2VisitArea.GetByID(ob.FloorName); exist && va.IsComplete() {
+				p.AddCount(q, i, 1)
+			}
+		}
+	}
+	ao.sendQuestNoti(q, p)
+	return nil
+}
+
+// DoCompleteQuest consume Deliver carryobj and give reward, giver checked by floor
+func (ao *ActiveObject) DoCompleteQuest(q *questdata.Quest) error {
+	p := aoquest.Find(ao.questList, q.Name)
+	if p == nil || p.Completed {
+		return fmt.Errorf("not in progress %v %v", ao, q.Name)
+	}
+	var deliverList []craftdata.Material
+	for i, ob := range q.Objective {
+		if ob.Type == questdata.Deliver {
+			deliverList = append(deliverList, ob.Material)
+			continue
+		}
+		if p.Count[i] < ob.Count {
+			return fmt.Errorf("objective not done %v %v", ao, ob)
+		}
+	}
+	if err := ao.inven.RemoveMaterial(deliverList); err != nil {
+		return fmt.Errorf("insufficient deliver %v %v", ao, err)
+	}
+	for i, ob := range q.Objective {
+		if ob.Type == questdata.Deliver {
+			p.Count[i] = ob.Count
+		}
+	}
+	p.Completed = true
+	ao.achieveStat.Inc(achievetype.QuestComplete)
+	for _, rw := range q.Reward {
+		ao.giveQuestReward(rw)
+	}
+	ao.sendQuestNoti(q, p)
+	return nil
+}
+
+func (ao *ActiveObject) giveQuestReward(rw questdata.Reward) {
+	switch rw.Type {
+	default:
+		ao.log.Fatal("unknown reward %v %v", ao, rw)
+	case questdata.Exp:
+		ao.AddBattleExp(rw.Value)
+	case questdata.Money:
+		ao.inven.AddToWallet(carryingobject.NewMoney(rw.Value))
+		ao.achieveStat.Add(achievetype.MoneyGet, rw.Value)
+	case questdata.Item:
+		for i := 0; i < rw.Material.Count; i++ {
+			var po gamei.CarryingObjectI
+			switch rw.Material.CarryingObjectType {
+			case carryingobjecttype.Potion:
+				po = carryingobject.NewPotion(rw.Material.PotionType)
+			case carryingobjecttype.Scroll:
+				po = carryingobject.NewScroll(rw.Material.ScrollType)
+			}
+			if err := ao.inven.AddToBag(po); err != nil {
+				ao.log.Error("fail to add reward %v %v", ao, err)
+			}
+		}
+	case questdata.Buff:
+		ao.buffManager.Add(rw.PotionType.String(), false, false,
+			potiontype.GetBuffByPotionType(rw.PotionType))
+	}
+}
+
+// questOnKill count Kill objective by faction of killed
+func (ao *ActiveObject) questOnKill(ft factiontype.FactionType) {
+	ao.questAddCount(func(ob questdata.Objective) bool {
+		return ob.Type == questdata.Kill && ob.Faction == ft
+	})
+}
+
+// questOnEnterFloor count Reach objective
+func (ao *ActiveObject) questOnEnterFloor(f gamei.FloorI) {
+	ao.questAddCount(func(ob questdata.Objective) bool {
+		return ob.Type == questdata.Reach && ob.FloorName == f.GetName()
+	})
+}
+
+// questOnVisit count Visit objective if visitarea complete
+func (ao *ActiveObject) questOnVisit(va *visitarea.VisitArea) {
+	complete := false
+	checked := false
+	ao.questAddCount(func(ob questdata.Objective) bool {
+		if ob.Type != questdata.Visit || ob.FloorName != va.GetName() {
+			return false
+		}
+		if !checked { // IsComplete lock visitarea, check once
+			complete = va.IsComplete()
+			checked = true
+		}
+		return complete
+	})
+}
+
+func (ao *ActiveObject) questAddCount(match func(ob questdata.Objective) bool) {
+	for _, p := range ao.questList {
+		if p.Completed {
+			continue
+		}
+		q := questdata.FindByName(ao.getTowerQuestList(), p.Name)
+		if q == nil {
+			continue
+		}
+		changed := false
+		for i, ob := range q.Objective {
+			if match(ob) && p.AddCount(q, i, 1) {
+				changed = true
+			}
+		}
+		if changed {
+			ao.sendQuestNoti(q, p)
+		}
+	}
+}
+
+func (ao *ActiveObject) sendQuestNoti(q *questdata.Quest, p *aoquest.Progress) {
+	if aoconn := ao.clientConn; aoconn != nil {
+		if err := aoconn.SendNotiPacket(c2t_idnoti.Quest,
+			&c2t_obj.NotiQuest_data{
+				Quest: ao.toPacket_QuestClient(q, p),
+			},
+		); err != nil {
+			ao.log.Error("%v %v", ao, err)
+		}
+	}
+}
+
+// ToPacket_QuestClient with progress if accepted
+func (ao *ActiveObject) ToPacket_QuestClient(q *questdata.Quest) *c2t_obj.QuestClient {
+	return ao.toPacket_QuestClient(q, aoquest.Find(ao.questList, q.Name))
+}
+
+// ToPacket_QuestClientList accepted quest
+func (ao *ActiveObject) ToPacket_QuestClientList() []*c2t_obj.QuestClient {
+	var rtn []*c2t_obj.QuestClient
+	for _, p := range ao.questList {
+		q := questdata.FindByName(ao.getTowerQuestList(), p.Name)
+		if q == nil {
+			continue
+		}
+		rtn = append(rtn, ao.toPacket_QuestClient(q, p))
+	}
+	return rtn
+}
+
+// toPacket_QuestClient p nil if not accepted
+// Deliver count from bag until completed
+func (ao *ActiveObject) toPacket_QuestClient(q *questdata.Quest, p *aoquest.Progress) *c2t_obj.QuestClient {
+	qc := &c2t_obj.QuestClient{
+		Name:  q.Name,
+		Giver: q.GiverString(),
+	}
+	for i, ob := range q.Objective {
+		qo := c2t_obj.QuestObjective{
+			Objective: ob.String(),
+			Need:      ob.Count,
+		}
+		if p != nil {
+			qo.Count = p.Count[i]
+			if ob.Type == questdata.Deliver && !p.Completed {
+				qo.Count = ao.inven.CountMaterial(ob.Material)
+				if qo.Count > ob.Count {
+					qo.Count = ob.Count
+				}
+			}
+		}
+		qc.Objective = append(qc.Objective, qo)
+	}
+	for _, rw := range q.Reward {
+		qc.Reward = append(qc.Reward, rw.String())
+	}
+	if p != nil {
+		qc.Accepted = true
+		qc.Completed = p.Completed
+	}
+	return qc
+}
@@ -0,0 +1,93 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activeobject
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/config/questdata"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/towerachieve_vector"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/game/gamei"
+)
+
+// questTower, questFloor provide only quest list to ao
+type questTower struct {
+	gamei.TowerI
+	questList []*questdata.Quest
+}
+
+func (tw *questTower) GetQuestList() []*questdata.Quest {
+	return tw.questList
+}
+
+type questFloor struct {
+	gamei.FloorI
+	tower *questTower
+}
+
+func (f *questFloor) GetTower() gamei.TowerI {
+	return f.tower
+}
+
+func TestQuestProgress(t *testing.T) {
+	q, err := questdata.ParseQuest(
+		"Hunt Floor:Practice Kill:Red*2,Deliver:Potion:RecoverHP10 Exp:100,Money:50")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &questFloor{tower: &questTower{questList: []*questdata.Quest{q}}}
+	ao := newActiveObj(1, f, nil, new(towerachieve_vector.TowerAchieveVector))
+	ao.questOnKill(factiontype.Red)
+	if len(ao.GetQuestProgressList()) != 0 {
+		t.Fatal("progress without accept")
+	}
+	if err := ao.DoAcceptQuest(q); err != nil {
+		t.Fatal(err)
+	}
+	if err := ao.DoAcceptQuest(q); err == nil {
+		t.Fatal("accept twice")
+	}
+	p := ao.GetQuestProgressList()[0]
+
+	ao.questOnKill(factiontype.Blue)
+	ao.questOnKill(factiontype.Red)
+	if p.Count[0] != 1 {
+		t.Fatalf("kill count %v", p)
+	}
+	ao.inven.AddToBag(carryingobject.NewPotion(potiontype.RecoverHP10))
+	if err := ao.DoCompleteQuest(q); err == nil {
+		t.Fatal("complete before kill done")
+	}
+	if ao.inven.GetBagCount() != 1 {
+		t.Fatal("deliver consumed on fail")
+	}
+
+	ao.questOnKill(factiontype.Red)
+	ao.questOnKill(factiontype.Red)
+	if p.Count[0] != 2 {
+		t.Fatalf("kill count over need %v", p)
+	}
+	wallet := ao.inven.GetWalletValue()
+	if err := ao.DoCompleteQuest(q); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Completed || ao.inven.GetBagCount() != 0 ||
+		ao.battleExp != 100 || ao.inven.GetWalletValue() != wallet+50 {
+		t.Errorf("invalid reward %v exp:%v", p, ao.battleExp)
+	}
+	if err := ao.DoCompleteQuest(q); err == nil {
+		t.Error("complete twice")
+	}
+}
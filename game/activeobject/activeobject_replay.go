@@ -0,0 +1,116 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activeobject
+
+import (
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/inventory"
+	"github.com/kasworld/goguelike/game/towersnapshot"
+	"github.com/kasworld/goguelike/game/turnrecorder"
+)
+
+// ToReplayDetail make state to restore replay ao at turn start
+func (ao *ActiveObject) ToReplayDetail() turnrecorder.AODetail {
+	rtn := turnrecorder.AODetail{
+		SP:        ao.sp,
+		BattleExp: ao.battleExp,
+		Bias:      ao.currentBias,
+		TurnData:  *ao.AOTurnData,
+		BuffList:  ao.buffManager.GetBuffListCopy(),
+
+		LearnedSkill:  ao.GetLearnedSkillList(),
+		SkillCoolTurn: ao.skillCoolTurn,
+
+		Wallet:  ao.inven.GetWalletValue(),
+		Ammo:    ao.inven.GetAmmoCount(),
+		KeyList: ao.inven.GetKeyList(),
+	}
+	for _, v := range ao.inven.GetEquipSlot() {
+		if v == nil {
+			continue
+		}
+		if cs, err := carryingobject.ToSnapshot(v); err != nil {
+			ao.log.Error("%v %v", ao, err)
+		} else {
+			rtn.EquipSlot = append(rtn.EquipSlot, cs)
+		}
+	}
+	eqList, potionList, scrollList := ao.inven.GetTypeList()
+	for _, v := range eqList {
+		if cs, err := carryingobject.ToSnapshot(v); err != nil {
+			ao.log.Error("%v %v", ao, err)
+		} else {
+			rtn.Bag = append(rtn.Bag, cs)
+		}
+	}
+	for _, v := range potionList {
+		if cs, err := carryingobject.ToSnapshot(v); err != nil {
+			ao.log.Error("%v %v", ao, err)
+		} else {
+			rtn.Bag = append(rtn.Bag, cs)
+		}
+	}
+	for _, v := range scrollList {
+		if cs, err := carryingobject.ToSnapshot(v); err != nil {
+			ao.log.Error("%v %v", ao, err)
+		} else {
+			rtn.Bag = append(rtn.Bag, cs)
+		}
+	}
+	return rtn
+}
+
+// SetStateForReplay sync state to recorded, inventory remade with recorded uuid
+func (ao *ActiveObject) SetStateForReplay(st turnrecorder.AOStartState) {
+	ao.hp = st.HP
+	ao.ap = st.AP
+	ao.sp = st.Detail.SP
+	ao.battleExp = st.Detail.BattleExp
+	ao.currentBias = st.Detail.Bias
+	td := st.Detail.TurnData
+	ao.AOTurnData = &td
+	ao.buffManager.SetBuffList(st.Detail.BuffList)
+	ao.setLearnedSkill(st.Detail.LearnedSkill, st.Detail.SkillCoolTurn)
+
+	ao.inven = inventory.New(ao.towerAchieveStat)
+	if st.Detail.Wallet > 0 {
+		ao.inven.AddToWallet(carryingobject.NewMoney(st.Detail.Wallet))
+	}
+	ao.inven.AddAmmo(st.Detail.Ammo)
+	for _, v := range st.Detail.KeyList {
+		ao.inven.AddKey(v)
+	}
+	for _, cs := range st.Detail.Bag {
+		ao.restoreToBagForReplay(cs)
+	}
+	for _, cs := range st.Detail.EquipSlot {
+		if po := ao.restoreToBagForReplay(cs); po != nil {
+			if err := ao.inven.EquipFromBagByUUID(po.GetUUID()); err != nil {
+				ao.log.Error("fail to restore equipped %v %v", ao, err)
+			}
+		}
+	}
+}
+
+func (ao *ActiveObject) restoreToBagForReplay(cs towersnapshot.CarryObjSnapshot) gamei.CarryingObjectI {
+	po, err := carryingobject.NewFromSnapshot(cs)
+	if err != nil {
+		ao.log.Error("%v %v", ao, err)
+		return nil
+	}
+	if err := ao.inven.AddToBag(po); err != nil {
+		ao.log.Error("fail to restore carryobj %v %v", ao, err)
+		return nil
+	}
+	return po
+}
@@ -37,6 +37,9 @@ func (ao *ActiveObject) ToPacket_ActiveObjClient(x, y int) *c2t_obj.ActiveObjCli
 		Alive:      ao.IsAlive(),
 		Chat:       ao.chat,
 	}
+	if owner := ao.GetPetOwner(); owner != nil {
+		aoc.OwnerUUID = owner.GetUUID()
+	}
 
 	if stepAct := ao.turnActReqRsp; stepAct != nil && stepAct.Acted() {
 		aoc.Act = stepAct.Done.Act
@@ -92,6 +95,17 @@ func (ao *ActiveObject) ToPacket_PlayerActiveObjInfo() *c2t_obj.PlayerActiveObjI
 	}
 	rtn.Wealth = int(ao.inven.GetTotalValue())
 	rtn.EquippedPo, rtn.EquipBag, rtn.PotionBag, rtn.ScrollBag, rtn.Wallet = ao.inven.ToPacket_InvenInfos()
+	ap := ao.homefloor.GetTower().GetAppearance()
+	for _, v := range rtn.PotionBag {
+		ap.MaskPotionClient(&ao.identified, v)
+	}
+	for _, v := range rtn.ScrollBag {
+		ap.MaskScrollClient(&ao.identified, v)
+	}
+	rtn.Ammo = ao.inven.GetAmmoCount()
+	rtn.Key = len(ao.inven.GetKeyList())
+	rtn.SkillList = ao.ToPacket_SkillClient()
+	rtn.PetList = ao.ToPacket_PetClientList()
 	rtn.TurnResult = make([]c2t_obj.TurnResultClient,
 		0, len(ao.turnResultList))
 	for _, v := range ao.turnResultList {
@@ -0,0 +1,102 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activeobject
+
+import (
+	"github.com/kasworld/goguelike/config/gamedata"
+	"github.com/kasworld/goguelike/enum/skilltype"
+	"github.com/kasworld/goguelike/enum/skilltype_vector"
+	"github.com/kasworld/goguelike/enum/turnresulttype"
+	"github.com/kasworld/goguelike/game/activeobject/turnresult"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+// updateLearnedSkill learn skill by level, learned skill kept on level down
+func (ao *ActiveObject) updateLearnedSkill() {
+	for _, st := range gamedata.SkillList.LearnedByLevel(int(ao.AOTurnData.Level)) {
+		if ao.learnedSkill[st] {
+			continue
+		}
+		ao.learnedSkill[st] = true
+		ao.AppendTurnResult(turnresult.New(turnresulttype.LearnSkill, nil, float64(st)))
+	}
+}
+
+// coolSkill reduce cooltime 1 turn
+func (ao *ActiveObject) coolSkill() {
+	for i, v := range ao.skillCoolTurn {
+		if v > 0 {
+			ao.skillCoolTurn[i]--
+		}
+	}
+}
+
+// setLearnedSkill replace learned skill and cooltime by saved or recorded
+func (ao *ActiveObject) setLearnedSkill(
+	learned []skilltype.SkillType, coolTurn skilltype_vector.SkillTypeVector) {
+	ao.learnedSkill = [skilltype.SkillType_Count]bool{}
+	for _, st := range learned {
+		if st <= skilltype.None || st >= skilltype.SkillType_Count {
+			ao.log.Warn("skip unknown skill %v %v", ao, st)
+			continue
+		}
+		ao.learnedSkill[st] = true
+	}
+	ao.skillCoolTurn = coolTurn
+}
+
+func (ao *ActiveObject) GetLearnedSkillList() []skilltype.SkillType {
+	var rtn []skilltype.SkillType
+	for i, v := range ao.learnedSkill {
+		if v {
+			rtn = append(rtn, skilltype.SkillType(i))
+		}
+	}
+	return rtn
+}
+
+// CanCastSkill check learned, cooltime, sp
+func (ao *ActiveObject) CanCastSkill(st skilltype.SkillType) c2t_error.ErrorCode {
+	if st <= skilltype.None || st >= skilltype.SkillType_Count || !ao.learnedSkill[st] {
+		return c2t_error.ActionProhibited
+	}
+	if ao.skillCoolTurn[st] > 0 {
+		return c2t_error.SkillCooling
+	}
+	if ao.sp < gamedata.SkillList[st].SPCost {
+		return c2t_error.InsufficientSP
+	}
+	return c2t_error.None
+}
+
+// CastSkill use sp, start cooltime, apply SelfBuff
+// dangerobj of skill made by floor
+func (ao *ActiveObject) CastSkill(st skilltype.SkillType) {
+	sk := &gamedata.SkillList[st]
+	ao.sp -= sk.SPCost
+	ao.skillCoolTurn[st] = sk.CoolTurn
+	if tb := sk.SelfBuff; tb != nil {
+		ao.buffManager.Add(st.String(), true, true, tb)
+	}
+}
+
+func (ao *ActiveObject) ToPacket_SkillClient() []*c2t_obj.SkillClient {
+	var rtn []*c2t_obj.SkillClient
+	for _, st := range ao.GetLearnedSkillList() {
+		rtn = append(rtn, &c2t_obj.SkillClient{
+			Skill:      st,
+			RemainCool: ao.skillCoolTurn[st],
+		})
+	}
+	return rtn
+}
@@ -0,0 +1,39 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package activeobject
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kasworld/goguelike/enum/chattype"
+)
+
+func TestCheckChatInterval(t *testing.T) {
+	ao := &ActiveObject{}
+	now := time.Now()
+	if !ao.CheckChatInterval(chattype.Floor, now) {
+		t.Fatal("first chat refused")
+	}
+	if ao.CheckChatInterval(chattype.Floor, now.Add(time.Second)) {
+		t.Fatal("too frequent chat allowed")
+	}
+	if !ao.CheckChatInterval(chattype.Near, now.Add(time.Second)) {
+		t.Fatal("other chattype refused")
+	}
+	// client sent chattype out of range
+	for _, ct := range []chattype.ChatType{chattype.ChatType_Count, chattype.ChatType_Count + 10} {
+		if ao.CheckChatInterval(ct, now) {
+			t.Errorf("invalid chattype allowed %v", ct)
+		}
+	}
+}
@@ -69,6 +69,10 @@ func (ao *ActiveObject) Web_ActiveObjInfo(w http.ResponseWriter, r *http.Request
 		KickActiveObj
 	</a>
 	</br>
+	{{with .GetBoss}}
+	{{.}} AIPlan {{.AIPlan}} Equip {{.Equip}} Loot {{.Loot}} Respawn {{.Respawn}}
+	</br>
+	{{end}}
 	Level : {{.GetTurnData.Level}}
 	</br>
 	Exp : {{.GetTurnData.TotalExp}} 
@@ -143,6 +147,12 @@ func (ao *ActiveObject) Web_ActiveObjInfo(w http.ResponseWriter, r *http.Request
 		{{end}}
 	{{end}}
 
+	<br/>
+	Quest<br/>
+	{{range $i, $v := .GetQuestProgressList}}
+		<a href="/Quest?name={{$v.Name}}" target="_blank">{{$v}}</a>
+		<br/>
+	{{end}}
 	<br/>
 	Achieve stat<br/>
 	{{with .GetAchieveStat}}
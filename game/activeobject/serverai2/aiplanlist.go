@@ -41,6 +41,11 @@ var allPlanList = []planObj{
 	aiplan.Attack:         {"Attack", initPlanAttack, actPlanAttack},
 	aiplan.MoveStraight3:  {"MoveStraight3", initPlanMoveStraight3, actPlanMoveStraight3},
 	aiplan.MoveStraight5:  {"MoveStraight5", initPlanMoveStraight5, actPlanMoveStraight5},
+	aiplan.CastSkill:      {"CastSkill", initPlanCastSkill, actPlanCastSkill},
+	aiplan.Investigate:    {"Investigate", initPlanInvestigate, actPlanInvestigate},
+	aiplan.OpenDoor:       {"OpenDoor", initPlanOpenDoor, actPlanOpenDoor},
+	aiplan.FollowOwner:    {"FollowOwner", initPlanFollowOwner, actPlanFollowOwner},
+	aiplan.GuardOwner:     {"GuardOwner", initPlanGuardOwner, actPlanGuardOwner},
 }
 
 var aoType2aiPlan = [...]planList{
@@ -57,6 +62,9 @@ var aoType2aiPlan = [...]planList{
 		aiplan.Attack,
 		aiplan.MoveStraight3,
 		aiplan.MoveStraight5,
+		aiplan.CastSkill,
+		aiplan.Investigate,
+		aiplan.OpenDoor,
 	},
 	aotype.User: planList{
 		aiplan.StrollAround,
@@ -72,9 +80,25 @@ var aoType2aiPlan = [...]planList{
 		aiplan.Attack,
 		aiplan.MoveStraight3,
 		aiplan.MoveStraight5,
+		aiplan.CastSkill,
+		aiplan.OpenDoor,
 	},
 }
 
+// petPlanList replace plan of ao bound to owner, no wander
+var petPlanList = planList{
+	aiplan.FollowOwner,
+	aiplan.GuardOwner,
+	aiplan.Revenge,
+	aiplan.RechargeCan,
+	aiplan.PickupCarryObj,
+	aiplan.Equip,
+	aiplan.UsePotion,
+	aiplan.Attack,
+	aiplan.CastSkill,
+	aiplan.OpenDoor,
+}
+
 type planList []aiplan.AIPlan
 
 func (pl planList) String() string {
@@ -47,10 +47,13 @@ type ServerAI struct {
 	interDur    *intervalduration.IntervalDuration
 
 	runningPlanList planList
+	basePlanList    planList // plan when not pet
+	isPet           bool
 
 	movePath2Dest   [][2]int
 	planCarryObj    gamei.CarryingObjectI
 	planActiveObj   gamei.ActiveObjectI
+	planDoorPos     [2]int
 	planRemainCount int
 	moveDir         way9type.Way9Type
 
@@ -66,6 +69,11 @@ func (sai *ServerAI) String() string {
 }
 
 func New(seed int64, ao gamei.ActiveObjectI, l *g2log.LogBase) *ServerAI {
+	return NewWithPlanList(seed, ao, l, aoType2aiPlan[ao.GetActiveObjType()])
+}
+
+// NewWithPlanList plan repeated in pl selected more often
+func NewWithPlanList(seed int64, ao gamei.ActiveObjectI, l *g2log.LogBase, pl []aiplan.AIPlan) *ServerAI {
 	sai := &ServerAI{
 		rnd:    g2rand.NewWithSeed(seed),
 		ao:     ao,
@@ -80,11 +88,18 @@ func New(seed int64, ao gamei.ActiveObjectI, l *g2log.LogBase) *ServerAI {
 	}
 	sai.fieldObjUseTime = make(map[string]time.Time)
 	sai.interDur = intervalduration.New("")
-	sai.runningPlanList = aoType2aiPlan[sai.ao.GetActiveObjType()].dup()
+	sai.basePlanList = planList(pl).dup()
+	sai.setPlanList(sai.basePlanList)
+	return sai
+}
+
+// setPlanList shuffled copy of pl
+func (sai *ServerAI) setPlanList(pl planList) {
+	sai.runningPlanList = pl.dup()
 	sai.rnd.Shuffle(len(sai.runningPlanList), func(i, j int) {
 		sai.runningPlanList[i], sai.runningPlanList[j] = sai.runningPlanList[j], sai.runningPlanList[i]
 	})
-	return sai
+	sai.planRemainCount = 0
 }
 
 func (sai *ServerAI) Cleanup() {
@@ -107,6 +122,15 @@ func (sai *ServerAI) actTurn(turnTime time.Time) {
 	if !sai.ao.IsAlive() {
 		return
 	}
+	// tamed, summoned or released
+	if isPet := sai.ao.GetPetOwner() != nil; isPet != sai.isPet {
+		sai.isPet = isPet
+		if isPet {
+			sai.setPlanList(petPlanList)
+		} else {
+			sai.setPlanList(sai.basePlanList)
+		}
+	}
 	if NeedChangePlan(sai.ao.GetTurnActReqRsp()) {
 		sai.planRemainCount = 0
 	}
@@ -136,6 +160,19 @@ func (sai *ServerAI) actTurn(turnTime time.Time) {
 		sai.aoAttackLast() != nil {
 
 		sai.runningPlanList.move2Front(aiplan.Revenge)
+		sai.selectPlan()
+	} else if sai.runningPlanList.getCurrentPlan() != aiplan.GuardOwner &&
+		sai.runningPlanList.getCurrentPlan() != aiplan.Revenge &&
+		sai.petTarget2Attack() != nil &&
+		sai.runningPlanList.move2Front(aiplan.GuardOwner) {
+
+		sai.selectPlan()
+	} else if sai.runningPlanList.getCurrentPlan() != aiplan.Attack &&
+		sai.runningPlanList.getCurrentPlan() != aiplan.Revenge &&
+		sai.runningPlanList.getCurrentPlan() != aiplan.Investigate &&
+		len(sai.ao.GetHeardNoiseList()) > 0 &&
+		sai.runningPlanList.move2Front(aiplan.Investigate) {
+
 		sai.selectPlan()
 	} else {
 		// need select new plan?
@@ -18,6 +18,7 @@ import (
 	"github.com/kasworld/goguelike/config/gamedata"
 	"github.com/kasworld/goguelike/config/viewportdata"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/enum/petorder"
 	"github.com/kasworld/goguelike/enum/tile_flag"
 	"github.com/kasworld/goguelike/enum/way9type"
 	"github.com/kasworld/goguelike/game/attackcheck"
@@ -393,7 +394,9 @@ func initPlanAttack(sai *ServerAI) int {
 		func(o uuidposman.UUIDPosI, x, y int, xylen findnear.XYLen) bool {
 			if o.GetUUID() != sai.ao.GetUUID() &&
 				o.(gamei.ActiveObjectI).IsAlive() &&
-				ter.GetTiles()[x][y].CanBattle() {
+				ter.GetTiles()[x][y].CanBattle() &&
+				sai.isInSight(xylen) &&
+				sai.isHostile(o.(gamei.ActiveObjectI)) {
 				return true
 			}
 			return false
@@ -449,7 +452,7 @@ func actPlanAttack(sai *ServerAI) bool {
 
 func initPlanRevenge(sai *ServerAI) int {
 	dstActiveObj := sai.aoAttackLast()
-	if dstActiveObj == nil {
+	if dstActiveObj == nil || !sai.attackAllowed(dstActiveObj) {
 		return 0
 	}
 	dstx, dsty, exist := sai.currentFloor.GetActiveObjPosMan().GetXYByUUID(dstActiveObj.GetUUID())
@@ -535,17 +538,169 @@ func actPlanEquip(sai *ServerAI) bool {
 }
 
 func initPlanUsePotion(sai *ServerAI) int {
-	if len(sai.ao.GetInven().GetPotionList()) == 0 {
+	if sai.selectPotion2Use() == nil {
 		return 0
 	}
 	return 1
 }
 func actPlanUsePotion(sai *ServerAI) bool {
-	for _, po := range sai.ao.GetInven().GetPotionList() {
-		if po == nil {
+	if po := sai.selectPotion2Use(); po != nil {
+		sai.sendActNotiPacket2Floor(c2t_idcmd.DrinkPotion, way9type.Center,
+			po.GetUUID())
+	}
+	return false
+}
+
+// initPlanInvestigate move to loudest noise heard last turn
+func initPlanInvestigate(sai *ServerAI) int {
+	heardList := sai.ao.GetHeardNoiseList()
+	if len(heardList) == 0 {
+		return 0
+	}
+	sai.movePath2Dest = sai.makePath2Dest(heardList[0].X, heardList[0].Y)
+	if len(sai.movePath2Dest) == 0 {
+		return 0
+	}
+	return len(sai.movePath2Dest) + 5
+}
+func actPlanInvestigate(sai *ServerAI) bool {
+	if _, _, found := sai.findNearEnemy(); found {
+		// enemy in sight, plan change to attack
+		return false
+	}
+	moveDir, isContact := sai.followPath2Dest()
+	if !isContact {
+		return false
+	}
+	if moveDir != way9type.Center {
+		sai.sendActNotiPacket2Floor(c2t_idcmd.Move, moveDir, "")
+		return true
+	}
+	// arrived, nothing found
+	return false
+}
+
+// initPlanOpenDoor move near closed door can open
+func initPlanOpenDoor(sai *ServerAI) int {
+	tr := sai.currentFloor.GetTerrain()
+	findObj, dstx, dsty := sai.currentFloor.GetFieldObjPosMan().Search1stByXYLenList(
+		viewportdata.ViewportXYLenList,
+		sai.aox, sai.aoy,
+		func(o uuidposman.UUIDPosI, x, y int, xylen findnear.XYLen) bool {
+			fo, ok := o.(*fieldobject.FieldObject)
+			if !ok || fo.ActType != fieldobjacttype.Door || !fo.DoorClosed {
+				return false
+			}
+			if fo.DoorLocked && !sai.ao.GetInven().HasKey(fo.ID) {
+				return false
+			}
+			lastTime := sai.fieldObjUseTime[fo.ID]
+			return !lastTime.Add(time.Second * 60).After(sai.turnTime)
+		},
+	)
+	if findObj == nil {
+		return 0
+	}
+	sai.planDoorPos = [2]int{dstx, dsty}
+	w, h := tr.GetXYLen()
+	if contact, _ := way9type.CalcContactDirWrappedXY(sai.aox, sai.aoy, dstx, dsty, w, h); contact {
+		sai.movePath2Dest = [][2]int{{sai.aox, sai.aoy}}
+		return 1
+	}
+	tiles := tr.GetTiles()
+	for dir := way9type.Way9Type(1); dir < way9type.Way9Type_Count; dir++ {
+		x, y := tr.WrapXY(dstx+dir.Dx(), dsty+dir.Dy())
+		if !tiles[x][y].CharPlaceable() {
 			continue
 		}
-		// no use potion
+		sai.movePath2Dest = sai.makePath2Dest(x, y)
+		if len(sai.movePath2Dest) > 0 {
+			return len(sai.movePath2Dest) + 5
+		}
+	}
+	// unreachable, skip for a while
+	sai.fieldObjUseTime[findObj.GetUUID()] = sai.turnTime
+	return 0
+}
+func actPlanOpenDoor(sai *ServerAI) bool {
+	moveDir, isContact := sai.followPath2Dest()
+	if !isContact {
+		return false
+	}
+	if moveDir != way9type.Center {
+		sai.sendActNotiPacket2Floor(c2t_idcmd.Move, moveDir, "")
+		return true
+	}
+	// dest arrived
+	w, h := sai.currentFloor.GetTerrain().GetXYLen()
+	contact, dir := way9type.CalcContactDirWrappedXY(
+		sai.aox, sai.aoy, sai.planDoorPos[0], sai.planDoorPos[1], w, h)
+	if !contact || dir == way9type.Center {
+		return false
+	}
+	if fo := sai.currentFloor.GetTerrain().GetDoorAt(sai.planDoorPos[0], sai.planDoorPos[1]); fo != nil {
+		sai.fieldObjUseTime[fo.ID] = sai.turnTime
+	}
+	sai.sendActNotiPacket2Floor(c2t_idcmd.OpenDoor, dir, "")
+	// plan change to other
+	return false
+}
+
+// initPlanFollowOwner move near owner, wait by Stay order
+func initPlanFollowOwner(sai *ServerAI) int {
+	if sai.ao.GetPetOwner() == nil {
+		return 0
+	}
+	if order, _ := sai.ao.GetPetOrder(); order == petorder.Stay {
+		return 1
+	}
+	ox, oy, exist := sai.ownerPos()
+	if !exist || sai.nearOwner(ox, oy) {
+		return 0
+	}
+	sai.movePath2Dest = sai.makePath2Dest(ox, oy)
+	if len(sai.movePath2Dest) == 0 {
+		return 0
+	}
+	return len(sai.movePath2Dest)
+}
+func actPlanFollowOwner(sai *ServerAI) bool {
+	if order, _ := sai.ao.GetPetOrder(); order == petorder.Stay {
+		sai.sendActNotiPacket2Floor(c2t_idcmd.Meditate, way9type.Center, "")
+		return false
+	}
+	ox, oy, exist := sai.ownerPos()
+	if !exist || sai.nearOwner(ox, oy) {
+		return false
+	}
+	moveDir, isContact := sai.followPath2Dest()
+	if !isContact {
+		return false
+	}
+	if moveDir != way9type.Center {
+		sai.sendActNotiPacket2Floor(c2t_idcmd.Move, moveDir, "")
+		return true
 	}
 	return false
 }
+
+// initPlanGuardOwner attack ordered target or attacker of owner
+func initPlanGuardOwner(sai *ServerAI) int {
+	dstActiveObj := sai.petTarget2Attack()
+	if dstActiveObj == nil {
+		return 0
+	}
+	dstx, dsty, exist := sai.currentFloor.GetActiveObjPosMan().GetXYByUUID(dstActiveObj.GetUUID())
+	if !exist {
+		return 0
+	}
+	sai.planActiveObj = dstActiveObj
+	sai.movePath2Dest = sai.makePath2Dest(dstx, dsty)
+	if len(sai.movePath2Dest) == 0 {
+		return 0
+	}
+	return len(sai.movePath2Dest) + 10
+}
+func actPlanGuardOwner(sai *ServerAI) bool {
+	return actPlanAttack(sai)
+}
@@ -14,9 +14,15 @@ package serverai2
 import (
 	"math/rand"
 
+	"github.com/kasworld/findnear"
+	"github.com/kasworld/go-abs"
 	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/config/leveldata"
+	"github.com/kasworld/goguelike/config/viewportdata"
 	"github.com/kasworld/goguelike/enum/aiplan"
 	"github.com/kasworld/goguelike/enum/equipslottype"
+	"github.com/kasworld/goguelike/enum/petorder"
+	"github.com/kasworld/goguelike/enum/potiontype"
 	"github.com/kasworld/goguelike/enum/turnresulttype"
 	"github.com/kasworld/goguelike/enum/way9type"
 	"github.com/kasworld/goguelike/game/aoactreqrsp"
@@ -195,6 +201,66 @@ func (sai *ServerAI) needRecharge() bool {
 	return sai.ao.GetSPRate() < 0.3 || sai.ao.GetHPRate() < 0.3
 }
 
+// selectPotion2Use use identified potion if needed,
+// drink not identified potion to identify it when not in danger
+func (sai *ServerAI) selectPotion2Use() gamei.PotionI {
+	kn := sai.ao.GetIdentifyKnowledge()
+	var unknown gamei.PotionI
+	for _, po := range sai.ao.GetInven().GetPotionList() {
+		pt := po.GetPotionType()
+		if !kn.KnowPotion(pt) {
+			unknown = po
+			continue
+		}
+		if sai.needUsePotion(pt) {
+			return po
+		}
+	}
+	if unknown != nil && !sai.needRecharge() {
+		return unknown
+	}
+	return nil
+}
+
+func (sai *ServerAI) needUsePotion(pt potiontype.PotionType) bool {
+	td := sai.ao.GetTurnData()
+	hpLack := td.HPMax - sai.ao.GetHP()
+	spLack := td.SPMax * (1 - sai.ao.GetSPRate())
+	switch pt {
+	case potiontype.RecoverHP10:
+		return hpLack > 10
+	case potiontype.RecoverHP50:
+		return hpLack > 50
+	case potiontype.RecoverHP100:
+		return hpLack > 100
+
+	case potiontype.RecoverSP10:
+		return spLack > 10
+	case potiontype.RecoverSP50:
+		return spLack > 50
+	case potiontype.RecoverSP100:
+		return spLack > 100
+
+	case potiontype.RecoverHPRate10:
+		return hpLack > td.HPMax/10
+	case potiontype.RecoverHPRate50, potiontype.BuffRecoverHP1:
+		return sai.ao.GetHPRate() < 0.5
+	case potiontype.RecoverHPFull:
+		return sai.ao.GetHPRate() < 0.1
+
+	case potiontype.RecoverSPRate10:
+		return spLack > td.SPMax/10
+	case potiontype.RecoverSPRate50, potiontype.BuffRecoverSP1:
+		return sai.ao.GetSPRate() < 0.5
+	case potiontype.RecoverSPFull:
+		return sai.ao.GetSPRate() < 0.1
+
+	case potiontype.BuffSight1, potiontype.BuffSight5, potiontype.BuffSightMax:
+		return td.Sight <= leveldata.Sight(int(td.Level))
+	}
+	return false
+}
+
 func (sai *ServerAI) aoAttackLast() gamei.ActiveObjectI {
 	for _, v := range sai.ao.GetTurnResultList() {
 		if v.GetTurnResultType() == turnresulttype.AttackedFrom {
@@ -214,6 +280,87 @@ func (sai *ServerAI) aoAttackLast() gamei.ActiveObjectI {
 	return nil
 }
 
+// isInSight xylen from ao not blocked by wall, dark
+func (sai *ServerAI) isInSight(xylen findnear.XYLen) bool {
+	sightMat := sai.ao.GetSightMat()
+	if sightMat == nil {
+		return true
+	}
+	i, exist := viewportdata.ViewportXY2Index[[2]int{xylen.X, xylen.Y}]
+	if !exist {
+		return false
+	}
+	return sightMat[i] <= float32(sai.ao.GetTurnData().Sight)
+}
+
+// isHostile target of Attack plan by tower diplomacy, not owner or pet
+func (sai *ServerAI) isHostile(dstao gamei.ActiveObjectI) bool {
+	return !sai.ao.IsPartyOf(dstao) &&
+		sai.currentFloor.GetTower().GetDiplomacy().IsHostile(
+			sai.ao.GetBattleFaction(), dstao.GetBattleFaction())
+}
+
+// attackAllowed target of Revenge plan by tower diplomacy, not owner or pet
+func (sai *ServerAI) attackAllowed(dstao gamei.ActiveObjectI) bool {
+	return !sai.ao.IsPartyOf(dstao) &&
+		sai.currentFloor.GetTower().GetDiplomacy().AttackRate(
+			sai.ao.GetBattleFaction(), dstao.GetBattleFaction()) > 0
+}
+
+// ownerPos pos of pet owner in current floor
+func (sai *ServerAI) ownerPos() (int, int, bool) {
+	owner := sai.ao.GetPetOwner()
+	if owner == nil {
+		return 0, 0, false
+	}
+	return sai.currentFloor.GetActiveObjPosMan().GetXYByUUID(owner.GetUUID())
+}
+
+// nearOwner in PetFollowLen from owner at ox,oy
+func (sai *ServerAI) nearOwner(ox, oy int) bool {
+	w, h := sai.currentFloor.GetTerrain().GetXYLen()
+	dx, dy := way9type.CalcDxDyWrapped(ox-sai.aox, oy-sai.aoy, w, h)
+	return abs.Absi(dx) <= gameconst.PetFollowLen && abs.Absi(dy) <= gameconst.PetFollowLen
+}
+
+// petTarget2Attack ordered target or attacker of owner in current floor
+// nil if not pet or Stay order
+func (sai *ServerAI) petTarget2Attack() gamei.ActiveObjectI {
+	owner := sai.ao.GetPetOwner()
+	if owner == nil {
+		return nil
+	}
+	aoPosMan := sai.currentFloor.GetActiveObjPosMan()
+	order, target := sai.ao.GetPetOrder()
+	switch order {
+	case petorder.Stay:
+		return nil
+	case petorder.Attack:
+		if dst, ok := aoPosMan.GetByUUID(target).(gamei.ActiveObjectI); ok && dst.IsAlive() {
+			return dst
+		}
+		// target dead or gone
+		sai.ao.SetPetOrder(petorder.Follow, "")
+	}
+	// turn result of owner in other floor not safe to read
+	if _, _, exist := aoPosMan.GetXYByUUID(owner.GetUUID()); !exist {
+		return nil
+	}
+	for _, v := range owner.GetTurnResultList() {
+		if v.GetTurnResultType() != turnresulttype.AttackedFrom {
+			continue
+		}
+		dst, ok := v.GetDstObj().(gamei.ActiveObjectI)
+		if !ok || !dst.IsAlive() || sai.ao.IsPartyOf(dst) {
+			continue
+		}
+		if _, _, exist := aoPosMan.GetXYByUUID(dst.GetUUID()); exist {
+			return dst
+		}
+	}
+	return nil
+}
+
 func (sai *ServerAI) overloadRate() float64 {
 	return sai.ao.GetTurnData().LoadRate
 }
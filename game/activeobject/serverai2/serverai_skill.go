@@ -0,0 +1,112 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serverai2
+
+import (
+	"github.com/kasworld/findnear"
+	"github.com/kasworld/go-abs"
+	"github.com/kasworld/goguelike/config/gamedata"
+	"github.com/kasworld/goguelike/config/skilldata"
+	"github.com/kasworld/goguelike/config/viewportdata"
+	"github.com/kasworld/goguelike/enum/dangertype"
+	"github.com/kasworld/goguelike/enum/skilltype"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/attackcheck"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/lib/uuidposman"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+)
+
+func initPlanCastSkill(sai *ServerAI) int {
+	if len(sai.ao.GetLearnedSkillList()) == 0 {
+		return 0
+	}
+	return 10
+}
+func actPlanCastSkill(sai *ServerAI) bool {
+	for _, st := range sai.ao.GetLearnedSkillList() {
+		if sai.ao.CanCastSkill(st) != c2t_error.None {
+			continue
+		}
+		if gamedata.SkillList[st].ForRecover {
+			if sai.ao.GetHPRate() < 0.5 {
+				sai.sendCastPacket2Floor(st, way9type.Center, sai.aox, sai.aoy)
+				return true
+			}
+			continue
+		}
+		dstx, dsty, found := sai.findNearEnemy()
+		if !found {
+			return false
+		}
+		if dir, x, y, ok := sai.calcSkillTarget(st, dstx, dsty); ok {
+			sai.sendCastPacket2Floor(st, dir, x, y)
+			return true
+		}
+	}
+	return false
+}
+
+// findNearEnemy nearest alive hostile ao in battle tile
+func (sai *ServerAI) findNearEnemy() (int, int, bool) {
+	ter := sai.currentFloor.GetTerrain()
+	findObj, dstx, dsty := sai.currentFloor.GetActiveObjPosMan().Search1stByXYLenList(
+		viewportdata.ViewportXYLenList,
+		sai.aox, sai.aoy,
+		func(o uuidposman.UUIDPosI, x, y int, xylen findnear.XYLen) bool {
+			return o.GetUUID() != sai.ao.GetUUID() &&
+				o.(gamei.ActiveObjectI).IsAlive() &&
+				ter.GetTiles()[x][y].CanBattle() &&
+				sai.isInSight(xylen) &&
+				sai.isHostile(o.(gamei.ActiveObjectI))
+		},
+	)
+	return dstx, dsty, findObj != nil
+}
+
+// calcSkillTarget return dir, pos to cast st to enemy at dstx,dsty
+func (sai *ServerAI) calcSkillTarget(st skilltype.SkillType, dstx, dsty int) (
+	way9type.Way9Type, int, int, bool) {
+
+	sk := &gamedata.SkillList[st]
+	tiles := sai.currentFloor.GetTerrain().GetTiles()
+	switch sk.Target {
+	case skilldata.TargetSelf:
+		if sk.DangerType == dangertype.None { // self buff in battle
+			return way9type.Center, sai.aox, sai.aoy, true
+		}
+		_, contact := attackcheck.CanBasicAttackTo(tiles, sai.aox, sai.aoy, dstx, dsty)
+		return way9type.Center, sai.aox, sai.aoy, contact
+	case skilldata.TargetDir:
+		dir, canShoot := attackcheck.CanShootTo(tiles, sai.aox, sai.aoy, dstx, dsty)
+		return dir, dstx, dsty, canShoot
+	case skilldata.TargetPos:
+		w, h := tiles.GetXYLen()
+		dx, dy := way9type.CalcDxDyWrapped(dstx-sai.aox, dsty-sai.aoy, w, h)
+		inRange := abs.Absi(dx) <= sk.CastRange && abs.Absi(dy) <= sk.CastRange
+		return way9type.Center, dstx, dsty, inRange
+	}
+	return way9type.Center, 0, 0, false
+}
+
+func (sai *ServerAI) sendCastPacket2Floor(
+	st skilltype.SkillType, dir way9type.Way9Type, x, y int) {
+	sai.ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act:   c2t_idcmd.Cast,
+		Dir:   dir,
+		Skill: st,
+		X:     x,
+		Y:     y,
+	})
+}
@@ -16,6 +16,8 @@ import (
 
 	"github.com/kasworld/goguelike/enum/condition"
 	"github.com/kasworld/goguelike/enum/condition_flag"
+	"github.com/kasworld/goguelike/enum/petorder"
+	"github.com/kasworld/goguelike/enum/skilltype"
 	"github.com/kasworld/goguelike/enum/way9type"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
@@ -25,6 +27,20 @@ type Act struct {
 	Act  c2t_idcmd.CommandID
 	Dir  way9type.Way9Type
 	UUID string
+
+	// Cast only
+	Skill skilltype.SkillType
+	X, Y  int // target pos of skilldata.TargetPos
+
+	// Craft only
+	Recipe string
+
+	// AcceptQuest, CompleteQuest only
+	Quest string
+
+	// CommandPet only, UUID is pet
+	PetOrder petorder.PetOrder
+	Target   string // ao to attack, potion to feed
 }
 
 func (act Act) CalcAPByActAndCondition(cndflag condition_flag.ConditionFlag) float64 {
@@ -50,7 +50,7 @@ func (toam *ActiveObjID2Floor) ActiveObjEnterTower(
 	toam.mutex.Lock()
 	defer toam.mutex.Unlock()
 
-	x, y, err := dstFloor.SearchRandomActiveObjPosInRoomOrRandPos()
+	x, y, err := searchEnterPos(dstFloor, ao)
 	if err != nil {
 		return err
 	}
@@ -97,6 +97,27 @@ func (toam *ActiveObjID2Floor) ActiveObjMoveToFloor(
 	return nil
 }
 
+// ActiveObjMoveToFloorNear enter empty pos near x,y, for pet follow owner
+func (toam *ActiveObjID2Floor) ActiveObjMoveToFloorNear(
+	dstFloor gamei.FloorI, ao gamei.ActiveObjectI, x, y int) error {
+
+	toam.mutex.Lock()
+	defer toam.mutex.Unlock()
+
+	oldfloor := toam.aoID2Floor[ao.GetUUID()]
+	if oldfloor != nil && oldfloor != dstFloor {
+		toam.aoLeaveFloorNolock(ao, oldfloor)
+	}
+	toam.aoID2Floor[ao.GetUUID()] = dstFloor
+	dstFloor.GetReqCh() <- &cmd2floor.ReqEnterFloor{
+		ActiveObj: ao,
+		X:         x,
+		Y:         y,
+		Near:      true,
+	}
+	return nil
+}
+
 func (toam *ActiveObjID2Floor) ActiveObjRebirthToFloor(
 	dstFloor gamei.FloorI, ao gamei.ActiveObjectI) error {
 
@@ -107,7 +128,7 @@ func (toam *ActiveObjID2Floor) ActiveObjRebirthToFloor(
 	if oldfloor != nil && oldfloor != dstFloor {
 		toam.aoLeaveFloorNolock(ao, oldfloor)
 	}
-	x, y, err := dstFloor.SearchRandomActiveObjPosInRoomOrRandPos()
+	x, y, err := searchEnterPos(dstFloor, ao)
 	if err != nil {
 		toam.log.Fatal("%v %v %v", err, dstFloor, ao)
 	}
@@ -115,6 +136,14 @@ func (toam *ActiveObjID2Floor) ActiveObjRebirthToFloor(
 	return nil
 }
 
+// searchEnterPos boss near its script pos, other rand pos
+func searchEnterPos(dstFloor gamei.FloorI, ao gamei.ActiveObjectI) (int, int, error) {
+	if bs := ao.GetBoss(); bs != nil {
+		return dstFloor.SearchBossPos(bs)
+	}
+	return dstFloor.SearchRandomActiveObjPosInRoomOrRandPos()
+}
+
 func (toam *ActiveObjID2Floor) ActiveObjLeaveFloor(ao gamei.ActiveObjectI) {
 	toam.mutex.Lock()
 	defer toam.mutex.Unlock()
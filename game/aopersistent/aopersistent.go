@@ -9,17 +9,42 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package aopersistent user activeobject data kept across tower restart
 package aopersistent
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/kasworld/goguelike/enum/achievetype_vector"
+	"github.com/kasworld/goguelike/enum/condition_vector"
+	"github.com/kasworld/goguelike/enum/equipslottype"
 	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/fieldobjacttype_vector"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/potiontype_vector"
+	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/enum/scrolltype_vector"
+	"github.com/kasworld/goguelike/enum/skilltype"
+	"github.com/kasworld/goguelike/enum/skilltype_vector"
+	"github.com/kasworld/goguelike/game/aoquest"
 	"github.com/kasworld/goguelike/game/bias"
+	"github.com/kasworld/goguelike/game/identify"
+	"github.com/kasworld/goguelike/game/visitarea"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd_stats"
 )
 
+func (aop AOPersistent) String() string {
+	return fmt.Sprintf("AOPersistent[%v %v %v]",
+		aop.NickName, aop.UUID, aop.Update.Format(time.RFC3339))
+}
+
+// AOPersistent pet binding is not saved,
+// pet is lost on owner leave tower or tower restart with its exp, level :
+// tamed pet stay in floor as wild ao, summoned pet is removed
 type AOPersistent struct {
-	UUID string // sessionid? aouuid?
+	UUID        string // aouuid at save time
+	SessionUUID string // owner session, key of store
 
 	Create   time.Time `prettystring:"simple"` // first
 	NickName string
@@ -29,4 +54,48 @@ type AOPersistent struct {
 	Exp    float64
 	Wealth float64
 	Bias   bias.Bias `prettystring:"simple"` // current
+
+	BattleExp float64
+	HomeFloor string // floor name to enter on login
+	HP        float64
+	SP        float64
+
+	LearnedSkill  []skilltype.SkillType            `prettystring:"simple"`
+	SkillCoolTurn skilltype_vector.SkillTypeVector `prettystring:"simple"` // remain turn to cast again
+
+	// inventory
+	Wallet     float64
+	Ammo       int
+	EquipList  []EquipPersistent       `prettystring:"simple"`
+	PotionList []potiontype.PotionType `prettystring:"simple"`
+	ScrollList []scrolltype.ScrollType `prettystring:"simple"`
+
+	VisitAreaList []VisitAreaPersistent `prettystring:"simple"`
+
+	AchieveStat   achievetype_vector.AchieveTypeVector         `prettystring:"simple"`
+	PotionStat    potiontype_vector.PotionTypeVector           `prettystring:"simple"`
+	ScrollStat    scrolltype_vector.ScrollTypeVector           `prettystring:"simple"`
+	Identified    identify.Knowledge                           `prettystring:"simple"`
+	QuestList     []aoquest.Progress                           `prettystring:"simple"`
+	FoActStat     fieldobjacttype_vector.FieldObjActTypeVector `prettystring:"simple"`
+	AOActionStat  c2t_idcmd_stats.CommandIDStat                `prettystring:"simple"`
+	ConditionStat condition_vector.ConditionVector             `prettystring:"simple"`
+}
+
+type EquipPersistent struct {
+	Name       string
+	Material   string // empty : saved before material, no light
+	EquipType  equipslottype.EquipSlotType
+	Faction    factiontype.FactionType
+	BiasLen    float64
+	Durability int // 0 : saved before durability, restored as max
+	Equipped   bool
+}
+
+type VisitAreaPersistent struct {
+	FloorName           string
+	W                   int
+	H                   int
+	DiscoveredTileCount int
+	BitsList            []visitarea.BitContainder
 }
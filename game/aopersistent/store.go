@@ -0,0 +1,126 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aopersistent
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/kasworld/configutil"
+)
+
+// StoreI save/take AOPersistent by SessionUUID
+// session uuid is issued by server and known only to owner client
+// tower use FileStore by default, other backend can replace it
+type StoreI interface {
+	// Take load and hold saved ao, can not be taken again until Return
+	// held ao is not lost by fail to enter or tower crash
+	Take(sessionuuid string) (*AOPersistent, error)
+	// Return put back taken ao, when fail to enter tower
+	Return(sessionuuid string) error
+	// Commit delete taken ao, after entered ao saved with its current session
+	Commit(sessionuuid string) error
+	// Save overwrite, ao in play is saved again on suspend/leave
+	Save(aop *AOPersistent) error
+}
+
+func (fs *FileStore) String() string {
+	return fmt.Sprintf("FileStore[%v]", fs.dir)
+}
+
+// FileStore save each AOPersistent to json file in dir
+type FileStore struct {
+	mutex sync.Mutex `prettystring:"hide"`
+	dir   string
+}
+
+// NewFileStore put back ao taken but not committed by last run
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	fs := &FileStore{
+		dir: dir,
+	}
+	takenList, err := filepath.Glob(filepath.Join(dir, "*.json"+takenSuffix))
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range takenList {
+		if err := os.Rename(v, strings.TrimSuffix(v, takenSuffix)); err != nil {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+const takenSuffix = ".taken"
+
+func (fs *FileStore) makeFilename(sessionuuid string) string {
+	return filepath.Join(fs.dir, url.PathEscape(sessionuuid)+".json")
+}
+
+func (fs *FileStore) Take(sessionuuid string) (*AOPersistent, error) {
+	if sessionuuid == "" {
+		return nil, fmt.Errorf("empty session uuid")
+	}
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	filename := fs.makeFilename(sessionuuid)
+	if err := os.Rename(filename, filename+takenSuffix); err != nil {
+		return nil, err
+	}
+	aop := &AOPersistent{}
+	if err := configutil.LoadJSON(filename+takenSuffix, aop); err != nil {
+		if rerr := os.Rename(filename+takenSuffix, filename); rerr != nil {
+			return nil, rerr
+		}
+		return nil, err
+	}
+	return aop, nil
+}
+
+// Return not overwrite ao saved after Take
+func (fs *FileStore) Return(sessionuuid string) error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	filename := fs.makeFilename(sessionuuid)
+	if _, err := os.Stat(filename); err == nil {
+		return os.Remove(filename + takenSuffix)
+	}
+	return os.Rename(filename+takenSuffix, filename)
+}
+
+func (fs *FileStore) Commit(sessionuuid string) error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	return os.Remove(fs.makeFilename(sessionuuid) + takenSuffix)
+}
+
+// Save write to temp file then rename, not to break old data on fail
+func (fs *FileStore) Save(aop *AOPersistent) error {
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	if aop.SessionUUID == "" {
+		return fmt.Errorf("empty session uuid %v", aop)
+	}
+	filename := fs.makeFilename(aop.SessionUUID)
+	tmpname := filename + ".tmp"
+	if err := configutil.SaveJSON(tmpname, aop); err != nil {
+		return err
+	}
+	return os.Rename(tmpname, filename)
+}
@@ -0,0 +1,115 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aopersistent
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func newTestStore(t *testing.T) *FileStore {
+	dir, err := ioutil.TempDir("", "aopersistent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	fs, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+func TestFileStore_TakeOnce(t *testing.T) {
+	fs := newTestStore(t)
+	if err := fs.Save(&AOPersistent{SessionUUID: "s1", NickName: "same", Wallet: 100}); err != nil {
+		t.Fatal(err)
+	}
+	// same nickname other session can not restore
+	if _, err := fs.Take("s2"); err == nil {
+		t.Fatal("take by other session")
+	}
+	aop, err := fs.Take("s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aop.NickName != "same" || aop.Wallet != 100 {
+		t.Fatalf("restored %v", aop)
+	}
+	// second login can not make copy
+	if _, err := fs.Take("s1"); err == nil {
+		t.Fatal("take twice")
+	}
+}
+
+func TestFileStore_SaveNoSession(t *testing.T) {
+	fs := newTestStore(t)
+	if err := fs.Save(&AOPersistent{NickName: "nosession"}); err == nil {
+		t.Fatal("saved without session")
+	}
+	if _, err := fs.Take(""); err == nil {
+		t.Fatal("take empty session")
+	}
+}
+
+func TestFileStore_ReturnCommit(t *testing.T) {
+	fs := newTestStore(t)
+	if err := fs.Save(&AOPersistent{SessionUUID: "s1", Wallet: 100}); err != nil {
+		t.Fatal(err)
+	}
+	// fail to enter, can take again
+	if _, err := fs.Take("s1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Return("s1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.Take("s1"); err != nil {
+		t.Fatal("not returned", err)
+	}
+	// entered, saved with new session
+	if err := fs.Save(&AOPersistent{SessionUUID: "s2", Wallet: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Commit("s1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.Take("s1"); err == nil {
+		t.Fatal("take committed")
+	}
+	if _, err := fs.Take("s2"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileStore_RestoreTakenByRestart(t *testing.T) {
+	fs := newTestStore(t)
+	if err := fs.Save(&AOPersistent{SessionUUID: "s1", Wallet: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.Take("s1"); err != nil {
+		t.Fatal(err)
+	}
+	// crash before commit
+	fs, err := NewFileStore(fs.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aop, err := fs.Take("s1")
+	if err != nil {
+		t.Fatal("lost by restart", err)
+	}
+	if aop.Wallet != 100 {
+		t.Fatalf("restored %v", aop)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aoquest per ao progress of accepted quest, kept in aopersistent
+package aoquest
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/config/questdata"
+)
+
+func (p Progress) String() string {
+	return fmt.Sprintf("Progress[%v %v %v]", p.Name, p.Count, p.Completed)
+}
+
+type Progress struct {
+	Name      string
+	Count     []int // per objective, Deliver counted from bag on check
+	Completed bool
+}
+
+func New(q *questdata.Quest) *Progress {
+	return &Progress{
+		Name:  q.Name,
+		Count: make([]int, len(q.Objective)),
+	}
+}
+
+// FitTo reset count if quest objective changed after save
+func (p *Progress) FitTo(q *questdata.Quest) {
+	if len(p.Count) != len(q.Objective) {
+		p.Count = make([]int, len(q.Objective))
+	}
+}
+
+// AddCount add n to objective i, limit to need count
+// return true if changed
+func (p *Progress) AddCount(q *questdata.Quest, i int, n int) bool {
+	if p.Completed {
+		return false
+	}
+	need := q.Objective[i].Count
+	if p.Count[i] >= need {
+		return false
+	}
+	p.Count[i] += n
+	if p.Count[i] > need {
+		p.Count[i] = need
+	}
+	return true
+}
+
+// Find return nil if not found
+func Find(pList []*Progress, name string) *Progress {
+	for _, v := range pList {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aoquest
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/config/questdata"
+)
+
+func TestAddCount(t *testing.T) {
+	q, err := questdata.ParseQuest("Hunt Floor:Practice Kill:Red*3,Reach:SoilPlant Exp:100")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	p := New(q)
+	if !p.AddCount(q, 0, 2) || !p.AddCount(q, 0, 2) || p.AddCount(q, 0, 1) {
+		t.Errorf("invalid AddCount result %v", p)
+	}
+	if p.Count[0] != 3 {
+		t.Errorf("count over need %v", p)
+	}
+	p.Completed = true
+	if p.AddCount(q, 1, 1) {
+		t.Errorf("count changed after complete %v", p)
+	}
+	if Find([]*Progress{p}, "Hunt") != p || Find([]*Progress{p}, "None") != nil {
+		t.Errorf("invalid Find")
+	}
+	p.Count = p.Count[:1]
+	p.FitTo(q)
+	if len(p.Count) != 2 || p.Count[0] != 0 {
+		t.Errorf("invalid FitTo %v", p)
+	}
+}
@@ -36,3 +36,31 @@ func CanBasicAttackTo(ta tilearea.TileArea, x1, y1, x2, y2 int) (way9type.Way9Ty
 	contact, dir := way9type.CalcContactDirWrappedXY(x1, y1, x2, y2, w, h)
 	return dir, contact && !ta[x1][y1].NoBattle() && !ta[x2][y2].NoBattle()
 }
+
+// CanShootTo projectile reach x2,y2 in range without blocked tile
+func CanShootTo(ta tilearea.TileArea, x1, y1, x2, y2 int) (way9type.Way9Type, bool) {
+	w, h := ta.GetXYLen()
+	dx, dy := way9type.CalcDxDyWrapped(x2-x1, y2-y1, w, h)
+	absx := abs.Absi(dx)
+	absy := abs.Absi(dy)
+	dist := absx
+	if absy > dist {
+		dist = absy
+	}
+	isWay9 := absx == 0 || absy == 0 || absx == absy
+	if dist == 0 || dist > gameconst.ProjectileRange || !isWay9 {
+		return way9type.Center, false
+	}
+	way := way9type.RemoteDxDy2Way9(dx, dy)
+	if ta[x1][y1].NoBattle() {
+		return way, false
+	}
+	xWrap, yWrap := ta.GetXYWrapper()
+	for i := 1; i <= dist; i++ {
+		tl := ta[xWrap(x1+way.Dx()*i)][yWrap(y1+way.Dy()*i)]
+		if !tl.CharPlaceable() || tl.NoBattle() {
+			return way, false
+		}
+	}
+	return way, true
+}
@@ -0,0 +1,90 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carryingobject
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+	"github.com/kasworld/uuidstr"
+)
+
+// Ammo bundle of ammo for Shoot, go to inventory ammo count on pickup
+type Ammo struct {
+	uuid              string
+	remainTurnInFloor int
+
+	count int
+}
+
+func (po Ammo) String() string {
+	return fmt.Sprintf("Ammo[%v %v]", po.uuid, po.count)
+}
+
+func NewAmmo(count int) gamei.AmmoI {
+	if count < 0 {
+		count = 0
+	}
+	return &Ammo{
+		uuid:  uuidstr.New(),
+		count: count,
+	}
+}
+
+func (po *Ammo) GetCount() int {
+	return po.count
+}
+
+func (po *Ammo) ToPacket_CarryObjClientOnFloor(x, y int) *c2t_obj.CarryObjClientOnFloor {
+	poc := &c2t_obj.CarryObjClientOnFloor{
+		UUID:               po.uuid,
+		CarryingObjectType: po.GetCarryingObjectType(),
+		X:                  x,
+		Y:                  y,
+
+		Value: po.count,
+	}
+	return poc
+}
+
+// IDPosI interface
+func (po *Ammo) GetUUID() string {
+	return po.uuid
+}
+
+func (po *Ammo) GetCarryingObjectType() carryingobjecttype.CarryingObjectType {
+	return carryingobjecttype.Ammo
+}
+func (po *Ammo) GetWeight() float64 {
+	return float64(po.count) * gameconst.AmmoGram
+}
+func (po *Ammo) GetValue() float64 {
+	return float64(po.count) * gameconst.AmmoValue
+}
+
+// life in floor handle
+
+func (po *Ammo) GetRemainTurnInFloor() int {
+	return po.remainTurnInFloor
+}
+func (po *Ammo) DecRemainTurnInFloor() int {
+	if po.remainTurnInFloor > 0 {
+		po.remainTurnInFloor--
+	}
+	return po.remainTurnInFloor
+}
+func (po *Ammo) SetRemainTurnInFloor() {
+	po.remainTurnInFloor = gameconst.CarryingObjectLifeTurnInFloor
+}
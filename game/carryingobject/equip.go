@@ -26,8 +26,8 @@ import (
 )
 
 func (po EquipObj) String() string {
-	return fmt.Sprintf("EquipObj[%v %v %v %v]",
-		po.uuid, po.equipType, po.Faction, po.BiasLen)
+	return fmt.Sprintf("EquipObj[%v %v %v %v %v]",
+		po.uuid, po.equipType, po.Faction, po.BiasLen, po.durability)
 }
 
 type EquipObj struct {
@@ -36,14 +36,18 @@ type EquipObj struct {
 
 	equipType equipslottype.EquipSlotType
 	name      string
+	material  string // decide light radius, empty if saved before material
 
 	Faction factiontype.FactionType
 	BiasLen float64
+
+	durability int // 0 : broken
 }
 
 func NewRandFactionEquipObj(aoname string, ft factiontype.FactionType, rnd *g2rand.G2Rand) gamei.EquipObjI {
 	po := EquipObj{
-		uuid: uuidstr.New(),
+		uuid:       uuidstr.New(),
+		durability: gameconst.EquipDurabilityMax,
 	}
 	po.Faction = ft
 	po.equipType = equipslottype.EquipSlotType(rnd.Intn(equipslottype.EquipSlotType_Count))
@@ -55,6 +59,7 @@ func NewRandFactionEquipObj(aoname string, ft factiontype.FactionType, rnd *g2ra
 
 	namelist := po.equipType.Names()
 	name := namelist[rnd.Intn(len(namelist))]
+	po.material = material
 	po.name = fmt.Sprintf("%s's %s %s", aoname, material, name)
 
 	biaslen := po.equipType.BaseLen()
@@ -72,7 +77,8 @@ func NewEquipByFactionSlot(aoname string,
 	rnd *g2rand.G2Rand) gamei.EquipObjI {
 
 	po := EquipObj{
-		uuid: uuidstr.New(),
+		uuid:       uuidstr.New(),
+		durability: gameconst.EquipDurabilityMax,
 	}
 	po.Faction = ft
 	po.equipType = eqslot
@@ -84,6 +90,7 @@ func NewEquipByFactionSlot(aoname string,
 
 	namelist := po.equipType.Names()
 	name := namelist[rnd.Intn(len(namelist))]
+	po.material = material
 	po.name = fmt.Sprintf("%s's %s %s", aoname, material, name)
 
 	biaslen := po.equipType.BaseLen()
@@ -95,8 +102,70 @@ func NewEquipByFactionSlot(aoname string,
 	return &po
 }
 
+// NewEquipObj make equip with known attribute, used to restore saved equip
+// durability <= 0 (data saved before durability) is made max
+func NewEquipObj(name string, material string,
+	ft factiontype.FactionType,
+	eqslot equipslottype.EquipSlotType,
+	biasLen float64,
+	durability int) gamei.EquipObjI {
+
+	if durability <= 0 || durability > gameconst.EquipDurabilityMax {
+		durability = gameconst.EquipDurabilityMax
+	}
+	po := EquipObj{
+		uuid:       uuidstr.New(),
+		equipType:  eqslot,
+		name:       name,
+		material:   material,
+		Faction:    ft,
+		BiasLen:    biasLen,
+		durability: durability,
+	}
+	return &po
+}
+
+// GetBias bias reduced by worn durability
 func (po *EquipObj) GetBias() bias.Bias {
-	return bias.NewByFaction(po.Faction, po.BiasLen)
+	return bias.NewByFaction(po.Faction, po.BiasLen*po.durabilityRate())
+}
+
+func (po *EquipObj) GetMaterial() string {
+	return po.material
+}
+
+// GetLightRadius by material
+func (po *EquipObj) GetLightRadius() float64 {
+	return equipslottype.MaterialLightRadius(po.material)
+}
+
+func (po *EquipObj) durabilityRate() float64 {
+	return gameconst.EquipWornBiasRate +
+		(1-gameconst.EquipWornBiasRate)*float64(po.durability)/gameconst.EquipDurabilityMax
+}
+
+func (po *EquipObj) GetDurability() int {
+	return po.durability
+}
+
+// DecDurability return remain durability, 0 : broken
+func (po *EquipObj) DecDurability(n int) int {
+	po.durability -= n
+	if po.durability < 0 {
+		po.durability = 0
+	}
+	return po.durability
+}
+
+// GetRepairCost money to make durability max
+func (po *EquipObj) GetRepairCost() float64 {
+	worn := gameconst.EquipDurabilityMax - po.durability
+	return po.GetValue() * gameconst.EquipRepairCostRate *
+		float64(worn) / gameconst.EquipDurabilityMax
+}
+
+func (po *EquipObj) Repair() {
+	po.durability = gameconst.EquipDurabilityMax
 }
 
 func (po *EquipObj) ToPacket_CarryObjClientOnFloor(x, y int) *c2t_obj.CarryObjClientOnFloor {
@@ -118,8 +187,9 @@ func (po *EquipObj) ToPacket_EquipClient() *c2t_obj.EquipClient {
 		Name:      po.name,
 		EquipType: po.equipType,
 
-		Faction: po.Faction,
-		BiasLen: po.BiasLen,
+		Faction:    po.Faction,
+		BiasLen:    po.BiasLen,
+		Durability: po.durability,
 	}
 	return poc
 }
@@ -0,0 +1,82 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carryingobject
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+	"github.com/kasworld/uuidstr"
+)
+
+// Key unlock door of KeyID, go to inventory keyring on pickup
+type Key struct {
+	uuid              string
+	remainTurnInFloor int
+
+	keyID string
+}
+
+func (po Key) String() string {
+	return fmt.Sprintf("Key[%v %v]", po.uuid, po.keyID)
+}
+
+func NewKey(keyID string) gamei.KeyI {
+	return &Key{
+		uuid:  uuidstr.New(),
+		keyID: keyID,
+	}
+}
+
+func (po *Key) GetKeyID() string {
+	return po.keyID
+}
+
+func (po *Key) ToPacket_CarryObjClientOnFloor(x, y int) *c2t_obj.CarryObjClientOnFloor {
+	poc := &c2t_obj.CarryObjClientOnFloor{
+		UUID:               po.uuid,
+		CarryingObjectType: po.GetCarryingObjectType(),
+		X:                  x,
+		Y:                  y,
+	}
+	return poc
+}
+
+// IDPosI interface
+func (po *Key) GetUUID() string {
+	return po.uuid
+}
+
+func (po *Key) GetCarryingObjectType() carryingobjecttype.CarryingObjectType {
+	return carryingobjecttype.Key
+}
+func (po *Key) GetWeight() float64 {
+	return gameconst.KeyGram
+}
+func (po *Key) GetValue() float64 {
+	return 0
+}
+
+// life in floor handle, key not removed from floor
+
+func (po *Key) GetRemainTurnInFloor() int {
+	return po.remainTurnInFloor
+}
+func (po *Key) DecRemainTurnInFloor() int {
+	return po.remainTurnInFloor
+}
+func (po *Key) SetRemainTurnInFloor() {
+	po.remainTurnInFloor = gameconst.CarryingObjectLifeTurnInFloor
+}
@@ -0,0 +1,104 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package carryingobject
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/towersnapshot"
+)
+
+// ToSnapshot make restorable state of carryobj, x,y not set
+func ToSnapshot(o interface{}) (towersnapshot.CarryObjSnapshot, error) {
+	cs := towersnapshot.CarryObjSnapshot{}
+	switch po := o.(type) {
+	default:
+		return cs, fmt.Errorf("unknown carryobj %v", o)
+	case *EquipObj:
+		cs.CarryingObjectType = carryingobjecttype.Equip
+		cs.UUID = po.uuid
+		cs.Name = po.name
+		cs.Material = po.material
+		cs.EquipType = po.equipType
+		cs.Faction = po.Faction
+		cs.BiasLen = po.BiasLen
+		cs.Durability = po.durability
+	case *Potion:
+		cs.CarryingObjectType = carryingobjecttype.Potion
+		cs.UUID = po.uuid
+		cs.PotionType = po.potionType
+	case *Scroll:
+		cs.CarryingObjectType = carryingobjecttype.Scroll
+		cs.UUID = po.uuid
+		cs.ScrollType = po.scrollType
+	case *Money:
+		cs.CarryingObjectType = carryingobjecttype.Money
+		cs.UUID = po.uuid
+		cs.Value = po.GetValue()
+	case *Ammo:
+		cs.CarryingObjectType = carryingobjecttype.Ammo
+		cs.UUID = po.uuid
+		cs.Value = float64(po.GetCount())
+	case *Key:
+		cs.CarryingObjectType = carryingobjecttype.Key
+		cs.UUID = po.uuid
+		cs.KeyID = po.keyID
+	}
+	return cs, nil
+}
+
+// NewFromSnapshot make carryobj, keep saved uuid if exist
+func NewFromSnapshot(cs towersnapshot.CarryObjSnapshot) (gamei.CarryingObjectI, error) {
+	switch cs.CarryingObjectType {
+	default:
+		return nil, fmt.Errorf("unknown carryobj type %v", cs.CarryingObjectType)
+	case carryingobjecttype.Equip:
+		po := NewEquipObj(cs.Name, cs.Material, cs.Faction, cs.EquipType, cs.BiasLen,
+			cs.Durability).(*EquipObj)
+		if cs.UUID != "" {
+			po.uuid = cs.UUID
+		}
+		return po, nil
+	case carryingobjecttype.Potion:
+		po := NewPotion(cs.PotionType).(*Potion)
+		if cs.UUID != "" {
+			po.uuid = cs.UUID
+		}
+		return po, nil
+	case carryingobjecttype.Scroll:
+		po := NewScroll(cs.ScrollType).(*Scroll)
+		if cs.UUID != "" {
+			po.uuid = cs.UUID
+		}
+		return po, nil
+	case carryingobjecttype.Money:
+		po := NewMoney(cs.Value).(*Money)
+		if cs.UUID != "" {
+			po.uuid = cs.UUID
+		}
+		return po, nil
+	case carryingobjecttype.Ammo:
+		po := NewAmmo(int(cs.Value)).(*Ammo)
+		if cs.UUID != "" {
+			po.uuid = cs.UUID
+		}
+		return po, nil
+	case carryingobjecttype.Key:
+		po := NewKey(cs.KeyID).(*Key)
+		if cs.UUID != "" {
+			po.uuid = cs.UUID
+		}
+		return po, nil
+	}
+}
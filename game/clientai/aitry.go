@@ -237,6 +237,11 @@ func (cai *ClientAI) needUnEquipCarryObj(PoBias bias.Bias) bool {
 }
 
 func (cai *ClientAI) needUseScroll(po *c2t_obj.ScrollClient) bool {
+	if !po.Identified() {
+		// read to identify when safe
+		pao := cai.OLNotiData.ActiveObj
+		return pao.HPMax/2 < pao.HP
+	}
 	cf := cai.currentFloor()
 	switch po.ScrollType {
 	case scrolltype.FloorMap:
@@ -249,6 +254,10 @@ func (cai *ClientAI) needUseScroll(po *c2t_obj.ScrollClient) bool {
 
 func (cai *ClientAI) needUsePotion(po *c2t_obj.PotionClient) bool {
 	pao := cai.OLNotiData.ActiveObj
+	if !po.Identified() {
+		// drink to identify when safe
+		return pao.HPMax/2 < pao.HP
+	}
 	switch po.PotionType {
 	case potiontype.RecoverHP10:
 		return pao.HPMax-pao.HP > 10
@@ -310,7 +319,7 @@ func (cai *ClientAI) recycleEqbag() bool {
 
 func (cai *ClientAI) recycleUselessPotion() bool {
 	for _, po := range cai.OLNotiData.ActiveObj.PotionBag {
-		if potiontype.AIRecycleMap[po.PotionType] {
+		if po.Identified() && potiontype.AIRecycleMap[po.PotionType] {
 			cai.ReqWithRspFnWithAuth(c2t_idcmd.Recycle,
 				&c2t_obj.ReqRecycle_data{UUID: po.UUID},
 				func(hd c2t_packet.Header, rsp interface{}) error {
@@ -324,7 +333,7 @@ func (cai *ClientAI) recycleUselessPotion() bool {
 
 func (cai *ClientAI) recycleUselessScroll() bool {
 	for _, po := range cai.OLNotiData.ActiveObj.ScrollBag {
-		if scrolltype.AIRecycleMap[po.ScrollType] {
+		if po.Identified() && scrolltype.AIRecycleMap[po.ScrollType] {
 			cai.ReqWithRspFnWithAuth(c2t_idcmd.Recycle,
 				&c2t_obj.ReqRecycle_data{UUID: po.UUID},
 				func(hd c2t_packet.Header, rsp interface{}) error {
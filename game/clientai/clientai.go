@@ -22,7 +22,9 @@ import (
 	"github.com/kasworld/goguelike/config/gameconst"
 	"github.com/kasworld/goguelike/config/viewportdata"
 	"github.com/kasworld/goguelike/game/clientfloor"
+	"github.com/kasworld/goguelike/game/objlistdelta"
 	"github.com/kasworld/goguelike/lib/g2log"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_conntcp"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_connwsgorilla"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_gob"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
@@ -31,6 +33,14 @@ import (
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_pid2rspfn"
 )
 
+// towerConnI c2t_connwsgorilla or c2t_conntcp
+type towerConnI interface {
+	ConnectTo(connAddr string) error
+	Run(mainctx context.Context) error
+	Cleanup()
+	EnqueueSendPacket(pk c2t_packet.Packet) error
+}
+
 type ClientAI struct {
 	log          *g2log.LogBase `prettystring:"hide"`
 	sendRecvStop func()         `prettystring:"hide"`
@@ -38,7 +48,7 @@ type ClientAI struct {
 	config    ClientAIConfig
 	runResult error
 
-	towerConn         *c2t_connwsgorilla.Connection
+	towerConn         towerConnI
 	ServiceInfo       *c2t_obj.ServiceInfo
 	AccountInfo       *c2t_obj.AccountInfo
 	TowerInfo         *c2t_obj.TowerInfo
@@ -52,6 +62,7 @@ type ClientAI struct {
 	// turn data
 	movePacketPerTurn     int32
 	OLNotiData            *c2t_obj.NotiObjectList_data
+	olReceiver            *objlistdelta.Receiver
 	playerActiveObjClient *c2t_obj.ActiveObjClient
 	onFieldObj            *c2t_obj.FieldObjClient
 	IsOverLoad            bool
@@ -71,17 +82,32 @@ func New(config ClientAIConfig, l *g2log.LogBase) *ClientAI {
 		Name2ClientFloor:  make(map[string]*clientfloor.ClientFloor),
 		pid2recv:          c2t_pid2rspfn.New(),
 		ViewportXYLenList: viewportdata.ViewportXYLenList,
+		olReceiver:        objlistdelta.NewReceiver(),
 	}
 	cai.sendRecvStop = func() {
 		cai.log.Error("Too early sendRecvStop call %v", cai)
 	}
-	cai.towerConn = c2t_connwsgorilla.New(
-		gameconst.ClientReadTimeoutSec*time.Second,
-		gameconst.ClientWriteTimeoutSec*time.Second,
-		c2t_gob.MarshalBodyFn,
-		cai.handleRecvPacket,
-		cai.handleSentPacket,
-	)
+	switch config.Net {
+	default:
+		cai.log.Error("unknown net %v, use web", config.Net)
+		fallthrough
+	case "web", "":
+		cai.towerConn = c2t_connwsgorilla.New(
+			gameconst.ClientReadTimeoutSec*time.Second,
+			gameconst.ClientWriteTimeoutSec*time.Second,
+			c2t_gob.MarshalBodyFn,
+			cai.handleRecvPacket,
+			cai.handleSentPacket,
+		)
+	case "tcp":
+		cai.towerConn = c2t_conntcp.New(
+			gameconst.ClientReadTimeoutSec*time.Second,
+			gameconst.ClientWriteTimeoutSec*time.Second,
+			c2t_gob.MarshalBodyFn,
+			cai.handleRecvPacket,
+			cai.handleSentPacket,
+		)
+	}
 	return cai
 }
 
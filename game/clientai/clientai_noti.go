@@ -26,22 +26,33 @@ import (
 )
 
 var DemuxNoti2ByteFnMap = [...]func(me interface{}, hd c2t_packet.Header, rbody []byte) error{
-	c2t_idnoti.Invalid:        bytesRecvNotiFn_Invalid,
-	c2t_idnoti.EnterTower:     bytesRecvNotiFn_EnterTower,
-	c2t_idnoti.LeaveTower:     bytesRecvNotiFn_LeaveTower,
-	c2t_idnoti.EnterFloor:     bytesRecvNotiFn_EnterFloor,
-	c2t_idnoti.LeaveFloor:     bytesRecvNotiFn_LeaveFloor,
-	c2t_idnoti.Ageing:         bytesRecvNotiFn_Ageing,
-	c2t_idnoti.Death:          bytesRecvNotiFn_Death,
-	c2t_idnoti.ReadyToRebirth: bytesRecvNotiFn_ReadyToRebirth,
-	c2t_idnoti.Rebirthed:      bytesRecvNotiFn_Rebirthed,
-	c2t_idnoti.Broadcast:      bytesRecvNotiFn_Broadcast,
-	c2t_idnoti.ObjectList:     bytesRecvNotiFn_ObjectList,
-	c2t_idnoti.VPTiles:        bytesRecvNotiFn_VPTiles,
-	c2t_idnoti.FloorTiles:     bytesRecvNotiFn_FloorTiles,
-	c2t_idnoti.FoundFieldObj:  bytesRecvNotiFn_FoundFieldObj,
-	c2t_idnoti.ForgetFloor:    bytesRecvNotiFn_ForgetFloor,
-	c2t_idnoti.ActivateTrap:   bytesRecvNotiFn_ActivateTrap,
+	c2t_idnoti.Invalid:         bytesRecvNotiFn_Invalid,
+	c2t_idnoti.EnterTower:      bytesRecvNotiFn_EnterTower,
+	c2t_idnoti.LeaveTower:      bytesRecvNotiFn_LeaveTower,
+	c2t_idnoti.EnterFloor:      bytesRecvNotiFn_EnterFloor,
+	c2t_idnoti.LeaveFloor:      bytesRecvNotiFn_LeaveFloor,
+	c2t_idnoti.Ageing:          bytesRecvNotiFn_Ageing,
+	c2t_idnoti.Death:           bytesRecvNotiFn_Death,
+	c2t_idnoti.ReadyToRebirth:  bytesRecvNotiFn_ReadyToRebirth,
+	c2t_idnoti.Rebirthed:       bytesRecvNotiFn_Rebirthed,
+	c2t_idnoti.Broadcast:       bytesRecvNotiFn_Broadcast,
+	c2t_idnoti.ChatWhisper:     bytesRecvNotiFn_ChatWhisper,
+	c2t_idnoti.ChatFloor:       bytesRecvNotiFn_ChatFloor,
+	c2t_idnoti.ChatTower:       bytesRecvNotiFn_ChatTower,
+	c2t_idnoti.ChatFaction:     bytesRecvNotiFn_ChatFaction,
+	c2t_idnoti.TradeState:      bytesRecvNotiFn_TradeState,
+	c2t_idnoti.Craft:           bytesRecvNotiFn_Craft,
+	c2t_idnoti.Quest:           bytesRecvNotiFn_Quest,
+	c2t_idnoti.BossKilled:      bytesRecvNotiFn_BossKilled,
+	c2t_idnoti.Diplomacy:       bytesRecvNotiFn_Diplomacy,
+	c2t_idnoti.HeardNoise:      bytesRecvNotiFn_HeardNoise,
+	c2t_idnoti.ObjectList:      bytesRecvNotiFn_ObjectList,
+	c2t_idnoti.ObjectListDelta: bytesRecvNotiFn_ObjectListDelta,
+	c2t_idnoti.VPTiles:         bytesRecvNotiFn_VPTiles,
+	c2t_idnoti.FloorTiles:      bytesRecvNotiFn_FloorTiles,
+	c2t_idnoti.FoundFieldObj:   bytesRecvNotiFn_FoundFieldObj,
+	c2t_idnoti.ForgetFloor:     bytesRecvNotiFn_ForgetFloor,
+	c2t_idnoti.ActivateTrap:    bytesRecvNotiFn_ActivateTrap,
 }
 
 func bytesRecvNotiFn_Invalid(me interface{}, hd c2t_packet.Header, rbody []byte) error {
@@ -162,6 +173,98 @@ func bytesRecvNotiFn_Broadcast(me interface{}, hd c2t_packet.Header, rbody []byt
 	return nil
 }
 
+func bytesRecvNotiFn_ChatWhisper(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	robj, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	pkbody, ok := robj.(*c2t_obj.NotiChatWhisper_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", robj)
+	}
+	cai, ok := me.(*ClientAI)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", me)
+	}
+	cai.log.Debug("%v whisper from %v: %v", cai, pkbody.SenderName, pkbody.Chat)
+	return nil
+}
+
+func bytesRecvNotiFn_ChatFloor(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	robj, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	pkbody, ok := robj.(*c2t_obj.NotiChatFloor_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", robj)
+	}
+	cai, ok := me.(*ClientAI)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", me)
+	}
+	cai.log.Debug("%v floor %v %v: %v", cai, pkbody.FloorName, pkbody.SenderName, pkbody.Chat)
+	return nil
+}
+
+func bytesRecvNotiFn_ChatTower(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	robj, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	pkbody, ok := robj.(*c2t_obj.NotiChatTower_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", robj)
+	}
+	cai, ok := me.(*ClientAI)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", me)
+	}
+	cai.log.Debug("%v tower %v: %v", cai, pkbody.SenderName, pkbody.Chat)
+	return nil
+}
+
+func bytesRecvNotiFn_ChatFaction(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	robj, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	pkbody, ok := robj.(*c2t_obj.NotiChatFaction_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", robj)
+	}
+	cai, ok := me.(*ClientAI)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", me)
+	}
+	cai.log.Debug("%v faction %v %v: %v", cai, pkbody.Faction, pkbody.SenderName, pkbody.Chat)
+	return nil
+}
+
+func bytesRecvNotiFn_TradeState(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	return nil
+}
+
+func bytesRecvNotiFn_Craft(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	return nil
+}
+
+func bytesRecvNotiFn_Quest(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	return nil
+}
+
+func bytesRecvNotiFn_BossKilled(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	return nil
+}
+
+func bytesRecvNotiFn_Diplomacy(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	return nil
+}
+
+func bytesRecvNotiFn_HeardNoise(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	return nil
+}
+
 func bytesRecvNotiFn_ObjectList(me interface{}, hd c2t_packet.Header, rbody []byte) error {
 	robj, err := c2t_gob.UnmarshalPacket(hd, rbody)
 	if err != nil {
@@ -175,6 +278,38 @@ func bytesRecvNotiFn_ObjectList(me interface{}, hd c2t_packet.Header, rbody []by
 	if !ok {
 		return fmt.Errorf("recvobj type mismatch %v", me)
 	}
+	cai.olReceiver.AddKeyframe(pkbody)
+	return cai.handleObjectList(pkbody)
+}
+
+func bytesRecvNotiFn_ObjectListDelta(me interface{}, hd c2t_packet.Header, rbody []byte) error {
+	robj, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	pkbody, ok := robj.(*c2t_obj.NotiObjectListDelta_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", robj)
+	}
+	cai, ok := me.(*ClientAI)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", me)
+	}
+	ol, err := cai.olReceiver.Apply(pkbody)
+	if err != nil {
+		// wait next keyframe
+		cai.log.Warn("%v", err)
+		return nil
+	}
+	return cai.handleObjectList(ol)
+}
+
+func (cai *ClientAI) handleObjectList(pkbody *c2t_obj.NotiObjectList_data) error {
+	if pkbody.Seq != 0 {
+		if err := cai.reqAckObjectList(pkbody.Seq); err != nil {
+			return err
+		}
+	}
 	cai.OLNotiData = pkbody
 	cai.ServerClientTimeDiff = pkbody.Time.Sub(time.Now())
 	oldOLNotiData := cai.OLNotiData
@@ -93,6 +93,18 @@ func (cai *ClientAI) reqAchieveInfo() error {
 	)
 }
 
+func (cai *ClientAI) reqAckObjectList(seq int) error {
+	return cai.ReqWithRspFnWithAuth(
+		c2t_idcmd.AckObjectList,
+		&c2t_obj.ReqAckObjectList_data{
+			Seq: seq,
+		},
+		func(hd c2t_packet.Header, rsp interface{}) error {
+			return nil
+		},
+	)
+}
+
 func (cai *ClientAI) reqHeartbeat() error {
 	return cai.ReqWithRspFnWithAuth(
 		c2t_idcmd.Heartbeat,
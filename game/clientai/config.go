@@ -12,6 +12,7 @@
 package clientai
 
 type ClientAIConfig struct {
+	Net               string // web or tcp
 	ConnectToTower    string
 	Nickname          string
 	SessionUUID       string
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/kasworld/findnear"
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
 	"github.com/kasworld/goguelike/enum/way9type"
 	"github.com/kasworld/goguelike/game/bias"
 	"github.com/kasworld/goguelike/game/tilearea"
@@ -175,6 +176,21 @@ func (cf *ClientFloor) EnterFloor() {
 	cf.visitTime = time.Now()
 }
 
+// IsDoorAt return isDoor, isClosed, closed door tile not CharPlaceable
+func (cf *ClientFloor) IsDoorAt(x, y int) (bool, bool) {
+	fo := cf.GetFieldObjAt(x, y)
+	if fo == nil || fo.ActType != fieldobjacttype.Door {
+		return false, false
+	}
+	return true, !cf.Tiles[x][y].CharPlaceable()
+}
+
+// IsBoulderAt boulder tile not CharPlaceable, pushed by move
+func (cf *ClientFloor) IsBoulderAt(x, y int) bool {
+	fo := cf.GetFieldObjAt(x, y)
+	return fo != nil && fo.ActType == fieldobjacttype.Boulder
+}
+
 func (cf *ClientFloor) GetFieldObjAt(x, y int) *c2t_obj.FieldObjClient {
 	po, ok := cf.FieldObjPosMan.Get1stObjAt(x, y).(*c2t_obj.FieldObjClient)
 	if !ok {
@@ -26,6 +26,7 @@ type ReqEnterFloor struct {
 	ActiveObj gamei.ActiveObjectI
 	X         int
 	Y         int
+	Near      bool // enter empty pos near X,Y
 }
 type ReqLeaveFloor struct {
 	ActiveObj gamei.ActiveObjectI
@@ -42,3 +43,32 @@ type APIAdminCmd2Floor struct {
 	ReqPk     *c2t_obj.ReqAdminFloorCmd_data
 	RspCh     chan<- c2t_error.ErrorCode
 }
+
+// ListShopResult Rsp nil if ErrorCode not None
+type ListShopResult struct {
+	Rsp       *c2t_obj.RspListShop_data
+	ErrorCode c2t_error.ErrorCode
+}
+
+type APIListShop struct {
+	ActiveObj gamei.ActiveObjectI
+	RspCh     chan<- ListShopResult
+}
+
+// ListQuestResult Rsp nil if ErrorCode not None
+type ListQuestResult struct {
+	Rsp       *c2t_obj.RspListQuest_data
+	ErrorCode c2t_error.ErrorCode
+}
+
+type APIListQuest struct {
+	ActiveObj gamei.ActiveObjectI
+	RspCh     chan<- ListQuestResult
+}
+
+// APITrade ReqPk is one of ReqTrade*_data
+type APITrade struct {
+	ActiveObj gamei.ActiveObjectI
+	ReqPk     interface{}
+	RspCh     chan<- c2t_error.ErrorCode
+}
@@ -135,3 +135,35 @@ func (pk ActiveObjRebirth) String() string {
 		pk.ActiveObj,
 	)
 }
+
+type BossKilled struct {
+	Boss       gamei.ActiveObjectI
+	Floor      gamei.FloorI
+	KillerName string
+}
+
+func (pk BossKilled) String() string {
+	return fmt.Sprintf(
+		"BossKilled[%v %v %v]",
+		pk.Boss,
+		pk.Floor,
+		pk.KillerName,
+	)
+}
+
+// SummonPet make pet of Owner near X,Y of Floor
+type SummonPet struct {
+	Owner gamei.ActiveObjectI
+	Floor gamei.FloorI
+	X, Y  int
+}
+
+func (pk SummonPet) String() string {
+	return fmt.Sprintf(
+		"SummonPet[%v %v %v %v]",
+		pk.Owner,
+		pk.Floor,
+		pk.X,
+		pk.Y,
+	)
+}
@@ -12,7 +12,10 @@
 package dangerobject
 
 import (
+	"github.com/kasworld/goguelike/config/gamedata"
 	"github.com/kasworld/goguelike/enum/dangertype"
+	"github.com/kasworld/goguelike/enum/skilltype"
+	"github.com/kasworld/goguelike/enum/way9type"
 	"github.com/kasworld/goguelike/lib/uuidposman"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
 	"github.com/kasworld/uuidstr"
@@ -25,6 +28,14 @@ type DangerObject struct {
 	DangerType     dangertype.DangerType
 	RemainTurn     int // remain turn to affect
 	AffectRate     float64
+	Skill          skilltype.SkillType // made by skill, apply TargetBuff to affected ao
+	Seq            int64               // add order in floor, process order in turn
+
+	// projectile only, move Speed tile to Dir each turn
+	Dir         way9type.Way9Type
+	Speed       int
+	RemainRange int  // remain tile to move
+	Hit         bool // hit ao, del next turn
 }
 
 // IDPosI interface
@@ -55,6 +66,39 @@ func NewFOAttact(attacker uuidposman.UUIDPosI, dt dangertype.DangerType, affectR
 	}
 }
 
+// NewAOSkill make dangerobj of skill area
+func NewAOSkill(
+	attacker uuidposman.UUIDPosI, st skilltype.SkillType, srcx, srcy int) *DangerObject {
+	return &DangerObject{
+		UUID:       uuidstr.New(),
+		Owner:      attacker,
+		OwnerX:     srcx,
+		OwnerY:     srcy,
+		DangerType: gamedata.SkillList[st].DangerType,
+		RemainTurn: gamedata.SkillList[st].DangerType.Turn2Live(),
+		AffectRate: 1,
+		Skill:      st,
+	}
+}
+
+// NewAOProjectile make projectile start at srcx,srcy
+func NewAOProjectile(
+	attacker uuidposman.UUIDPosI, srcx, srcy int,
+	dir way9type.Way9Type, speed, rangeLen int) *DangerObject {
+	return &DangerObject{
+		UUID:        uuidstr.New(),
+		Owner:       attacker,
+		OwnerX:      srcx,
+		OwnerY:      srcy,
+		DangerType:  dangertype.Projectile,
+		RemainTurn:  dangertype.Projectile.Turn2Live(),
+		AffectRate:  1,
+		Dir:         dir,
+		Speed:       speed,
+		RemainRange: rangeLen,
+	}
+}
+
 func (p *DangerObject) ToPacket_DangerObjClient(x, y int) *c2t_obj.DangerObjClient {
 	return &c2t_obj.DangerObjClient{
 		UUID:       p.UUID,
@@ -67,7 +111,11 @@ func (p *DangerObject) ToPacket_DangerObjClient(x, y int) *c2t_obj.DangerObjClie
 }
 
 // Live1Turn reduce remain turn and return alive
+// projectile alive until hit or move all range
 func (p *DangerObject) Live1Turn() bool {
+	if p.DangerType == dangertype.Projectile {
+		return !p.Hit && p.RemainRange > 0
+	}
 	p.RemainTurn--
 	return p.RemainTurn > 0
 }
@@ -0,0 +1,204 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diplomacy relation between factiontype in tower, set by tower script, admin cmd
+// relation by current faction of ao, so faction scroll change relation of ao
+// DiplomacyDefault relation=Relation : set all faction pair
+// Diplomacy faction1=FactionType faction2=FactionType relation=Relation : set pair both way
+// DiplomacyAttackRate relation=Relation rate=float : damage rate, 0 is attack not allowed
+package diplomacy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/relation"
+	"github.com/kasworld/goguelike/lib/scriptparse"
+)
+
+// CmdPrefix tower script line start with CmdPrefix is diplomacy cmd
+const CmdPrefix = "Diplomacy"
+
+func (dp *Diplomacy) String() string {
+	return fmt.Sprintf("Diplomacy[Ver%v]", dp.GetVersion())
+}
+
+// Diplomacy safe to use in multi goroutine
+// default all Hostile, attack rate 1
+type Diplomacy struct {
+	mutex      sync.RWMutex `prettystring:"hide"`
+	relation   [factiontype.FactionType_Count][factiontype.FactionType_Count]relation.Relation
+	attackRate [relation.Relation_Count]float64
+	version    int // inc on every change
+}
+
+func New() *Diplomacy {
+	dp := &Diplomacy{}
+	for i := range dp.attackRate {
+		dp.attackRate[i] = 1
+	}
+	return dp
+}
+
+func (dp *Diplomacy) GetVersion() int {
+	dp.mutex.RLock()
+	defer dp.mutex.RUnlock()
+	return dp.version
+}
+
+func (dp *Diplomacy) Get(ft1, ft2 factiontype.FactionType) relation.Relation {
+	dp.mutex.RLock()
+	defer dp.mutex.RUnlock()
+	return dp.relation[ft1][ft2]
+}
+
+// IsHostile server ai attack target
+func (dp *Diplomacy) IsHostile(ft1, ft2 factiontype.FactionType) bool {
+	return dp.Get(ft1, ft2) == relation.Hostile
+}
+
+// AttackRate damage rate of attack ft1 to ft2, 0 is not allowed
+func (dp *Diplomacy) AttackRate(ft1, ft2 factiontype.FactionType) float64 {
+	dp.mutex.RLock()
+	defer dp.mutex.RUnlock()
+	return dp.attackRate[dp.relation[ft1][ft2]]
+}
+
+func (dp *Diplomacy) GetAttackRate(rl relation.Relation) float64 {
+	dp.mutex.RLock()
+	defer dp.mutex.RUnlock()
+	return dp.attackRate[rl]
+}
+
+// ListByRelation factions in relation rl with ft, ft itself included
+func (dp *Diplomacy) ListByRelation(ft factiontype.FactionType, rl relation.Relation) []factiontype.FactionType {
+	dp.mutex.RLock()
+	defer dp.mutex.RUnlock()
+	var rtn []factiontype.FactionType
+	for i, v := range dp.relation[ft] {
+		if v == rl {
+			rtn = append(rtn, factiontype.FactionType(i))
+		}
+	}
+	return rtn
+}
+
+func (dp *Diplomacy) SetDefault(rl relation.Relation) {
+	dp.mutex.Lock()
+	defer dp.mutex.Unlock()
+	for i := range dp.relation {
+		for j := range dp.relation[i] {
+			dp.relation[i][j] = rl
+		}
+	}
+	dp.version++
+}
+
+// Set both way
+func (dp *Diplomacy) Set(ft1, ft2 factiontype.FactionType, rl relation.Relation) {
+	dp.mutex.Lock()
+	defer dp.mutex.Unlock()
+	dp.relation[ft1][ft2] = rl
+	dp.relation[ft2][ft1] = rl
+	dp.version++
+}
+
+func (dp *Diplomacy) SetAttackRate(rl relation.Relation, rate float64) error {
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("invalid attack rate %v %v", rl, rate)
+	}
+	dp.mutex.Lock()
+	defer dp.mutex.Unlock()
+	dp.attackRate[rl] = rate
+	dp.version++
+	return nil
+}
+
+// Snapshot relation, attack rate to keep runtime change across restart
+type Snapshot struct {
+	Relation   [factiontype.FactionType_Count][factiontype.FactionType_Count]relation.Relation
+	AttackRate [relation.Relation_Count]float64
+}
+
+func (dp *Diplomacy) ToSnapshot() Snapshot {
+	dp.mutex.RLock()
+	defer dp.mutex.RUnlock()
+	return Snapshot{
+		Relation:   dp.relation,
+		AttackRate: dp.attackRate,
+	}
+}
+
+func (dp *Diplomacy) SetSnapshot(ss Snapshot) {
+	dp.mutex.Lock()
+	defer dp.mutex.Unlock()
+	dp.relation = ss.Relation
+	dp.attackRate = ss.AttackRate
+	dp.version++
+}
+
+// ExecCmdlineList skip not diplomacy line
+func (dp *Diplomacy) ExecCmdlineList(lines []string) error {
+	for i, v := range lines {
+		if !IsCmdline(v) {
+			continue
+		}
+		if err := dp.ExecCmdline(v); err != nil {
+			return fmt.Errorf("line %v %v", i, err)
+		}
+	}
+	return nil
+}
+
+func IsCmdline(cmdline string) bool {
+	return strings.HasPrefix(strings.TrimSpace(cmdline), CmdPrefix)
+}
+
+func (dp *Diplomacy) ExecCmdline(cmdline string) error {
+	cmd, argLine := scriptparse.SplitCmdArgstr(cmdline, " ")
+	_, name2value, err := scriptparse.Split2ListMap(argLine, " ", "=")
+	if err != nil {
+		return err
+	}
+	rl, exist := relation.String2Relation(name2value["relation"])
+	if !exist {
+		return fmt.Errorf("invalid relation %v", cmdline)
+	}
+	switch cmd {
+	default:
+		return fmt.Errorf("unknown cmd %v", cmdline)
+
+	case "DiplomacyDefault":
+		dp.SetDefault(rl)
+
+	case "Diplomacy":
+		ft1, exist := factiontype.String2FactionType(name2value["faction1"])
+		if !exist {
+			return fmt.Errorf("invalid faction1 %v", cmdline)
+		}
+		ft2, exist := factiontype.String2FactionType(name2value["faction2"])
+		if !exist {
+			return fmt.Errorf("invalid faction2 %v", cmdline)
+		}
+		dp.Set(ft1, ft2, rl)
+
+	case "DiplomacyAttackRate":
+		rate, err := strconv.ParseFloat(name2value["rate"], 64)
+		if err != nil {
+			return fmt.Errorf("invalid rate %v", cmdline)
+		}
+		return dp.SetAttackRate(rl, rate)
+	}
+	return nil
+}
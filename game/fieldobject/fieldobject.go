@@ -14,6 +14,7 @@ package fieldobject
 import (
 	"fmt"
 
+	"github.com/kasworld/goguelike/config/craftdata"
 	"github.com/kasworld/goguelike/enum/decaytype"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype"
 	"github.com/kasworld/goguelike/enum/fieldobjdisplaytype"
@@ -43,11 +44,20 @@ type FieldObject struct {
 	// Mine, -1 on not triggered
 	// on trigger inc every turn, start 0 to Viewport size, end.
 	Radius float64
+
+	// shop
+	ShopStock       []craftdata.Material // kind and count on restock
+	ShopPriceRate   float64              // rate to carryobj value
+	ShopRestockTurn int
+
+	// door, closed door tile has wall, locked need key of door ID
+	DoorClosed bool
+	DoorLocked bool
 }
 
 func (p FieldObject) String() string {
 	return fmt.Sprintf(
-		"FieldObject[Floor:%v ID:%v %v %v %v %v %v Decay:%v Degree:%v PerTurn:%v WingLen:%v WingCount:%v R:%v]",
+		"FieldObject[Floor:%v ID:%v %v %v %v %v %v Decay:%v Degree:%v PerTurn:%v WingLen:%v WingCount:%v R:%v Closed:%v Locked:%v]",
 		p.FloorName,
 		p.ID,
 		p.ActType,
@@ -58,6 +68,7 @@ func (p FieldObject) String() string {
 		p.Degree, p.DegreePerTurn,
 		p.WingLen, p.WingCount,
 		p.Radius,
+		p.DoorClosed, p.DoorLocked,
 	)
 }
 
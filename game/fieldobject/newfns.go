@@ -15,6 +15,7 @@ import (
 	"fmt"
 
 	"github.com/kasworld/findnear"
+	"github.com/kasworld/goguelike/config/craftdata"
 	"github.com/kasworld/goguelike/config/gameconst"
 	"github.com/kasworld/goguelike/config/lineattackdata"
 	"github.com/kasworld/goguelike/enum/decaytype"
@@ -49,6 +50,82 @@ func NewRecycler(floorname string, displayType fieldobjdisplaytype.FieldObjDispl
 	}
 }
 
+func NewCrafter(floorname string, displayType fieldobjdisplaytype.FieldObjDisplayType, message string,
+) *FieldObject {
+	return &FieldObject{
+		ID:          uuidstr.New(),
+		FloorName:   floorname,
+		ActType:     fieldobjacttype.CraftCarryObj,
+		DisplayType: displayType,
+		Message:     message,
+	}
+}
+
+func NewRepairer(floorname string, displayType fieldobjdisplaytype.FieldObjDisplayType, message string,
+) *FieldObject {
+	return &FieldObject{
+		ID:          uuidstr.New(),
+		FloorName:   floorname,
+		ActType:     fieldobjacttype.RepairEquip,
+		DisplayType: displayType,
+		Message:     message,
+	}
+}
+
+func NewQuestGiver(floorname string, displayType fieldobjdisplaytype.FieldObjDisplayType, message string,
+) *FieldObject {
+	return &FieldObject{
+		ID:          uuidstr.New(),
+		FloorName:   floorname,
+		ActType:     fieldobjacttype.QuestGiver,
+		DisplayType: displayType,
+		Message:     message,
+	}
+}
+
+// NewDoor doorID is key id, keep same on remake floor
+func NewDoor(floorname string, displayType fieldobjdisplaytype.FieldObjDisplayType, message string,
+	doorID string,
+	closed, locked bool,
+) *FieldObject {
+	return &FieldObject{
+		ID:          doorID,
+		FloorName:   floorname,
+		ActType:     fieldobjacttype.Door,
+		DisplayType: displayType,
+		Message:     message,
+		DoorClosed:  closed || locked,
+		DoorLocked:  locked,
+	}
+}
+
+func NewBoulder(floorname string, displayType fieldobjdisplaytype.FieldObjDisplayType, message string,
+) *FieldObject {
+	return &FieldObject{
+		ID:          uuidstr.New(),
+		FloorName:   floorname,
+		ActType:     fieldobjacttype.Boulder,
+		DisplayType: displayType,
+		Message:     message,
+	}
+}
+
+func NewShop(floorname string, displayType fieldobjdisplaytype.FieldObjDisplayType,
+	stock []craftdata.Material, priceRate float64, restockTurn int,
+	message string,
+) *FieldObject {
+	return &FieldObject{
+		ID:              uuidstr.New(),
+		FloorName:       floorname,
+		ActType:         fieldobjacttype.Shop,
+		DisplayType:     displayType,
+		Message:         message,
+		ShopStock:       stock,
+		ShopPriceRate:   priceRate,
+		ShopRestockTurn: restockTurn,
+	}
+}
+
 func NewTrapTeleport(floorname string, message string,
 	dstFloorName string,
 ) *FieldObject {
@@ -21,9 +21,12 @@ import (
 	"github.com/kasworld/actpersec"
 	"github.com/kasworld/g2rand"
 	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/config/viewportdata"
 	"github.com/kasworld/goguelike/game/bias"
 	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/noise"
 	"github.com/kasworld/goguelike/game/terrain"
+	"github.com/kasworld/goguelike/game/turnrecorder"
 	"github.com/kasworld/goguelike/lib/g2log"
 	"github.com/kasworld/goguelike/lib/uuidposman"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
@@ -50,6 +53,7 @@ type Floor struct {
 	log *g2log.LogBase `prettystring:"hide"`
 
 	tower       gamei.TowerI
+	seed        int64 // for snapshot
 	w           int
 	h           int
 	bias        bias.Bias        `prettystring:"simple"`
@@ -63,6 +67,7 @@ type Floor struct {
 	poPosMan *uuidposman.UUIDPosMan `prettystring:"simple"`
 	foPosMan *uuidposman.UUIDPosMan `prettystring:"simple"`
 	doPosMan *uuidposman.UUIDPosMan `prettystring:"simple"`
+	doSeq    int64                  // last dangerobj Seq, see addDangerObj
 
 	interDur          *intervalduration.IntervalDuration `prettystring:"simple"`
 	statPacketObjOver *actpersec.ActPerSec               `prettystring:"simple"`
@@ -72,6 +77,26 @@ type Floor struct {
 	recvRequestCh chan interface{}
 
 	aiWG sync.WaitGroup // for ai run
+
+	// trade in progress, both side ao uuid to same trade
+	// used in floor goroutine only
+	aoUUID2Trade map[string]*aoTrade `prettystring:"simple"`
+
+	// stock of Shop fieldobj, used in floor goroutine only
+	foID2Shop map[string]*shopState `prettystring:"simple"`
+
+	// noise made in current turn, used in floor goroutine only
+	noiseList []noise.Noise `prettystring:"simple"`
+
+	// ao carried light and sight blocked by dark of current turn, used in floor goroutine only
+	aoLightList   []aoLight
+	litSightCache map[[2]int]*viewportdata.ViewportSight2
+	// light moved, appeared or gone since last turn
+	changedLightList []aoLight
+
+	// valid in ReplayTurn
+	replayRecord *turnrecorder.TurnRecord
+	replayResult []turnrecorder.ActResult
 }
 
 func New(seed int64, ts []string, tw gamei.TowerI) *Floor {
@@ -79,11 +104,14 @@ func New(seed int64, ts []string, tw gamei.TowerI) *Floor {
 	f := &Floor{
 		log:               tw.Log(),
 		tower:             tw,
+		seed:              seed,
 		rnd:               g2rand.NewWithSeed(seed),
 		interDur:          intervalduration.New(""),
 		statPacketObjOver: actpersec.New(),
 		floorCmdActStat:   actpersec.New(),
 		recvRequestCh:     make(chan interface{}, queuesize),
+		aoUUID2Trade:      make(map[string]*aoTrade),
+		foID2Shop:         make(map[string]*shopState),
 	}
 	f.terrain = terrain.New(f.rnd.Int63(), ts, f.tower.Config().DataFolder, f.log)
 	return f
@@ -120,6 +148,7 @@ func (f *Floor) Init() error {
 		f.rnd.Float64() - 0.5,
 		f.rnd.Float64() - 0.5,
 	}.MakeAbsSumTo(gameconst.FloorBaseBiasLen)
+	f.placeDoorKeys()
 
 	f.initialized = true
 	return nil
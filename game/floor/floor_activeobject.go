@@ -18,6 +18,7 @@ import (
 	"github.com/kasworld/goguelike/config/slippperydata"
 	"github.com/kasworld/goguelike/config/viewportdata"
 	"github.com/kasworld/goguelike/enum/achievetype"
+	"github.com/kasworld/goguelike/enum/aotype"
 	"github.com/kasworld/goguelike/enum/condition"
 	"github.com/kasworld/goguelike/enum/turnresulttype"
 	"github.com/kasworld/goguelike/enum/way9type"
@@ -224,9 +225,24 @@ func (f *Floor) ActiveObjDropCarryObjByDie(ao gamei.ActiveObjectI, aox, aoy int)
 		}
 	}
 
+	f.aoDropAllKey(ao, aox, aoy)
+	// user death not make new loot
+	if ao.GetActiveObjType() != aotype.User {
+		f.dropLootTableCarryObj(ao, aox, aoy)
+	}
 	return nil
 }
 
+// aoDropAllKey key is floor bound, dropped on die and leave floor
+// door not locked forever, key not carried to other floor or saved
+func (f *Floor) aoDropAllKey(ao gamei.ActiveObjectI, aox, aoy int) {
+	for _, keyID := range ao.GetInven().RemoveAllKey() {
+		if err := f.placeCarryObj2FloorAt(aox, aoy, carryingobject.NewKey(keyID)); err != nil {
+			f.log.TraceActiveObj("key place fail po lost, %v %v %v", f, ao, err)
+		}
+	}
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func (f *Floor) aoTeleportInFloorRandom(ao gamei.ActiveObjectI) error {
@@ -12,6 +12,7 @@
 package floor
 
 import (
+	"sort"
 	"time"
 
 	"github.com/kasworld/goguelike/config/contagionarea"
@@ -23,6 +24,7 @@ import (
 	"github.com/kasworld/goguelike/enum/equipslottype"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype"
 	"github.com/kasworld/goguelike/enum/fieldobjdisplaytype"
+	"github.com/kasworld/goguelike/enum/noisetype"
 	"github.com/kasworld/goguelike/enum/scrolltype"
 	"github.com/kasworld/goguelike/enum/turnresulttype"
 	"github.com/kasworld/goguelike/enum/way9type"
@@ -49,6 +51,7 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 
 	// wait ai run last turn
 	f.aiWG.Wait()
+	f.noiseList = f.noiseList[:0]
 
 	// prepare to process ao
 	ao2ActReqRsp := make(map[gamei.ActiveObjectI]*aoactreqrsp.ActReqRsp, f.aoPosMan.Count())
@@ -70,7 +73,18 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 			}
 		}
 	}
+	// process order must not depend on map order, same in replay
+	sortAOListByUUID(aoListToProcessInTurn)
+	sortAOListByUUID(aoAliveInFloorAtStart)
+	aoListActInTurn := make([]gamei.ActiveObjectI, 0, len(ao2ActReqRsp))
+	for _, ao := range aoListToProcessInTurn {
+		if _, exist := ao2ActReqRsp[ao]; exist {
+			aoListActInTurn = append(aoListActInTurn, ao)
+		}
+	}
 	f.floorCmdActStat.Add(len(ao2ActReqRsp))
+	turnRecord := f.beginTurnRecord(turnTime,
+		aoListToProcessInTurn, ao2ActReqRsp)
 
 	f.log.Monitor("%v ActiveObj:%v Alive:%v Acted:%v",
 		f,
@@ -181,7 +195,8 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 	}
 
 	// handle sleep condition
-	for ao, arr := range ao2ActReqRsp {
+	for _, ao := range aoListActInTurn {
+		arr := ao2ActReqRsp[ao]
 		if arr.Acted() {
 			continue
 		}
@@ -194,7 +209,8 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 		}
 	}
 	// handle remain turn2act ao
-	for ao, arr := range ao2ActReqRsp {
+	for _, ao := range aoListActInTurn {
+		arr := ao2ActReqRsp[ao]
 		if arr.Acted() {
 			continue
 		}
@@ -212,32 +228,38 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 	}); err != nil {
 		f.log.Fatal("fail to delete dangerobject %v", err)
 	}
+	f.moveProjectile()
 
 	// add areaattack fieldobj dangerobj
-	f.foPosMan.IterAll(func(o uuidposman.UUIDPosI, foX, foY int) bool {
-		fo := o.(*fieldobject.FieldObject)
+	for _, v := range f.getFieldObjListInOrder() {
+		fo, foX, foY := v.FO, v.X, v.Y
 		switch fo.ActType {
 		case fieldobjacttype.RotateLineAttack:
 			wings := fo.GetLineAttack()
 			for _, line := range wings {
 				for i, v := range line {
 					rr := fo.CalcLineAttackAffectRate(v.L, i, len(line))
-					f.doPosMan.AddToXY(
+					f.addDangerObj(
 						dangerobject.NewFOAttact(fo, dangertype.RotateLineAttack, rr),
 						foX+v.X, foY+v.Y,
 					)
 				}
 			}
 			fo.Degree += fo.DegreePerTurn
+		case fieldobjacttype.Shop:
+			f.restockShop(fo)
 		case fieldobjacttype.Mine:
 			if fo.Radius >= gameconst.ViewPortW { // end explode
 				fo.Radius = -1
 			}
+			if fo.Radius == 0 { // explode start
+				f.addNoise(noisetype.Explode, foX, foY, noisetype.Explode.Radius(), nil)
+			}
 			if fo.Radius >= 0 { //  active
 				// add do
 				rr := fo.CalcMineAffectRate()
 				for _, v := range minedata.MineData[int(fo.Radius)] {
-					f.doPosMan.AddToXY(
+					f.addDangerObj(
 						dangerobject.NewFOAttact(fo, dangertype.MineExplode, rr),
 						foX+v.X, foY+v.Y,
 					)
@@ -246,11 +268,20 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 				fo.Radius++
 			}
 		}
-		return false
-	})
+	}
+
+	// handle boulder push, crush damage apply with attack
+	for _, ao := range aoListActInTurn {
+		arr := ao2ActReqRsp[ao]
+		if arr.Acted() || !ao.IsAlive() || arr.Req.Act != c2t_idcmd.Move {
+			continue
+		}
+		f.aoPushBoulder(ao, arr)
+	}
 
 	// handle attack
-	for ao, arr := range ao2ActReqRsp {
+	for _, ao := range aoListActInTurn {
+		arr := ao2ActReqRsp[ao]
 		if arr.Acted() || !ao.IsAlive() {
 			continue
 		}
@@ -261,13 +292,23 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 			f.addAttackWide(ao, arr)
 		case c2t_idcmd.AttackLong:
 			f.addAttackLong(ao, arr)
+		case c2t_idcmd.Shoot:
+			f.addShoot(ao, arr)
+		case c2t_idcmd.Cast:
+			f.addCast(ao, arr)
 		}
 	}
 	// handle battle on danger obj
-	f.doPosMan.IterAll(func(o uuidposman.UUIDPosI, dstX, dstY int) bool {
-		do := o.(*dangerobject.DangerObject)
-		for _, vv := range f.aoPosMan.GetObjListAt(dstX, dstY) {
-			dstAO := vv.(gamei.ActiveObjectI)
+	for _, v := range f.getDangerObjListInOrder() {
+		do, dstX, dstY := v.DO, v.X, v.Y
+		switch do.DangerType {
+		case dangertype.BasicAttack, dangertype.WideAttack:
+			if f.terrain.IsDestructibleWallAt(dstX, dstY) {
+				srcTile := f.terrain.GetTiles()[do.OwnerX][do.OwnerY]
+				f.aoAttackWall(do.Owner.(gamei.ActiveObjectI), srcTile, dstX, dstY)
+			}
+		}
+		for _, dstAO := range f.getAOListAtInOrder(dstX, dstY) {
 			if !dstAO.IsAlive() {
 				continue
 			}
@@ -283,10 +324,16 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 				f.foRotateLineAttack(do, dstAO, dstX, dstY)
 			case dangertype.MineExplode:
 				f.foMineExplodeAttack(do, dstAO, dstX, dstY)
+			case dangertype.Projectile:
+				if !f.projectileAttack(do, dstAO, dstX, dstY) {
+					continue
+				}
+			case dangertype.SkillArea:
+				// affect by skill buff only
 			}
+			f.applySkillTargetBuff(do, dstAO)
 		}
-		return false
-	})
+	}
 
 	for _, ao := range aoListToProcessInTurn {
 		if ao.ApplyDamageFromDangerObj() { // just killed
@@ -295,7 +342,8 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 	}
 
 	// handle ao action except attack
-	for ao, arr := range ao2ActReqRsp {
+	for _, ao := range aoListActInTurn {
+		arr := ao2ActReqRsp[ao]
 		if arr.Acted() || !ao.IsAlive() {
 			continue
 		}
@@ -312,7 +360,8 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 		default:
 			f.log.Fatal("unknown aoact %v %v", f, arr)
 
-		case c2t_idcmd.Attack, c2t_idcmd.AttackWide, c2t_idcmd.AttackLong:
+		case c2t_idcmd.Attack, c2t_idcmd.AttackWide, c2t_idcmd.AttackLong, c2t_idcmd.Shoot,
+			c2t_idcmd.Cast:
 			// must be acted
 			f.log.Fatal("already acted %v %v", f, arr)
 
@@ -450,6 +499,9 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 				ao.GetInven().RemoveByUUID(arr.Req.UUID)
 				ao.GetAchieveStat().Inc(achievetype.UseCarryObj)
 				ao.GetScrollStat().Inc(scrolltype.Teleport)
+				ao.GetIdentifyKnowledge().IdentifyScroll(scrolltype.Teleport)
+			} else if ok && so.GetScrollType() == scrolltype.SummonPet {
+				f.aoSummonPet(ao, arr, aox, aoy)
 			} else {
 				if err := ao.DoUseCarryObj(arr.Req.UUID); err != nil {
 					f.log.Error("%v %v %v", f, ao, err)
@@ -490,6 +542,30 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 				aoactreqrsp.Act{Act: c2t_idcmd.Recycle, UUID: arr.Req.UUID},
 				c2t_error.None)
 
+		case c2t_idcmd.Craft:
+			f.aoActCraft(ao, arr, aox, aoy)
+
+		case c2t_idcmd.Buy:
+			f.aoActBuy(ao, arr, aox, aoy)
+
+		case c2t_idcmd.Repair:
+			f.aoActRepair(ao, arr, aox, aoy)
+
+		case c2t_idcmd.AcceptQuest:
+			f.aoActAcceptQuest(ao, arr, aox, aoy)
+
+		case c2t_idcmd.CompleteQuest:
+			f.aoActCompleteQuest(ao, arr, aox, aoy)
+
+		case c2t_idcmd.OpenDoor:
+			f.aoActOpenDoor(ao, arr, aox, aoy)
+
+		case c2t_idcmd.CloseDoor:
+			f.aoActCloseDoor(ao, arr, aox, aoy)
+
+		case c2t_idcmd.CommandPet:
+			f.aoActCommandPet(ao, arr, aox, aoy)
+
 		case c2t_idcmd.EnterPortal:
 			if ao.GetTurnData().Condition.TestByCondition(condition.Float) {
 				arr.SetDone(
@@ -602,7 +678,8 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 	}
 
 	// set ao act result
-	for ao, arr := range ao2ActReqRsp {
+	for _, ao := range aoListActInTurn {
+		arr := ao2ActReqRsp[ao]
 		ao.SetTurnActReqRsp(arr)
 	}
 
@@ -648,6 +725,9 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 		if err := f.ActiveObjDropCarryObjByDie(ao, aox, aoy); err != nil {
 			f.log.Error("%v %v %v", f, ao, err)
 		}
+		if ao.GetBoss() != nil {
+			f.bossDied(ao, aox, aoy)
+		}
 		ao.Noti_Death(f) // set rebirth count
 		if aoconn := ao.GetClientConn(); aoconn != nil {
 			if err := aoconn.SendNotiPacket(
@@ -659,6 +739,12 @@ func (f *Floor) processTurn(turnTime time.Time) error {
 		}
 	}
 
+	f.endTurnRecord(turnRecord, aoListToProcessInTurn, ao2ActReqRsp)
+
+	f.prepareLight(aoListToProcessInTurn)
+	f.addActNoise(aoListToProcessInTurn, ao2ActReqRsp)
+	f.processNoise(aoListToProcessInTurn, aoMapSkipTurn)
+
 	// for next turn
 	// request next turn act for user
 	f.sendViewportNoti(turnTime, aoListToProcessInTurn, aoMapSkipTurn)
@@ -724,26 +810,36 @@ func (f *Floor) sendViewportNoti(
 			f.log.Warn("ao not in currentfloor %v %v, skip tile, obj noti", f, ao)
 			continue
 		}
-		if ao.GetAndClearNeedTANoti() {
+		spConnList := f.tower.GetSpectatorManager().GetConnList(ao.GetUUID())
+		sightMat := f.getSightMat(aox, aoy)
+		ao.SetSightMat(sightMat)
+		needTANoti := ao.GetAndClearNeedTANoti()
+		if f.isNearChangedLight(aox, aoy, ao.GetTurnData().Sight) {
+			needTANoti = true // lit tiles changed by moving light
+		}
+		if needTANoti {
 			sight := ao.GetTurnData().Sight
-			sightMat := f.terrain.GetViewportCache().GetByCache(aox, aoy)
 			ao.UpdateVisitAreaBySightMat2(f, aox, aoy, sightMat,
 				float32(sight))
-			if aoconn := ao.GetClientConn(); aoconn != nil {
+			if aoconn := ao.GetClientConn(); aoconn != nil || len(spConnList) > 0 {
 				notiTA := f.ToPacket_NotiTileArea(aox, aoy, sight)
-				if err := aoconn.SendNotiPacket(
-					c2t_idnoti.VPTiles,
-					notiTA,
-				); err != nil {
-					f.log.Error("%v %v %v", f, ao, err)
+				if aoconn != nil {
+					if err := aoconn.SendNotiPacket(
+						c2t_idnoti.VPTiles,
+						notiTA,
+					); err != nil {
+						f.log.Error("%v %v %v", f, ao, err)
+					}
 				}
+				f.sendNoti2Spectator(spConnList, c2t_idnoti.VPTiles, notiTA)
 			}
 		}
-		if aoconn := ao.GetClientConn(); aoconn != nil {
+		if aoconn := ao.GetClientConn(); aoconn != nil || len(spConnList) > 0 {
 			notiOL := f.ToPacket_NotiObjectList(
 				turnTime,
 				vpixyolistcache,
-				aox, aoy, ao.GetTurnData().Sight)
+				aox, aoy, ao.GetTurnData().Sight,
+				ao.GetIdentifyKnowledge())
 			aoContidion := ao.GetTurnData().Condition
 			if aoContidion.TestByCondition(condition.Blind) ||
 				aoContidion.TestByCondition(condition.Invisible) {
@@ -752,13 +848,45 @@ func (f *Floor) sendViewportNoti(
 					ao.ToPacket_ActiveObjClient(aox, aoy))
 			}
 			notiOL.ActiveObj = ao.ToPacket_PlayerActiveObjInfo()
-			if err := aoconn.SendNotiPacket(
-				c2t_idnoti.ObjectList,
-				notiOL,
-			); err != nil {
-				f.log.Error("%v %v %v", f, ao, err)
+			if len(spConnList) > 0 {
+				// spectator not ack, send full ObjectList without Seq
+				spNotiOL := *notiOL
+				f.sendNoti2Spectator(spConnList, c2t_idnoti.ObjectList, &spNotiOL)
+			}
+			if aoconn != nil {
+				idnoti, body := ao.GetObjListSender().Make(
+					notiOL, f.tower.Config().ObjectListKeyframe)
+				if err := aoconn.SendNotiPacket(
+					idnoti,
+					body,
+				); err != nil {
+					f.log.Error("%v %v %v", f, ao, err)
+				}
 			}
 		}
+		f.sendDiplomacyNoti(ao)
 	}
 	// fmt.Printf("%v\n", vpixyolistcache)
 }
+
+type fieldObjAt struct {
+	FO   *fieldobject.FieldObject
+	X, Y int
+}
+
+// getFieldObjListInOrder return all fieldobj sorted by pos
+// uuid of fieldobj is random, pos is same in replay
+func (f *Floor) getFieldObjListInOrder() []fieldObjAt {
+	rtn := make([]fieldObjAt, 0, f.foPosMan.Count())
+	f.foPosMan.IterAll(func(o uuidposman.UUIDPosI, x, y int) bool {
+		rtn = append(rtn, fieldObjAt{o.(*fieldobject.FieldObject), x, y})
+		return false
+	})
+	sort.Slice(rtn, func(i, j int) bool {
+		if rtn[i].X != rtn[j].X {
+			return rtn[i].X < rtn[j].X
+		}
+		return rtn[i].Y < rtn[j].Y
+	})
+	return rtn
+}
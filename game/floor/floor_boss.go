@@ -0,0 +1,54 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/config/bossdata"
+	"github.com/kasworld/goguelike/enum/turnresulttype"
+	"github.com/kasworld/goguelike/game/cmd2tower"
+	"github.com/kasworld/goguelike/game/gamei"
+)
+
+// SearchBossPos near script pos or rand pos in room
+func (f *Floor) SearchBossPos(bs *bossdata.Boss) (int, int, error) {
+	if bs.InRoom {
+		return f.SearchRandomActiveObjPosInRoomOrRandPos()
+	}
+	return f.findActiveObjPlacabelNear(bs.X, bs.Y)
+}
+
+// bossDied drop loot of boss and announce to tower
+func (f *Floor) bossDied(ao gamei.ActiveObjectI, aox, aoy int) {
+	bs := ao.GetBoss()
+	for _, mt := range bs.Loot {
+		for i := 0; i < mt.Count; i++ {
+			po := f.makeCarryObjByMaterial(mt, bs.Name, bs.Faction)
+			if err := f.placeCarryObj2FloorAt(aox, aoy, po); err != nil {
+				f.log.TraceActiveObj("boss loot place fail po lost, %v %v", f, err)
+			}
+		}
+	}
+	killerName := ""
+	for _, v := range ao.GetTurnResultList() {
+		if v.GetTurnResultType() != turnresulttype.KilledBy {
+			continue
+		}
+		if killer, ok := v.GetDstObj().(gamei.ActiveObjectI); ok {
+			killerName = killer.GetNickName()
+		}
+	}
+	f.tower.GetReqCh() <- &cmd2tower.BossKilled{
+		Boss:       ao,
+		Floor:      f,
+		KillerName: killerName,
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"testing"
+)
+
+func TestSearchBossPos(t *testing.T) {
+	f := New(1, []string{
+		"NewTerrain w=32 h=32 name=BossTest",
+		"ResourceFillRect resource=Soil amount=64 x=0 y=0 w=32 h=32",
+		"FinalizeTerrain",
+		"AddBoss x=10 y=10 name=Slime level=1 faction=Black aiplan= equip= loot= respawn=10",
+	}, &testTower{})
+	if err := f.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Cleanup()
+	bs := f.terrain.GetBossList()[0]
+
+	x, y, err := f.SearchBossPos(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x != 10 || y != 10 {
+		t.Errorf("boss not at script pos %v %v", x, y)
+	}
+
+	// respawn when script pos taken by other ao
+	f.aoPosMan.AddToXY(posObj("other"), 10, 10)
+	x, y, err = f.SearchBossPos(bs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (x == 10 && y == 10) || x < 9 || x > 11 || y < 9 || y > 11 {
+		t.Errorf("boss not near script pos %v %v", x, y)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/noisetype"
+	"github.com/kasworld/goguelike/enum/turnresulttype"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/activeobject/turnresult"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+)
+
+// aoPushBoulder ao move to boulder push it 1 tile, ao move in move step
+// before attack step to apply crush damage in turn
+func (f *Floor) aoPushBoulder(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp) {
+	dir := arr.Req.Dir
+	if !dir.IsValid() || dir == way9type.Center {
+		return
+	}
+	aox, aoy, exist := f.aoPosMan.GetXYByUUID(ao.GetUUID())
+	if !exist {
+		return
+	}
+	bx, by := f.terrain.WrapXY(aox+dir.Dx(), aoy+dir.Dy())
+	if f.terrain.GetBoulderAt(bx, by) == nil {
+		return // not push, normal move
+	}
+	act := aoactreqrsp.Act{Act: c2t_idcmd.Move, Dir: dir}
+	dstX, dstY := f.terrain.WrapXY(bx+dir.Dx(), by+dir.Dy())
+	if !f.canPushBoulderTo(dstX, dstY) {
+		arr.SetDone(act, c2t_error.MoveBlockedByTile)
+		return
+	}
+	for _, dstAO := range f.getAOListAtInOrder(dstX, dstY) {
+		if !dstAO.IsAlive() {
+			continue
+		}
+		// crush ao against wall
+		backX, backY := f.terrain.WrapXY(dstX+dir.Dx(), dstY+dir.Dy())
+		if !f.terrain.GetTiles()[backX][backY].CharPlaceable() {
+			f.crushByBoulder(ao, dstAO)
+		}
+		arr.SetDone(act, c2t_error.MoveBlockedByActiveObj)
+		return
+	}
+	if err := f.terrain.MoveBoulder(bx, by, dstX, dstY); err != nil {
+		f.log.Error("%v %v %v", f, ao, err)
+		arr.SetDone(act, c2t_error.ActionCanceled)
+		return
+	}
+	f.notiTileChangedAt(bx, by)
+	f.notiTileChangedAt(dstX, dstY)
+	f.addNoise(noisetype.Rumble, dstX, dstY, noisetype.Rumble.Radius(), ao)
+}
+
+// canPushBoulderTo terrain allow and no carryobj to bury
+func (f *Floor) canPushBoulderTo(x, y int) bool {
+	return f.terrain.CanPushBoulderTo(x, y) &&
+		f.poPosMan.Get1stObjAt(x, y) == nil
+}
+
+func (f *Floor) crushByBoulder(src, dst gamei.ActiveObjectI) {
+	damage := gameconst.BoulderCrushRate * dst.GetTurnData().HPMax
+	src.AppendTurnResult(turnresult.New(turnresulttype.AttackTo, dst, damage))
+	dst.AppendTurnResult(turnresult.New(turnresulttype.AttackedFrom, src, damage))
+}
@@ -0,0 +1,117 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/turnresulttype"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/activeobject"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+)
+
+// newBoulderTestFloor boulder at 6,5, wall at 9,5
+func newBoulderTestFloor(t *testing.T) *Floor {
+	f := New(1, []string{
+		"NewTerrain w=32 h=32 name=BoulderTest",
+		"ResourceFillRect resource=Soil amount=64 x=0 y=0 w=32 h=32",
+		"TileAt tile=Wall x=9 y=5",
+		"FinalizeTerrain",
+		"AddBoulder x=6 y=5 display=Boulder message=Boulder",
+	}, &testTower{})
+	if err := f.Init(); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func addBoulderTestAO(t *testing.T, f *Floor, uuid string, x, y int) *activeobject.ActiveObject {
+	ao := activeobject.NewReplayActiveObj(uuid, uuid, f, f.log)
+	if err := f.aoPosMan.AddToXY(ao, x, y); err != nil {
+		t.Fatal(err)
+	}
+	ao.Noti_EnterFloor(f)
+	return ao
+}
+
+func pushEast(f *Floor, ao *activeobject.ActiveObject) *aoactreqrsp.ActReqRsp {
+	arr := &aoactreqrsp.ActReqRsp{
+		Req: aoactreqrsp.Act{Act: c2t_idcmd.Move, Dir: way9type.East},
+	}
+	f.aoPushBoulder(ao, arr)
+	return arr
+}
+
+func TestPushBoulder(t *testing.T) {
+	f := newBoulderTestFloor(t)
+	defer f.Cleanup()
+	pusher := addBoulderTestAO(t, f, "pusher", 5, 5)
+
+	arr := pushEast(f, pusher)
+	if arr.Acted() {
+		t.Fatalf("push not left to move %+v", arr)
+	}
+	if f.terrain.GetBoulderAt(6, 5) != nil || f.terrain.GetBoulderAt(7, 5) == nil {
+		t.Errorf("boulder not moved to 7 5")
+	}
+}
+
+func TestPushBoulderBlocked(t *testing.T) {
+	f := newBoulderTestFloor(t)
+	defer f.Cleanup()
+	pusher := addBoulderTestAO(t, f, "pusher", 5, 5)
+	if err := f.poPosMan.AddToXY(carryingobject.NewPotion(potiontype.RecoverHP10), 7, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	arr := pushEast(f, pusher)
+	if arr.Error != c2t_error.MoveBlockedByTile {
+		t.Errorf("push to carryobj %+v", arr)
+	}
+	if f.terrain.GetBoulderAt(6, 5) == nil {
+		t.Errorf("boulder moved over carryobj")
+	}
+}
+
+func TestPushBoulderCrush(t *testing.T) {
+	f := newBoulderTestFloor(t)
+	defer f.Cleanup()
+	if err := f.terrain.MoveBoulder(6, 5, 7, 5); err != nil {
+		t.Fatal(err)
+	}
+	pusher := addBoulderTestAO(t, f, "pusher", 6, 5)
+	victim := addBoulderTestAO(t, f, "victim", 8, 5) // wall at 9,5
+
+	arr := pushEast(f, pusher)
+	if arr.Error != c2t_error.MoveBlockedByActiveObj {
+		t.Errorf("push to ao %+v", arr)
+	}
+	if f.terrain.GetBoulderAt(7, 5) == nil {
+		t.Errorf("boulder moved over ao")
+	}
+	crushed := false
+	for _, v := range victim.GetTurnResultList() {
+		if v.ResultType == turnresulttype.AttackedFrom &&
+			v.Arg == gameconst.BoulderCrushRate*victim.GetTurnData().HPMax {
+			crushed = true
+		}
+	}
+	if !crushed {
+		t.Errorf("not crushed %v", victim.GetTurnResultList())
+	}
+}
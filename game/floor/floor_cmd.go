@@ -26,36 +26,56 @@ func (f *Floor) processCmd2Floor(data interface{}) {
 		f.log.Fatal("unknown pk recv %v %#v", f, data)
 
 	case *cmd2floor.ReqLeaveFloor:
+		f.cancelTradeOf(pk.ActiveObj)
+		if aox, aoy, exist := f.aoPosMan.GetXYByUUID(pk.ActiveObj.GetUUID()); exist {
+			f.aoDropAllKey(pk.ActiveObj, aox, aoy)
+		}
 		if err := f.aoPosMan.Del(pk.ActiveObj); err != nil {
 			f.log.Fatal("%v %v", f, err)
 		}
+		notiLeave := &c2t_obj.NotiLeaveFloor_data{
+			FI: f.ToPacket_FloorInfo(),
+		}
 		if conn := pk.ActiveObj.GetClientConn(); conn != nil {
 			if err := conn.SendNotiPacket(
 				c2t_idnoti.LeaveFloor,
-				&c2t_obj.NotiLeaveFloor_data{
-					FI: f.ToPacket_FloorInfo(),
-				},
+				notiLeave,
 			); err != nil {
 				f.log.Error("%v %v", f, err)
 			}
 		}
+		f.sendNoti2Spectator(
+			f.tower.GetSpectatorManager().GetConnList(pk.ActiveObj.GetUUID()),
+			c2t_idnoti.LeaveFloor, notiLeave)
 
 	case *cmd2floor.ReqEnterFloor:
-		err := f.aoPosMan.AddOrUpdateToXY(pk.ActiveObj, pk.X, pk.Y)
+		x, y := pk.X, pk.Y
+		if pk.Near {
+			if nx, ny, err := f.findActiveObjPlacabelNear(x, y); err == nil {
+				x, y = nx, ny
+			} else {
+				f.log.Warn("%v %v %v", f, pk.ActiveObj, err)
+			}
+		}
+		err := f.aoPosMan.AddOrUpdateToXY(pk.ActiveObj, x, y)
 		if err != nil {
 			f.log.Fatal("%v %v", f, err)
 		}
 		pk.ActiveObj.Noti_EnterFloor(f)
+		notiEnter := &c2t_obj.NotiEnterFloor_data{
+			FI: f.ToPacket_FloorInfo(),
+		}
 		if conn := pk.ActiveObj.GetClientConn(); conn != nil {
 			if err := conn.SendNotiPacket(
 				c2t_idnoti.EnterFloor,
-				&c2t_obj.NotiEnterFloor_data{
-					FI: f.ToPacket_FloorInfo(),
-				},
+				notiEnter,
 			); err != nil {
 				f.log.Error("%v %v", f, err)
 			}
 		}
+		f.sendNoti2Spectator(
+			f.tower.GetSpectatorManager().GetConnList(pk.ActiveObj.GetUUID()),
+			c2t_idnoti.EnterFloor, notiEnter)
 
 	case *cmd2floor.ReqRebirth2Floor:
 		err := f.aoPosMan.AddOrUpdateToXY(pk.ActiveObj, pk.X, pk.Y)
@@ -64,6 +84,13 @@ func (f *Floor) processCmd2Floor(data interface{}) {
 		}
 		pk.ActiveObj.Noti_EnterFloor(f)
 		pk.ActiveObj.Noti_Rebirth()
+		// rebirth floor may differ from dead floor
+		f.sendNoti2Spectator(
+			f.tower.GetSpectatorManager().GetConnList(pk.ActiveObj.GetUUID()),
+			c2t_idnoti.EnterFloor,
+			&c2t_obj.NotiEnterFloor_data{
+				FI: f.ToPacket_FloorInfo(),
+			})
 		if conn := pk.ActiveObj.GetClientConn(); conn != nil {
 			if err := conn.SendNotiPacket(
 				c2t_idnoti.Rebirthed,
@@ -79,6 +106,17 @@ func (f *Floor) processCmd2Floor(data interface{}) {
 	case *cmd2floor.APIAdminCmd2Floor:
 		pk.RspCh <- f.Call_APIAdminCmd2Floor(pk.ActiveObj, pk.ReqPk)
 
+	case *cmd2floor.APITrade:
+		pk.RspCh <- f.Call_APITrade(pk.ActiveObj, pk.ReqPk)
+
+	case *cmd2floor.APIListShop:
+		rsp, ec := f.Call_APIListShop(pk.ActiveObj)
+		pk.RspCh <- cmd2floor.ListShopResult{Rsp: rsp, ErrorCode: ec}
+
+	case *cmd2floor.APIListQuest:
+		rsp, ec := f.Call_APIListQuest(pk.ActiveObj)
+		pk.RspCh <- cmd2floor.ListQuestResult{Rsp: rsp, ErrorCode: ec}
+
 	}
 }
 
@@ -106,5 +144,15 @@ func (f *Floor) Call_APIAdminTeleport2Floor(
 
 func (f *Floor) Call_APIAdminCmd2Floor(
 	ActiveObj gamei.ActiveObjectI, ReqPk *c2t_obj.ReqAdminFloorCmd_data) c2t_error.ErrorCode {
+	switch ReqPk.Cmd {
+	default:
+		return c2t_error.ActionCanceled
+	case "LockDoor", "UnlockDoor", "OpenDoor", "CloseDoor":
+		if err := f.adminDoorCmd(ActiveObj, ReqPk.Cmd, ReqPk.Arg); err != nil {
+			f.log.Warn("%v %v %v", f, ActiveObj, err)
+			return c2t_error.ActionProhibited
+		}
+	}
+	ActiveObj.GetAchieveStat().Inc(achievetype.Admin)
 	return c2t_error.None
 }
@@ -0,0 +1,76 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/config/craftdata"
+	"github.com/kasworld/goguelike/config/gamedata"
+	"github.com/kasworld/goguelike/enum/condition"
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+func (f *Floor) aoActCraft(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp, aox, aoy int) {
+	act := aoactreqrsp.Act{Act: c2t_idcmd.Craft, Recipe: arr.Req.Recipe}
+	if ao.GetTurnData().Condition.TestByCondition(condition.Float) {
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	fo, ok := f.foPosMan.Get1stObjAt(aox, aoy).(*fieldobject.FieldObject)
+	if !ok || fo.ActType != fieldobjacttype.CraftCarryObj {
+		f.log.Error("not at Crafter FieldObj %v %v", f, ao)
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	rcp := craftdata.FindByName(gamedata.CraftRecipeList, arr.Req.Recipe)
+	if rcp == nil {
+		arr.SetDone(act, c2t_error.ObjectNotFound)
+		return
+	}
+	madeList, err := ao.DoCraftCarryObj(rcp)
+	if err != nil {
+		f.log.Debug("%v %v %v", f, ao, err)
+		arr.SetDone(act, c2t_error.InsufficientMaterial)
+		return
+	}
+	arr.SetDone(act, c2t_error.None)
+	f.sendCraftNoti(ao, rcp, madeList)
+}
+
+func (f *Floor) sendCraftNoti(ao gamei.ActiveObjectI, rcp *craftdata.Recipe, madeList []gamei.CarryingObjectI) {
+	aoconn := ao.GetClientConn()
+	if aoconn == nil {
+		return
+	}
+	noti := &c2t_obj.NotiCraft_data{
+		Recipe: rcp.Name,
+	}
+	for _, v := range madeList {
+		switch po := v.(type) {
+		case gamei.EquipObjI:
+			noti.EquipList = append(noti.EquipList, po.ToPacket_EquipClient())
+		case gamei.PotionI:
+			noti.PotionList = append(noti.PotionList, po.ToPacket_PotionClient())
+		case gamei.ScrollI:
+			noti.ScrollList = append(noti.ScrollList, po.ToPacket_ScrollClient())
+		}
+	}
+	if err := aoconn.SendNotiPacket(c2t_idnoti.Craft, noti); err != nil {
+		f.log.Error("%v %v %v", f, ao, err)
+	}
+}
@@ -12,11 +12,14 @@
 package floor
 
 import (
+	"sort"
+
 	"github.com/kasworld/goguelike/config/gameconst"
 	"github.com/kasworld/goguelike/config/slippperydata"
 	"github.com/kasworld/goguelike/enum/achievetype"
 	"github.com/kasworld/goguelike/enum/condition"
 	"github.com/kasworld/goguelike/enum/dangertype"
+	"github.com/kasworld/goguelike/enum/noisetype"
 	"github.com/kasworld/goguelike/enum/tile_flag"
 	"github.com/kasworld/goguelike/enum/turnresulttype"
 	"github.com/kasworld/goguelike/enum/way9type"
@@ -24,6 +27,7 @@ import (
 	"github.com/kasworld/goguelike/game/aoactreqrsp"
 	"github.com/kasworld/goguelike/game/dangerobject"
 	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/lib/uuidposman"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
 )
@@ -68,7 +72,7 @@ func (f *Floor) addAttackWide(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp
 		if f.terrain.GetTiles()[dstX][dstY].NoBattle() {
 			continue
 		}
-		if err := f.doPosMan.AddToXY(
+		if err := f.addDangerObj(
 			dangerobject.NewAOAttact(ao, dangertype.WideAttack, aox, aoy),
 			dstX, dstY); err != nil {
 			f.log.Fatal("fail to AddToXY %v", err)
@@ -91,7 +95,7 @@ func (f *Floor) addAttackLong(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp
 		if f.terrain.GetTiles()[dstX][dstY].NoBattle() {
 			continue
 		}
-		if err := f.doPosMan.AddToXY(
+		if err := f.addDangerObj(
 			dangerobject.NewAOAttact(ao, dangertype.LongAttack, aox, aoy),
 			dstX, dstY); err != nil {
 			f.log.Fatal("fail to AddToXY %v", err)
@@ -114,7 +118,7 @@ func (f *Floor) addBasicAttack(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRs
 			c2t_error.ActionProhibited)
 		return
 	}
-	if err := f.doPosMan.AddToXY(
+	if err := f.addDangerObj(
 		dangerobject.NewAOAttact(ao, dangertype.BasicAttack, aox, aoy),
 		dstX, dstY); err != nil {
 		f.log.Fatal("fail to AddToXY %v", err)
@@ -129,6 +133,18 @@ func (f *Floor) addBasicAttack(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRs
 
 func (f *Floor) aoAttackActiveObj(src, dst gamei.ActiveObjectI, srcTile, dstTile tile_flag.TileFlag) {
 
+	// no damage between owner and pet
+	if src.IsPartyOf(dst) {
+		return
+	}
+
+	// damage rate by faction relation, 0 : attack not allowed
+	atkRate := f.tower.GetDiplomacy().AttackRate(
+		src.GetBattleFaction(), dst.GetBattleFaction())
+	if atkRate <= 0 {
+		return
+	}
+
 	// attack to invisible ao miss 50%
 	if dst.GetTurnData().Condition.TestByCondition(condition.Invisible) && f.rnd.Intn(2) == 0 {
 		src.GetAchieveStat().Inc(achievetype.AttackMiss)
@@ -177,7 +193,7 @@ func (f *Floor) aoAttackActiveObj(src, dst gamei.ActiveObjectI, srcTile, dstTile
 		diffValue = -diffValue
 	}
 
-	damage := diffValue
+	damage := diffValue * atkRate
 
 	if atkCritical {
 		damage *= 2
@@ -186,6 +202,8 @@ func (f *Floor) aoAttackActiveObj(src, dst gamei.ActiveObjectI, srcTile, dstTile
 
 	src.AppendTurnResult(turnresult.New(turnresulttype.AttackTo, dst, damage))
 	dst.AppendTurnResult(turnresult.New(turnresulttype.AttackedFrom, src, damage))
+	src.WearEquipByHit(true)
+	dst.WearEquipByHit(false)
 
 	src.GetAchieveStat().Add(achievetype.DamageTotalGive, damage)
 	src.GetAchieveStat().SetIfGt(achievetype.DamageMaxGive, damage)
@@ -195,6 +213,27 @@ func (f *Floor) aoAttackActiveObj(src, dst gamei.ActiveObjectI, srcTile, dstTile
 	src.AddBattleExp(damage * gameconst.ActiveObjExp_Damage)
 }
 
+// aoAttackWall damage destructible wall, collapse to rubble
+func (f *Floor) aoAttackWall(src gamei.ActiveObjectI, srcTile tile_flag.TileFlag, dstX, dstY int) {
+	srcbias := src.GetTurnData().AttackBias.Add(f.GetEnvBias())
+	damage := srcbias.SelectSkill(f.rnd.Intn(3))*srcTile.AtkMod() +
+		src.GetTurnData().Level
+	if damage <= 0 {
+		return
+	}
+	collapsed, err := f.terrain.DamageWallAt(dstX, dstY, damage)
+	if err != nil {
+		f.log.Error("%v %v %v", f, src, err)
+		return
+	}
+	src.WearEquipByHit(true)
+	if !collapsed {
+		return
+	}
+	f.notiTileChangedAt(dstX, dstY)
+	f.addNoise(noisetype.Rumble, dstX, dstY, noisetype.Rumble.Radius(), nil)
+}
+
 func (f *Floor) foRotateLineAttack(do *dangerobject.DangerObject, dstao gamei.ActiveObjectI, dstx, dsty int) {
 	hpdamage := do.AffectRate * dstao.GetTurnData().HPMax
 	dstao.AppendTurnResult(turnresult.New(turnresulttype.AttackedFrom, do.Owner, hpdamage))
@@ -204,3 +243,40 @@ func (f *Floor) foMineExplodeAttack(do *dangerobject.DangerObject, dstao gamei.A
 	hpdamage := do.AffectRate * dstao.GetTurnData().HPMax
 	dstao.AppendTurnResult(turnresult.New(turnresulttype.AttackedFrom, do.Owner, hpdamage))
 }
+
+// addDangerObj set Seq and add to floor
+// uuid of dangerobj is random, Seq keep process order same in replay
+func (f *Floor) addDangerObj(do *dangerobject.DangerObject, x, y int) error {
+	f.doSeq++
+	do.Seq = f.doSeq
+	return f.doPosMan.AddToXY(do, x, y)
+}
+
+type dangerObjAt struct {
+	DO   *dangerobject.DangerObject
+	X, Y int
+}
+
+// getDangerObjListInOrder return all dangerobj sorted by Seq
+func (f *Floor) getDangerObjListInOrder() []dangerObjAt {
+	rtn := make([]dangerObjAt, 0, f.doPosMan.Count())
+	f.doPosMan.IterAll(func(o uuidposman.UUIDPosI, x, y int) bool {
+		rtn = append(rtn, dangerObjAt{o.(*dangerobject.DangerObject), x, y})
+		return false
+	})
+	sort.Slice(rtn, func(i, j int) bool {
+		return rtn[i].DO.Seq < rtn[j].DO.Seq
+	})
+	return rtn
+}
+
+// getAOListAtInOrder return ao at x,y sorted by uuid
+func (f *Floor) getAOListAtInOrder(x, y int) []gamei.ActiveObjectI {
+	objList := f.aoPosMan.GetObjListAt(x, y)
+	rtn := make([]gamei.ActiveObjectI, 0, len(objList))
+	for _, v := range objList {
+		rtn = append(rtn, v.(gamei.ActiveObjectI))
+	}
+	sortAOListByUUID(rtn)
+	return rtn
+}
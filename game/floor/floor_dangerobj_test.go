@@ -0,0 +1,64 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/enum/turnresulttype"
+	"github.com/kasworld/goguelike/game/activeobject"
+	"github.com/kasworld/goguelike/game/diplomacy"
+)
+
+// attackDamage total damage of n attack between same faction ao
+func attackDamage(t *testing.T, diplomacyCmd string, n int) float64 {
+	dp := diplomacy.New()
+	if err := dp.ExecCmdline(diplomacyCmd); err != nil {
+		t.Fatal(err)
+	}
+	f := New(1, []string{
+		"NewTerrain w=32 h=32 name=AttackTest",
+		"ResourceFillRect resource=Soil amount=64 x=0 y=0 w=32 h=32",
+		"FinalizeTerrain",
+	}, &testTower{diplomacy: dp})
+	if err := f.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Cleanup()
+	src := activeobject.NewReplayActiveObj("src", "src", f, f.log)
+	dst := activeobject.NewReplayActiveObj("dst", "dst", f, f.log)
+	tl := f.terrain.GetTiles()[0][0]
+	for i := 0; i < n; i++ {
+		f.aoAttackActiveObj(src, dst, tl, tl)
+	}
+	sum := 0.0
+	for _, v := range src.GetTurnResultList() {
+		if v.GetTurnResultType() == turnresulttype.AttackTo {
+			sum += v.GetDamage()
+		}
+	}
+	return sum
+}
+
+func TestAttackRateDamage(t *testing.T) {
+	full := attackDamage(t, "DiplomacyDefault relation=Hostile", 100)
+	if full <= 0 {
+		t.Fatalf("no damage %v", full)
+	}
+	half := attackDamage(t, "DiplomacyAttackRate relation=Hostile rate=0.5", 100)
+	if diff := full - half*2; diff > 1e-6 || diff < -1e-6 {
+		t.Errorf("damage not scaled by attack rate %v %v", full, half)
+	}
+	if none := attackDamage(t, "DiplomacyAttackRate relation=Hostile rate=0", 100); none != 0 {
+		t.Errorf("damage on attack not allowed %v", none)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/enum/relation"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+// sendDiplomacyNoti if faction of ao changed (by faction scroll) or diplomacy changed
+func (f *Floor) sendDiplomacyNoti(ao gamei.ActiveObjectI) {
+	aoconn := ao.GetClientConn()
+	if aoconn == nil {
+		return
+	}
+	dp := f.tower.GetDiplomacy()
+	if !ao.NeedDiplomacyNoti(dp.GetVersion()) {
+		return
+	}
+	ft := ao.GetBias().NearFaction()
+	noti := &c2t_obj.NotiDiplomacy_data{
+		Faction:        ft,
+		AllyList:       dp.ListByRelation(ft, relation.Ally),
+		HostileList:    dp.ListByRelation(ft, relation.Hostile),
+		AllyAttackRate: dp.GetAttackRate(relation.Ally),
+	}
+	if err := aoconn.SendNotiPacket(c2t_idnoti.Diplomacy, noti); err != nil {
+		f.log.Error("%v %v %v", f, ao, err)
+	}
+}
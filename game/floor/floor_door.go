@@ -0,0 +1,218 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/enum/noisetype"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/lib/uuidposman"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+)
+
+// placeDoorKeys key of locked door at random pos in floor
+func (f *Floor) placeDoorKeys() {
+	var lockedList []*fieldobject.FieldObject
+	f.foPosMan.IterAll(func(o uuidposman.UUIDPosI, x, y int) bool {
+		fo := o.(*fieldobject.FieldObject)
+		if fo.ActType == fieldobjacttype.Door && fo.DoorLocked {
+			lockedList = append(lockedList, fo)
+		}
+		return false
+	})
+	for _, fo := range lockedList {
+		if err := f.placeDoorKey(fo); err != nil {
+			f.log.Warn("%v, door never open %v %v", err, f, fo)
+		}
+	}
+}
+
+// placeDoorKey key of door at random pos in floor
+func (f *Floor) placeDoorKey(fo *fieldobject.FieldObject) error {
+	for try := 100; try > 0; try-- {
+		x, y := f.rnd.Intn(f.w), f.rnd.Intn(f.h)
+		if !f.canCarryObjPlaceAt(x, y) {
+			continue
+		}
+		if err := f.placeCarryObj2FloorAt(x, y, carryingobject.NewKey(fo.ID)); err != nil {
+			f.log.Error("fail to place key %v %v", f, err)
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("fail to place key")
+}
+
+// hasDoorKey key of door in floor or ao in floor
+func (f *Floor) hasDoorKey(doorID string) bool {
+	for _, v := range f.poPosMan.GetAllList() {
+		if key, ok := v.(gamei.KeyI); ok && key.GetKeyID() == doorID {
+			return true
+		}
+	}
+	for _, v := range f.aoPosMan.GetAllList() {
+		if ao, ok := v.(gamei.ActiveObjectI); ok && ao.GetInven().HasKey(doorID) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeDoorKeys remove all key in floor
+func (f *Floor) removeDoorKeys() {
+	for _, v := range f.poPosMan.GetAllList() {
+		if _, ok := v.(gamei.KeyI); !ok {
+			continue
+		}
+		if err := f.poPosMan.Del(v); err != nil {
+			f.log.Error("fail to remove key %v %v", f, err)
+		}
+	}
+}
+
+// setDoorState change door and send tile noti to ao see door
+func (f *Floor) setDoorState(x, y int, closed, locked bool) error {
+	if err := f.terrain.SetDoorState(x, y, closed, locked); err != nil {
+		return err
+	}
+	f.notiTileChangedAt(x, y)
+	return nil
+}
+
+// canCloseDoorAt no ao, carryobj on door
+func (f *Floor) canCloseDoorAt(x, y int) bool {
+	return f.aoPosMan.Get1stObjAt(x, y) == nil && f.poPosMan.Get1stObjAt(x, y) == nil
+}
+
+func (f *Floor) aoActOpenDoor(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp, aox, aoy int) {
+	dir := arr.Req.Dir
+	act := aoactreqrsp.Act{Act: c2t_idcmd.OpenDoor, Dir: dir}
+	if !dir.IsValid() || dir == way9type.Center {
+		arr.SetDone(act, c2t_error.InvalidDirection)
+		return
+	}
+	x, y := f.terrain.WrapXY(aox+dir.Dx(), aoy+dir.Dy())
+	fo := f.terrain.GetDoorAt(x, y)
+	if fo == nil || !fo.DoorClosed {
+		arr.SetDone(act, c2t_error.ObjectNotFound)
+		return
+	}
+	if fo.DoorLocked && !ao.GetInven().HasKey(fo.ID) {
+		arr.SetDone(act, c2t_error.DoorLocked)
+		return
+	}
+	if err := f.setDoorState(x, y, false, false); err != nil {
+		f.log.Error("%v %v %v", f, ao, err)
+		arr.SetDone(act, c2t_error.ActionCanceled)
+		return
+	}
+	ao.GetFieldObjActStat().Inc(fo.ActType)
+	arr.SetDone(act, c2t_error.None)
+}
+
+func (f *Floor) aoActCloseDoor(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp, aox, aoy int) {
+	dir := arr.Req.Dir
+	act := aoactreqrsp.Act{Act: c2t_idcmd.CloseDoor, Dir: dir}
+	if !dir.IsValid() || dir == way9type.Center {
+		arr.SetDone(act, c2t_error.InvalidDirection)
+		return
+	}
+	x, y := f.terrain.WrapXY(aox+dir.Dx(), aoy+dir.Dy())
+	fo := f.terrain.GetDoorAt(x, y)
+	if fo == nil || fo.DoorClosed {
+		arr.SetDone(act, c2t_error.ObjectNotFound)
+		return
+	}
+	if !f.canCloseDoorAt(x, y) {
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	if err := f.setDoorState(x, y, true, false); err != nil {
+		f.log.Error("%v %v %v", f, ao, err)
+		arr.SetDone(act, c2t_error.ActionCanceled)
+		return
+	}
+	ao.GetFieldObjActStat().Inc(fo.ActType)
+	arr.SetDone(act, c2t_error.None)
+}
+
+// addDoorNoise noise of door open, close
+func (f *Floor) addDoorNoise(ao gamei.ActiveObjectI, aox, aoy int, dir way9type.Way9Type) {
+	x, y := f.terrain.WrapXY(aox+dir.Dx(), aoy+dir.Dy())
+	f.addNoise(noisetype.Door, x, y, noisetype.Door.Radius(), ao)
+}
+
+// adminDoorCmd LockDoor UnlockDoor OpenDoor CloseDoor
+// arg "x y" or empty for doors near ao
+func (f *Floor) adminDoorCmd(ao gamei.ActiveObjectI, cmd, arg string) error {
+	var posList [][2]int
+	if arg == "" {
+		aox, aoy, exist := f.aoPosMan.GetXYByUUID(ao.GetUUID())
+		if !exist {
+			return fmt.Errorf("ao not in floor %v", ao)
+		}
+		for dir := way9type.Way9Type(1); dir < way9type.Way9Type_Count; dir++ {
+			x, y := f.terrain.WrapXY(aox+dir.Dx(), aoy+dir.Dy())
+			if f.terrain.GetDoorAt(x, y) != nil {
+				posList = append(posList, [2]int{x, y})
+			}
+		}
+	} else {
+		var x, y int
+		if _, err := fmt.Sscanf(arg, "%d %d", &x, &y); err != nil {
+			return fmt.Errorf("invalid arg %v %v", arg, err)
+		}
+		x, y = f.terrain.WrapXY(x, y)
+		posList = append(posList, [2]int{x, y})
+	}
+	if len(posList) == 0 {
+		return fmt.Errorf("no door near %v", ao)
+	}
+	for _, pos := range posList {
+		fo := f.terrain.GetDoorAt(pos[0], pos[1])
+		if fo == nil {
+			return fmt.Errorf("no door at %v", pos)
+		}
+		closed, locked := fo.DoorClosed, fo.DoorLocked
+		switch cmd {
+		default:
+			return fmt.Errorf("unknown door cmd %v", cmd)
+		case "LockDoor":
+			closed, locked = true, true
+		case "UnlockDoor":
+			locked = false
+		case "OpenDoor":
+			closed, locked = false, false
+		case "CloseDoor":
+			closed = true
+		}
+		if closed && !fo.DoorClosed && !f.canCloseDoorAt(pos[0], pos[1]) {
+			return fmt.Errorf("door blocked at %v", pos)
+		}
+		if err := f.setDoorState(pos[0], pos[1], closed, locked); err != nil {
+			return err
+		}
+		if locked && !f.hasDoorKey(fo.ID) {
+			if err := f.placeDoorKey(fo); err != nil {
+				return fmt.Errorf("%v, door locked at %v", err, pos)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/game/activeobject"
+	"github.com/kasworld/goguelike/game/cmd2floor"
+	"github.com/kasworld/goguelike/game/gamei"
+)
+
+// newDoorTestFloor not locked door at 8,5
+func newDoorTestFloor(t *testing.T) *Floor {
+	f := New(1, []string{
+		"NewTerrain w=32 h=32 name=DoorTest",
+		"ResourceFillRect resource=Soil amount=64 x=0 y=0 w=32 h=32",
+		"TileAt tile=Door x=8 y=5",
+		"FinalizeTerrain",
+		"AddDoor x=8 y=5 display=Door closed=false locked=false message=Door",
+	}, &testTower{})
+	if err := f.Init(); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func keyListAt(f *Floor, x, y int) []string {
+	var rtn []string
+	for _, v := range f.poPosMan.GetObjListAt(x, y) {
+		if key, ok := v.(gamei.KeyI); ok {
+			rtn = append(rtn, key.GetKeyID())
+		}
+	}
+	return rtn
+}
+
+func keyCount(f *Floor) int {
+	count := 0
+	for _, v := range f.poPosMan.GetAllList() {
+		if _, ok := v.(gamei.KeyI); ok {
+			count++
+		}
+	}
+	return count
+}
+
+func TestAdminLockDoorPlaceKey(t *testing.T) {
+	f := newDoorTestFloor(t)
+	defer f.Cleanup()
+	fo := f.terrain.GetDoorAt(8, 5)
+	if fo == nil {
+		t.Fatal("no door")
+	}
+	if keyCount(f) != 0 {
+		t.Fatalf("key of not locked door placed")
+	}
+	for i := 0; i < 2; i++ {
+		if err := f.adminDoorCmd(nil, "LockDoor", "8 5"); err != nil {
+			t.Fatal(err)
+		}
+		if !fo.DoorLocked || keyCount(f) != 1 || !f.hasDoorKey(fo.ID) {
+			t.Fatalf("lock %v key count %v", i, keyCount(f))
+		}
+	}
+
+	// key in ao, not placed again
+	f.removeDoorKeys()
+	ao := activeobject.NewReplayActiveObj("ao", "ao", f, f.log)
+	if err := f.aoPosMan.AddToXY(ao, 5, 5); err != nil {
+		t.Fatal(err)
+	}
+	ao.GetInven().AddKey(fo.ID)
+	if err := f.adminDoorCmd(nil, "LockDoor", "8 5"); err != nil {
+		t.Fatal(err)
+	}
+	if keyCount(f) != 0 {
+		t.Errorf("key placed while ao has key")
+	}
+}
+
+func TestLeaveFloorDropKey(t *testing.T) {
+	f := newDoorTestFloor(t)
+	defer f.Cleanup()
+	fo := f.terrain.GetDoorAt(8, 5)
+	ao := activeobject.NewReplayActiveObj("ao", "ao", f, f.log)
+	if err := f.aoPosMan.AddToXY(ao, 5, 5); err != nil {
+		t.Fatal(err)
+	}
+	ao.Noti_EnterFloor(f)
+	ao.GetInven().AddKey(fo.ID)
+
+	f.processCmd2Floor(&cmd2floor.ReqLeaveFloor{ActiveObj: ao})
+	if ao.GetInven().HasKey(fo.ID) {
+		t.Errorf("key carried out of floor")
+	}
+	if got := keyListAt(f, 5, 5); len(got) != 1 || got[0] != fo.ID {
+		t.Errorf("key not dropped %v", got)
+	}
+}
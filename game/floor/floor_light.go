@@ -0,0 +1,124 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/config/viewportdata"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/terrain/lightmap"
+)
+
+// aoLight light carried by ao in turn
+type aoLight struct {
+	X, Y   int
+	Radius float64
+}
+
+// prepareLight make ao light list and clear lit sight cache, call every turn
+func (f *Floor) prepareLight(aoList []gamei.ActiveObjectI) {
+	f.litSightCache = make(map[[2]int]*viewportdata.ViewportSight2)
+	lastLightList := f.aoLightList
+	f.aoLightList = nil
+	if f.terrain.GetLightMap() != nil {
+		f.aoLightList = f.makeAOLightList(aoList)
+	}
+	f.changedLightList = append(
+		diffLightList(f.aoLightList, lastLightList),
+		diffLightList(lastLightList, f.aoLightList)...)
+}
+
+func (f *Floor) makeAOLightList(aoList []gamei.ActiveObjectI) []aoLight {
+	var rtn []aoLight
+	for _, ao := range aoList {
+		if !ao.IsAlive() {
+			continue
+		}
+		r := ao.GetLightRadius()
+		if r <= 0 {
+			continue
+		}
+		aox, aoy, exist := f.aoPosMan.GetXYByUUID(ao.GetUUID())
+		if !exist {
+			continue
+		}
+		rtn = append(rtn, aoLight{aox, aoy, r})
+	}
+	return rtn
+}
+
+// diffLightList light in src not in dst
+func diffLightList(src, dst []aoLight) []aoLight {
+	var rtn []aoLight
+loop:
+	for _, v := range src {
+		for _, w := range dst {
+			if v == w {
+				continue loop
+			}
+		}
+		rtn = append(rtn, v)
+	}
+	return rtn
+}
+
+// isNearChangedLight lit tiles in sight of x,y may changed by moving light
+func (f *Floor) isNearChangedLight(x, y int, sight float64) bool {
+	for _, v := range f.changedLightList {
+		dx, dy := way9type.CalcDxDyWrapped(x-v.X, y-v.Y, f.w, f.h)
+		r := v.Radius + sight
+		if float64(dx*dx+dy*dy) <= r*r {
+			return true
+		}
+	}
+	return false
+}
+
+// isLit by terrain lightmap or ao light
+func (f *Floor) isLit(lm lightmap.LightMap, x, y int) bool {
+	if lm[x][y] >= gameconst.LightSeeMin {
+		return true
+	}
+	for _, v := range f.aoLightList {
+		dx, dy := way9type.CalcDxDyWrapped(x-v.X, y-v.Y, f.w, f.h)
+		if lightmap.Level(dx, dy, v.Radius) >= gameconst.LightSeeMin {
+			return true
+		}
+	}
+	return false
+}
+
+// getSightMat sight line of viewportcache, unlit tile blocked
+// tile in LightDarkSight always seen
+func (f *Floor) getSightMat(x, y int) *viewportdata.ViewportSight2 {
+	sightMat := f.terrain.GetViewportCache().GetByCache(x, y)
+	lm := f.terrain.GetLightMap()
+	if lm == nil {
+		return sightMat
+	}
+	if rtn, exist := f.litSightCache[[2]int{x, y}]; exist {
+		return rtn
+	}
+	rtn := *sightMat
+	for i, v := range viewportdata.ViewportXYLenList {
+		if v.L <= gameconst.LightDarkSight {
+			continue
+		}
+		fx, fy := f.terrain.WrapXY(x+v.X, y+v.Y)
+		if !f.isLit(lm, fx, fy) {
+			rtn[i] = gameconst.SightXray
+		}
+	}
+	f.litSightCache[[2]int{x, y}] = &rtn
+	return &rtn
+}
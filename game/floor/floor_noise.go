@@ -0,0 +1,140 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/config/viewportdata"
+	"github.com/kasworld/goguelike/enum/condition"
+	"github.com/kasworld/goguelike/enum/noisetype"
+	"github.com/kasworld/goguelike/enum/tile"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/noise"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+func (f *Floor) addNoise(nt noisetype.NoiseType, x, y int, radius float64, srcAO gamei.ActiveObjectI) {
+	if radius <= 0 {
+		return
+	}
+	ns := noise.Noise{
+		NoiseType: nt,
+		X:         x,
+		Y:         y,
+		Radius:    radius,
+	}
+	if srcAO != nil {
+		ns.SrcUUID = srcAO.GetUUID()
+		ns.Hidden = srcAO.GetTurnData().Condition.TestByCondition(condition.Invisible)
+	}
+	f.noiseList = append(f.noiseList, ns)
+}
+
+// addActNoise add noise by ao act done in turn
+func (f *Floor) addActNoise(
+	aoListToProcessInTurn []gamei.ActiveObjectI,
+	ao2ActReqRsp map[gamei.ActiveObjectI]*aoactreqrsp.ActReqRsp) {
+
+	tiles := f.terrain.GetTiles()
+	for _, ao := range aoListToProcessInTurn {
+		arr, exist := ao2ActReqRsp[ao]
+		if !exist || arr.Error != c2t_error.None {
+			continue
+		}
+		aox, aoy, exist := f.aoPosMan.GetXYByUUID(ao.GetUUID())
+		if !exist {
+			continue
+		}
+		switch arr.Done.Act {
+		case c2t_idcmd.Attack, c2t_idcmd.AttackWide, c2t_idcmd.AttackLong, c2t_idcmd.Shoot:
+			f.addNoise(noisetype.Attack, aox, aoy, noisetype.Attack.Radius(), ao)
+		case c2t_idcmd.OpenDoor, c2t_idcmd.CloseDoor:
+			f.addDoorNoise(ao, aox, aoy, arr.Done.Dir)
+		case c2t_idcmd.Move:
+			if ao.GetTurnData().Condition.TestByCondition(condition.Float) {
+				continue // not touch tile
+			}
+			nt := noisetype.Move
+			if tiles[aox][aoy].TestByTile(tile.Door) {
+				nt = noisetype.Door
+			}
+			f.addNoise(nt, aox, aoy, tiles[aox][aoy].MoveNoise(), ao)
+		}
+	}
+}
+
+// processNoise set heard noise to alive ao, send noti
+// skip noise made by self or source in sight
+func (f *Floor) processNoise(
+	aoListToProcessInTurn []gamei.ActiveObjectI,
+	aoMapSkipTurn map[string]bool) {
+
+	tiles := f.terrain.GetTiles()
+	for _, ao := range aoListToProcessInTurn {
+		if _, exist := aoMapSkipTurn[ao.GetUUID()]; exist || !ao.IsAlive() {
+			ao.SetHeardNoiseList(nil)
+			continue
+		}
+		aox, aoy, exist := f.aoPosMan.GetXYByUUID(ao.GetUUID())
+		if !exist || len(f.noiseList) == 0 {
+			ao.SetHeardNoiseList(nil)
+			continue
+		}
+		sight := float32(ao.GetTurnData().Sight)
+		sightMat := f.getSightMat(aox, aoy)
+		var heardList []noise.Heard
+		for _, ns := range f.noiseList {
+			if ns.SrcUUID == ao.GetUUID() {
+				continue
+			}
+			level := ns.LevelAt(tiles, aox, aoy)
+			if level <= 0 {
+				continue
+			}
+			if !ns.Hidden {
+				dx, dy := f.terrain.WrapXY(ns.X-aox, ns.Y-aoy)
+				if dx > f.w/2 {
+					dx -= f.w
+				}
+				if dy > f.h/2 {
+					dy -= f.h
+				}
+				if i, exist := viewportdata.ViewportXY2Index[[2]int{dx, dy}]; exist && sightMat[i] <= sight {
+					continue // source in sight
+				}
+			}
+			heardList = append(heardList, noise.Heard{
+				NoiseType: ns.NoiseType,
+				X:         ns.X,
+				Y:         ns.Y,
+				Level:     level,
+			})
+		}
+		heardList = noise.SortHeard(heardList, gameconst.NoiseHeardMax)
+		ao.SetHeardNoiseList(heardList)
+		if len(heardList) == 0 {
+			continue
+		}
+		if aoconn := ao.GetClientConn(); aoconn != nil {
+			if err := aoconn.SendNotiPacket(
+				c2t_idnoti.HeardNoise,
+				&c2t_obj.NotiHeardNoise_data{NoiseList: heardList},
+			); err != nil {
+				f.log.Error("%v %v %v", f, ao, err)
+			}
+		}
+	}
+}
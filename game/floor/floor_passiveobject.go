@@ -14,6 +14,8 @@ package floor
 import (
 	"fmt"
 
+	"github.com/kasworld/goguelike/config/lootdata"
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
 	"github.com/kasworld/goguelike/enum/potiontype"
 	"github.com/kasworld/goguelike/enum/scrolltype"
 	"github.com/kasworld/goguelike/game/carryingobject"
@@ -25,37 +27,102 @@ func (f *Floor) canCarryObjPlaceAt(x, y int) bool {
 }
 
 func (f *Floor) addNewRandCarryObj2Floor() error {
-	var obj gamei.CarryingObjectI
-	switch f.rnd.Intn(4) {
+	obj := f.makeRandCarryObj()
+	if obj == nil {
+		return nil
+	}
+	for try := 5; try > 0; try-- {
+		x, y := f.rnd.Intn(f.w), f.rnd.Intn(f.h)
+		if f.canCarryObjPlaceAt(x, y) {
+			return f.placeCarryObj2FloorAt(x, y, obj)
+		}
+	}
+	return fmt.Errorf("fail to addNewRandCarryObj2Floor")
+}
+
+func (f *Floor) placeCarryObj2FloorAt(x, y int, po gamei.CarryingObjectI) error {
+	po.SetRemainTurnInFloor()
+	return f.poPosMan.AddToXY(po, x, y)
+}
+
+// makeRandCarryObj by terrain LootTable if exist
+func (f *Floor) makeRandCarryObj() gamei.CarryingObjectI {
+	if lt := f.terrain.GetLootTable(); lt != nil {
+		return f.makeLootTableCarryObj(lt)
+	}
+	switch f.rnd.Intn(5) {
 	case 0:
-		obj = carryingobject.NewRandFactionEquipObj(f.GetName(), f.GetEnvBias().NearFaction(), f.rnd)
+		return carryingobject.NewRandFactionEquipObj(f.GetName(), f.GetEnvBias().NearFaction(), f.rnd)
 	case 1:
 		n := f.rnd.Intn(potiontype.TotalPotionMakeRate)
-		obj = carryingobject.NewPotionByMakeRate(n)
+		return carryingobject.NewPotionByMakeRate(n)
 
 	case 2:
 		n := f.rnd.Intn(scrolltype.TotalScrollMakeRate)
-		obj = carryingobject.NewScrollByMakeRate(n)
+		return carryingobject.NewScrollByMakeRate(n)
 
 	case 3:
 		v := f.rnd.NormFloat64Range(100, 50)
 		if v < 1 {
 			v = 1
 		}
-		obj = carryingobject.NewMoney(v)
-	default:
-		return nil
+		return carryingobject.NewMoney(v)
+
+	case 4:
+		return carryingobject.NewAmmo(1 + f.rnd.Intn(10))
 	}
-	for try := 5; try > 0; try-- {
-		x, y := f.rnd.Intn(f.w), f.rnd.Intn(f.h)
-		if f.canCarryObjPlaceAt(x, y) {
-			return f.placeCarryObj2FloorAt(x, y, obj)
+	return nil
+}
+
+func (f *Floor) makeLootTableCarryObj(lt *lootdata.LootTable) gamei.CarryingObjectI {
+	switch lt.TypeByWeight(f.rnd.Intn(lt.TypeTotal)) {
+	case carryingobjecttype.Equip:
+		eqt := lt.EquipByWeight(f.rnd.Intn(lt.EquipTotal))
+		return carryingobject.NewEquipByFactionSlot(f.GetName(), f.GetEnvBias().NearFaction(), eqt, f.rnd)
+	case carryingobjecttype.Potion:
+		return carryingobject.NewPotion(lt.PotionByWeight(f.rnd.Intn(lt.PotionTotal)))
+	case carryingobjecttype.Scroll:
+		return carryingobject.NewScroll(lt.ScrollByWeight(f.rnd.Intn(lt.ScrollTotal)))
+	case carryingobjecttype.Money:
+		depthRate := lt.DepthRate(f.getDepth())
+		v := f.rnd.NormFloat64Range(lt.MoneyMean*depthRate, lt.MoneyStdDev*depthRate)
+		if v < 1 {
+			v = 1
 		}
+		return carryingobject.NewMoney(v)
+	case carryingobjecttype.Ammo:
+		return carryingobject.NewAmmo(1 + f.rnd.Intn(10))
 	}
-	return fmt.Errorf("fail to addNewRandCarryObj2Floor")
+	return nil
 }
 
-func (f *Floor) placeCarryObj2FloorAt(x, y int, po gamei.CarryingObjectI) error {
-	po.SetRemainTurnInFloor()
-	return f.poPosMan.AddToXY(po, x, y)
+// dropLootTableCarryObj extra carryobj of LootTable on ao death
+func (f *Floor) dropLootTableCarryObj(ao gamei.ActiveObjectI, aox, aoy int) {
+	lt := f.terrain.GetLootTable()
+	if lt == nil || lt.DeathDrop <= 0 {
+		return
+	}
+	mean := lt.DeathDrop * lt.DepthRate(f.getDepth())
+	count := int(mean)
+	if f.rnd.Float64() < mean-float64(count) {
+		count++
+	}
+	for i := 0; i < count; i++ {
+		obj := f.makeLootTableCarryObj(lt)
+		if obj == nil {
+			continue
+		}
+		if err := f.placeCarryObj2FloorAt(aox, aoy, obj); err != nil {
+			f.log.TraceActiveObj("loot place fail po lost, %v %v %v", f, ao, err)
+		}
+	}
+}
+
+// getDepth index of floor in tower, 0 is top floor
+func (f *Floor) getDepth() int {
+	depth, err := f.tower.GetFloorManager().GetFloorIndexByName(f.GetName())
+	if err != nil {
+		return 0
+	}
+	return depth
 }
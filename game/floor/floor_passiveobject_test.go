@@ -0,0 +1,46 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/game/carryingobject"
+)
+
+func TestDropLootTableCarryObj(t *testing.T) {
+	f := New(1, []string{
+		"NewTerrain w=32 h=32 name=LootTest",
+		"ResourceFillRect resource=Soil amount=64 x=0 y=0 w=32 h=32",
+		"FinalizeTerrain",
+		"LootTable type=Potion equip= potion=RecoverHP10 scroll= moneymean=100 moneystddev=0 depthscale=0.5 deathdrop=2",
+	}, &testTower{depth: 2})
+	if err := f.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Cleanup()
+	before := f.poPosMan.Count()
+
+	// deathdrop 2 * depthrate 2 at depth 2
+	f.dropLootTableCarryObj(nil, 10, 10)
+	dropList := f.poPosMan.GetObjListAt(10, 10)
+	if len(dropList) != 4 || f.poPosMan.Count() != before+4 {
+		t.Fatalf("drop count %v", len(dropList))
+	}
+	for _, v := range dropList {
+		po, ok := v.(*carryingobject.Potion)
+		if !ok || po.GetPotionType() != potiontype.RecoverHP10 {
+			t.Errorf("not in loot table %v", v)
+		}
+	}
+}
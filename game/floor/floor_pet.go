@@ -0,0 +1,146 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/achievetype"
+	"github.com/kasworld/goguelike/enum/aotype"
+	"github.com/kasworld/goguelike/enum/petorder"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/cmd2tower"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+)
+
+// canHavePet not pet and pet count under max
+func canHavePet(ao gamei.ActiveObjectI) bool {
+	return ao.GetPetOwner() == nil && len(ao.GetPetList()) < gameconst.PetCountMax
+}
+
+// aoSummonPet read SummonPet scroll, pet made by tower near ao
+func (f *Floor) aoSummonPet(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp, aox, aoy int) {
+	act := aoactreqrsp.Act{Act: c2t_idcmd.ReadScroll, UUID: arr.Req.UUID}
+	if !canHavePet(ao) {
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	ao.GetInven().RemoveByUUID(arr.Req.UUID)
+	ao.GetAchieveStat().Inc(achievetype.UseCarryObj)
+	ao.GetScrollStat().Inc(scrolltype.SummonPet)
+	ao.GetIdentifyKnowledge().IdentifyScroll(scrolltype.SummonPet)
+	f.tower.GetReqCh() <- &cmd2tower.SummonPet{
+		Owner: ao,
+		Floor: f,
+		X:     aox,
+		Y:     aoy,
+	}
+	arr.SetDone(act, c2t_error.None)
+}
+
+func (f *Floor) aoActCommandPet(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp, aox, aoy int) {
+	act := aoactreqrsp.Act{
+		Act:      c2t_idcmd.CommandPet,
+		UUID:     arr.Req.UUID,
+		PetOrder: arr.Req.PetOrder,
+		Target:   arr.Req.Target,
+	}
+	if arr.Req.PetOrder == petorder.Feed {
+		f.aoFeedActiveObj(ao, arr, act, aox, aoy)
+		return
+	}
+	pet := ao.GetPetByUUID(arr.Req.UUID)
+	if pet == nil {
+		arr.SetDone(act, c2t_error.ObjectNotFound)
+		return
+	}
+	switch arr.Req.PetOrder {
+	default:
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	case petorder.Follow, petorder.Stay:
+		pet.SetPetOrder(arr.Req.PetOrder, "")
+	case petorder.Attack:
+		dst, ok := f.aoPosMan.GetByUUID(arr.Req.Target).(gamei.ActiveObjectI)
+		if !ok || !dst.IsAlive() || ao.IsPartyOf(dst) {
+			arr.SetDone(act, c2t_error.ObjectNotFound)
+			return
+		}
+		pet.SetPetOrder(petorder.Attack, arr.Req.Target)
+	case petorder.Release:
+		if err := ao.RemovePet(pet); err != nil {
+			f.log.Error("%v %v %v", f, ao, err)
+			arr.SetDone(act, c2t_error.ActionCanceled)
+			return
+		}
+	}
+	arr.SetDone(act, c2t_error.None)
+}
+
+// aoFeedActiveObj feed potion to near ao, own pet or tame try
+func (f *Floor) aoFeedActiveObj(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp,
+	act aoactreqrsp.Act, aox, aoy int) {
+
+	dst, ok := f.aoPosMan.GetByUUID(arr.Req.UUID).(gamei.ActiveObjectI)
+	if !ok || !dst.IsAlive() || dst.GetUUID() == ao.GetUUID() {
+		arr.SetDone(act, c2t_error.ObjectNotFound)
+		return
+	}
+	dstx, dsty, _ := f.aoPosMan.GetXYByUUID(dst.GetUUID())
+	if contact, _ := way9type.CalcContactDirWrappedXY(aox, aoy, dstx, dsty, f.w, f.h); !contact {
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	po, ok := ao.GetInven().GetByUUID(arr.Req.Target).(gamei.PotionI)
+	if !ok {
+		arr.SetDone(act, c2t_error.ObjectNotFound)
+		return
+	}
+	owner := dst.GetPetOwner()
+	if owner != nil && owner.GetUUID() != ao.GetUUID() {
+		// pet of other
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	if owner == nil && (!canHavePet(ao) ||
+		dst.GetActiveObjType() != aotype.System || dst.GetBoss() != nil ||
+		dst.GetTurnData().Level > ao.GetTurnData().Level) {
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+
+	ao.GetInven().RemoveByUUID(po.GetUUID())
+	ao.GetAchieveStat().Inc(achievetype.UseCarryObj)
+	if tb := potiontype.GetBuffByPotionType(po.GetPotionType()); tb != nil {
+		dst.GetBuffManager().Add(po.GetPotionType().String(), false, false, tb)
+	}
+	if owner != nil {
+		arr.SetDone(act, c2t_error.None)
+		return
+	}
+	// weak ao tamed easy
+	if f.rnd.Float64() >= gameconst.PetTameRate*(2-dst.GetHPRate()) {
+		arr.SetDone(act, c2t_error.TameFailed)
+		return
+	}
+	if err := ao.AddPet(dst); err != nil {
+		f.log.Error("%v %v %v", f, ao, err)
+		arr.SetDone(act, c2t_error.ActionCanceled)
+		return
+	}
+	dst.SetNeedTANoti()
+	arr.SetDone(act, c2t_error.None)
+}
@@ -0,0 +1,149 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/enum/petorder"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/game/activeobject"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+)
+
+func newPetTestFloor(t *testing.T) *Floor {
+	f := New(1, []string{
+		"NewTerrain w=32 h=32 name=PetTest",
+		"ResourceFillRect resource=Soil amount=64 x=0 y=0 w=32 h=32",
+		"FinalizeTerrain",
+	}, &testTower{})
+	if err := f.Init(); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func addPetTestAO(t *testing.T, f *Floor, uuid string, x, y int) *activeobject.ActiveObject {
+	ao := activeobject.NewReplayActiveObj(uuid, uuid, f, f.log)
+	if err := f.aoPosMan.AddToXY(ao, x, y); err != nil {
+		t.Fatal(err)
+	}
+	ao.Noti_EnterFloor(f)
+	ao.ApplyTurnAct() // make turndata
+	return ao
+}
+
+func commandPet(f *Floor, ao *activeobject.ActiveObject,
+	petUUID string, order petorder.PetOrder, target string) *aoactreqrsp.ActReqRsp {
+	arr := &aoactreqrsp.ActReqRsp{
+		Req: aoactreqrsp.Act{
+			Act:      c2t_idcmd.CommandPet,
+			UUID:     petUUID,
+			PetOrder: order,
+			Target:   target,
+		},
+	}
+	x, y, _ := f.aoPosMan.GetXYByUUID(ao.GetUUID())
+	f.aoActCommandPet(ao, arr, x, y)
+	return arr
+}
+
+func TestTamePet(t *testing.T) {
+	f := newPetTestFloor(t)
+	defer f.Cleanup()
+	owner := addPetTestAO(t, f, "owner", 5, 5)
+	wild := addPetTestAO(t, f, "wild", 6, 5)
+	far := addPetTestAO(t, f, "far", 10, 10)
+
+	feed := func(dst string) *aoactreqrsp.ActReqRsp {
+		po := carryingobject.NewPotion(potiontype.RecoverHP10)
+		if err := owner.GetInven().AddToBag(po); err != nil {
+			t.Fatal(err)
+		}
+		return commandPet(f, owner, dst, petorder.Feed, po.GetUUID())
+	}
+	if arr := feed(far.GetUUID()); arr.Error != c2t_error.ActionProhibited {
+		t.Errorf("tame not contact ao %+v", arr)
+	}
+	// tame rate over 0.3, fail 30 times in a row is rare
+	tamed := false
+	for i := 0; i < 30 && !tamed; i++ {
+		arr := feed(wild.GetUUID())
+		switch arr.Error {
+		case c2t_error.None:
+			tamed = true
+		case c2t_error.TameFailed:
+		default:
+			t.Fatalf("tame %+v", arr)
+		}
+	}
+	if !tamed || wild.GetPetOwner() != owner || owner.GetPetByUUID(wild.GetUUID()) == nil {
+		t.Fatalf("not tamed %v", wild.GetPetOwner())
+	}
+
+	other := addPetTestAO(t, f, "other", 7, 5)
+	po := carryingobject.NewPotion(potiontype.RecoverHP10)
+	if err := other.GetInven().AddToBag(po); err != nil {
+		t.Fatal(err)
+	}
+	if arr := commandPet(f, other, wild.GetUUID(), petorder.Feed, po.GetUUID()); arr.Error != c2t_error.ActionProhibited {
+		t.Errorf("feed pet of other %+v", arr)
+	}
+}
+
+func TestCommandPet(t *testing.T) {
+	f := newPetTestFloor(t)
+	defer f.Cleanup()
+	owner := addPetTestAO(t, f, "owner", 5, 5)
+	pet := addPetTestAO(t, f, "pet", 6, 5)
+	pet2 := addPetTestAO(t, f, "pet2", 4, 5)
+	enemy := addPetTestAO(t, f, "enemy", 10, 10)
+	for _, v := range []*activeobject.ActiveObject{pet, pet2} {
+		if err := owner.AddPet(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if arr := commandPet(f, owner, enemy.GetUUID(), petorder.Stay, ""); arr.Error != c2t_error.ObjectNotFound {
+		t.Errorf("command not pet %+v", arr)
+	}
+	if arr := commandPet(f, owner, pet.GetUUID(), petorder.Stay, ""); arr.Error != c2t_error.None {
+		t.Errorf("stay %+v", arr)
+	}
+	if order, _ := pet.GetPetOrder(); order != petorder.Stay {
+		t.Errorf("order not stay %v", order)
+	}
+	if arr := commandPet(f, owner, pet.GetUUID(), petorder.Attack, pet2.GetUUID()); arr.Error != c2t_error.ObjectNotFound {
+		t.Errorf("attack party %+v", arr)
+	}
+	if arr := commandPet(f, owner, pet.GetUUID(), petorder.Attack, enemy.GetUUID()); arr.Error != c2t_error.None {
+		t.Errorf("attack %+v", arr)
+	}
+	if order, target := pet.GetPetOrder(); order != petorder.Attack || target != enemy.GetUUID() {
+		t.Errorf("order not attack %v %v", order, target)
+	}
+	if arr := commandPet(f, owner, pet.GetUUID(), petorder.Follow, ""); arr.Error != c2t_error.None {
+		t.Errorf("follow %+v", arr)
+	}
+	if order, target := pet.GetPetOrder(); order != petorder.Follow || target != "" {
+		t.Errorf("order not follow %v %v", order, target)
+	}
+	if arr := commandPet(f, owner, pet.GetUUID(), petorder.Release, ""); arr.Error != c2t_error.None {
+		t.Errorf("release %+v", arr)
+	}
+	if pet.GetPetOwner() != nil || owner.GetPetByUUID(pet.GetUUID()) != nil {
+		t.Errorf("pet not released")
+	}
+}
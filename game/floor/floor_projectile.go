@@ -0,0 +1,118 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/dangertype"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/dangerobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+)
+
+// projectileBlocked projectile can not enter tile
+func (f *Floor) projectileBlocked(x, y int) bool {
+	tl := f.terrain.GetTiles()[x][y]
+	return !tl.CharPlaceable() || tl.NoBattle()
+}
+
+// projectileTargetAt return first alive ao at x,y except owner
+func (f *Floor) projectileTargetAt(do *dangerobject.DangerObject, x, y int) gamei.ActiveObjectI {
+	for _, ao := range f.getAOListAtInOrder(x, y) {
+		if ao.IsAlive() && ao.GetUUID() != do.Owner.GetUUID() {
+			return ao
+		}
+	}
+	return nil
+}
+
+func (f *Floor) addShoot(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp) {
+	aox, aoy, atkdir := f.checkAttackSrc(ao, arr)
+	if arr.Acted() {
+		return
+	}
+	if ao.GetInven().GetAmmoCount() < 1 {
+		arr.SetDone(aoactreqrsp.Act{Act: c2t_idcmd.Shoot, Dir: atkdir},
+			c2t_error.InsufficientAmmo)
+		return
+	}
+	dstX, dstY := f.terrain.WrapXY(aox+atkdir.Dx(), aoy+atkdir.Dy())
+	if f.projectileBlocked(dstX, dstY) {
+		arr.SetDone(aoactreqrsp.Act{Act: c2t_idcmd.Shoot, Dir: atkdir},
+			c2t_error.ActionProhibited)
+		return
+	}
+	if err := ao.GetInven().UseAmmo(1); err != nil {
+		arr.SetDone(aoactreqrsp.Act{Act: c2t_idcmd.Shoot, Dir: atkdir},
+			c2t_error.InsufficientAmmo)
+		return
+	}
+	// first tile affect in this turn, move from next turn
+	if err := f.addDangerObj(
+		dangerobject.NewAOProjectile(ao, aox, aoy, atkdir,
+			gameconst.ProjectileSpeed, gameconst.ProjectileRange-1),
+		dstX, dstY); err != nil {
+		f.log.Fatal("fail to AddToXY %v", err)
+		arr.SetDone(aoactreqrsp.Act{Act: c2t_idcmd.Shoot, Dir: atkdir},
+			c2t_error.ActionCanceled)
+		return
+	}
+	arr.SetDone(
+		aoactreqrsp.Act{Act: c2t_idcmd.Shoot, Dir: atkdir},
+		c2t_error.None)
+}
+
+// moveProjectile move projectile Speed tile to Dir
+// stop before blocked tile or at first ao in path
+func (f *Floor) moveProjectile() {
+	for _, v := range f.getDangerObjListInOrder() {
+		do, x, y := v.DO, v.X, v.Y
+		if do.DangerType != dangertype.Projectile {
+			continue
+		}
+		for i := 0; i < do.Speed && do.RemainRange > 0; i++ {
+			nextX, nextY := f.terrain.WrapXY(x+do.Dir.Dx(), y+do.Dir.Dy())
+			if f.projectileBlocked(nextX, nextY) {
+				do.RemainRange = 0
+				break
+			}
+			x, y = nextX, nextY
+			do.RemainRange--
+			if f.projectileTargetAt(do, x, y) != nil {
+				break
+			}
+		}
+		if err := f.doPosMan.UpdateToXY(do, x, y); err != nil {
+			f.log.Fatal("fail to UpdateToXY %v", err)
+		}
+	}
+}
+
+// projectileAttack hit only first ao, owner not in floor make no damage
+// return true if hit dstao
+func (f *Floor) projectileAttack(do *dangerobject.DangerObject, dstao gamei.ActiveObjectI, dstx, dsty int) bool {
+	if do.Hit || dstao.GetUUID() == do.Owner.GetUUID() {
+		return false
+	}
+	do.Hit = true
+	// owner by uuid, restored projectile has only uuid of owner
+	owner, ok := f.aoPosMan.GetByUUID(do.Owner.GetUUID()).(gamei.ActiveObjectI)
+	if !ok {
+		return true
+	}
+	srcTile := f.terrain.GetTiles()[do.OwnerX][do.OwnerY]
+	dstTile := f.terrain.GetTiles()[dstx][dsty]
+	f.aoAttackActiveObj(owner, dstao, srcTile, dstTile)
+	return true
+}
@@ -0,0 +1,104 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/config/questdata"
+	"github.com/kasworld/goguelike/enum/aotype"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+// giverQuestList quest of QuestGiver fieldobj at pos and near npc(system ao)
+func (f *Floor) giverQuestList(aox, aoy int) []*questdata.Quest {
+	atGiver := false
+	fo, ok := f.foPosMan.Get1stObjAt(aox, aoy).(*fieldobject.FieldObject)
+	if ok && fo.ActType == fieldobjacttype.QuestGiver {
+		atGiver = true
+	}
+	var nearNPC [factiontype.FactionType_Count]bool
+	for dir := way9type.Way9Type(1); dir < way9type.Way9Type_Count; dir++ {
+		x, y := f.terrain.WrapXY(aox+dir.Dx(), aoy+dir.Dy())
+		for _, v := range f.aoPosMan.GetObjListAt(x, y) {
+			npc := v.(gamei.ActiveObjectI)
+			if npc.GetActiveObjType() == aotype.System && npc.IsAlive() {
+				nearNPC[npc.GetBias().NearFaction()] = true
+			}
+		}
+	}
+	var rtn []*questdata.Quest
+	for _, q := range f.tower.GetQuestList() {
+		if q.GiverFloor != "" {
+			if atGiver && q.GiverFloor == f.GetName() {
+				rtn = append(rtn, q)
+			}
+		} else if nearNPC[q.GiverFaction] {
+			rtn = append(rtn, q)
+		}
+	}
+	return rtn
+}
+
+// Call_APIListQuest offer list empty if not at giver
+func (f *Floor) Call_APIListQuest(ao gamei.ActiveObjectI) (*c2t_obj.RspListQuest_data, c2t_error.ErrorCode) {
+	aox, aoy, exist := f.aoPosMan.GetXYByUUID(ao.GetUUID())
+	if !exist {
+		f.log.Warn("ActiveObj not in floor %v %v", f, ao)
+		return nil, c2t_error.ActionProhibited
+	}
+	rtn := &c2t_obj.RspListQuest_data{
+		QuestList: ao.ToPacket_QuestClientList(),
+	}
+	for _, q := range f.giverQuestList(aox, aoy) {
+		if qc := ao.ToPacket_QuestClient(q); !qc.Accepted {
+			rtn.OfferList = append(rtn.OfferList, qc)
+		}
+	}
+	return rtn, c2t_error.None
+}
+
+func (f *Floor) aoActAcceptQuest(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp, aox, aoy int) {
+	act := aoactreqrsp.Act{Act: c2t_idcmd.AcceptQuest, Quest: arr.Req.Quest}
+	q := questdata.FindByName(f.giverQuestList(aox, aoy), arr.Req.Quest)
+	if q == nil {
+		arr.SetDone(act, c2t_error.ObjectNotFound)
+		return
+	}
+	if err := ao.DoAcceptQuest(q); err != nil {
+		f.log.Debug("%v %v %v", f, ao, err)
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	arr.SetDone(act, c2t_error.None)
+}
+
+func (f *Floor) aoActCompleteQuest(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp, aox, aoy int) {
+	act := aoactreqrsp.Act{Act: c2t_idcmd.CompleteQuest, Quest: arr.Req.Quest}
+	q := questdata.FindByName(f.giverQuestList(aox, aoy), arr.Req.Quest)
+	if q == nil {
+		arr.SetDone(act, c2t_error.ObjectNotFound)
+		return
+	}
+	if err := ao.DoCompleteQuest(q); err != nil {
+		f.log.Debug("%v %v %v", f, ao, err)
+		arr.SetDone(act, c2t_error.QuestNotComplete)
+		return
+	}
+	arr.SetDone(act, c2t_error.None)
+}
@@ -0,0 +1,48 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/enum/condition"
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+)
+
+// aoActRepair repair equip in inventory(equipped or in bag) at Repairer
+func (f *Floor) aoActRepair(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp, aox, aoy int) {
+	act := aoactreqrsp.Act{Act: c2t_idcmd.Repair, UUID: arr.Req.UUID}
+	if ao.GetTurnData().Condition.TestByCondition(condition.Float) {
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	fo, ok := f.foPosMan.Get1stObjAt(aox, aoy).(*fieldobject.FieldObject)
+	if !ok || fo.ActType != fieldobjacttype.RepairEquip {
+		f.log.Error("not at Repairer FieldObj %v %v", f, ao)
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	eq, ok := ao.GetInven().GetByUUID(arr.Req.UUID).(gamei.EquipObjI)
+	if !ok {
+		arr.SetDone(act, c2t_error.ObjectNotFound)
+		return
+	}
+	if err := ao.DoRepairEquip(eq); err != nil {
+		f.log.Debug("%v %v %v", f, ao, err)
+		arr.SetDone(act, c2t_error.InsufficientMoney)
+		return
+	}
+	arr.SetDone(act, c2t_error.None)
+}
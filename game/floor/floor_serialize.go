@@ -14,6 +14,7 @@ package floor
 import (
 	"time"
 
+	"github.com/kasworld/goguelike/game/identify"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
 )
 
@@ -37,14 +38,15 @@ func (f *Floor) ToPacket_NotiAgeing() *c2t_obj.NotiAgeing_data {
 func (f *Floor) ToPacket_NotiObjectList(
 	turnTime time.Time,
 	cache *CacheVPIXYOList,
-	x, y int, sight float64) *c2t_obj.NotiObjectList_data {
+	x, y int, sight float64,
+	kn *identify.Knowledge) *c2t_obj.NotiObjectList_data {
 
 	x, y = f.terrain.WrapXY(x, y)
 	vpixyolists := cache.GetAtByCache(x, y)
 
-	sightMat := f.terrain.GetViewportCache().GetByCache(x, y)
+	sightMat := f.getSightMat(x, y)
 	aOs := f.makeViewportActiveObjs2(vpixyolists[0], sightMat, float32(sight))
-	pOs := f.makeViewportCarryObjs2(vpixyolists[1], sightMat, float32(sight))
+	pOs := f.makeViewportCarryObjs2(vpixyolists[1], sightMat, float32(sight), kn)
 	fOs := f.makeViewportFieldObjs2(vpixyolists[2], sightMat, float32(sight))
 	dOs := f.makeViewportDangerObjs2(vpixyolists[3], sightMat, float32(sight))
 
@@ -62,7 +64,7 @@ func (f *Floor) ToPacket_NotiTileArea(
 	x, y int, sight float64) *c2t_obj.NotiVPTiles_data {
 
 	x, y = f.terrain.WrapXY(x, y)
-	sightMat := f.terrain.GetViewportCache().GetByCache(x, y)
+	sightMat := f.getSightMat(x, y)
 	cstiles := f.makeViewportTiles2(x, y, sightMat, float32(sight))
 
 	return &c2t_obj.NotiVPTiles_data{
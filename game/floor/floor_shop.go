@@ -0,0 +1,160 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/config/craftdata"
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/enum/condition"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+// shopState carryobj for sale of Shop fieldobj
+// used in floor goroutine only
+type shopState struct {
+	remainRestock int
+	itemList      []gamei.CarryingObjectI
+}
+
+func (f *Floor) getShopState(fo *fieldobject.FieldObject) *shopState {
+	st, exist := f.foID2Shop[fo.ID]
+	if !exist {
+		st = &shopState{}
+		f.foID2Shop[fo.ID] = st
+	}
+	return st
+}
+
+// restockShop called every turn, refill stock by ShopRestockTurn
+func (f *Floor) restockShop(fo *fieldobject.FieldObject) {
+	st := f.getShopState(fo)
+	st.remainRestock--
+	if st.remainRestock > 0 {
+		return
+	}
+	st.remainRestock = fo.ShopRestockTurn
+	st.itemList = st.itemList[:0]
+	for _, mt := range fo.ShopStock {
+		for i := 0; i < mt.Count; i++ {
+			st.itemList = append(st.itemList, f.makeShopItem(mt))
+		}
+	}
+}
+
+func (f *Floor) makeShopItem(mt craftdata.Material) gamei.CarryingObjectI {
+	return f.makeCarryObjByMaterial(mt, f.GetName(), f.GetEnvBias().NearFaction())
+}
+
+// makeCarryObjByMaterial equip made with eqName, eqFaction
+func (f *Floor) makeCarryObjByMaterial(mt craftdata.Material,
+	eqName string, eqFaction factiontype.FactionType) gamei.CarryingObjectI {
+	switch mt.CarryingObjectType {
+	default:
+		f.log.Fatal("not supported material %v", mt)
+		return nil
+	case carryingobjecttype.Equip:
+		return carryingobject.NewRandFactionEquipObj(eqName, eqFaction, f.rnd)
+	case carryingobjecttype.Potion:
+		if mt.AnyType {
+			return carryingobject.NewPotionByMakeRate(f.rnd.Intn(potiontype.TotalPotionMakeRate))
+		}
+		return carryingobject.NewPotion(mt.PotionType)
+	case carryingobjecttype.Scroll:
+		if mt.AnyType {
+			return carryingobject.NewScrollByMakeRate(f.rnd.Intn(scrolltype.TotalScrollMakeRate))
+		}
+		return carryingobject.NewScroll(mt.ScrollType)
+	}
+}
+
+// getShopAt return nil if not Shop
+func (f *Floor) getShopAt(x, y int) *fieldobject.FieldObject {
+	fo, ok := f.foPosMan.Get1stObjAt(x, y).(*fieldobject.FieldObject)
+	if !ok || fo.ActType != fieldobjacttype.Shop {
+		return nil
+	}
+	return fo
+}
+
+func (f *Floor) Call_APIListShop(ao gamei.ActiveObjectI) (*c2t_obj.RspListShop_data, c2t_error.ErrorCode) {
+	aox, aoy, exist := f.aoPosMan.GetXYByUUID(ao.GetUUID())
+	if !exist {
+		f.log.Warn("ActiveObj not in floor %v %v", f, ao)
+		return nil, c2t_error.ActionProhibited
+	}
+	fo := f.getShopAt(aox, aoy)
+	if fo == nil {
+		return nil, c2t_error.ActionProhibited
+	}
+	st := f.getShopState(fo)
+	ap := f.tower.GetAppearance()
+	kn := ao.GetIdentifyKnowledge()
+	rtn := &c2t_obj.RspListShop_data{
+		ShopID:        fo.ID,
+		RemainRestock: st.remainRestock,
+	}
+	for _, v := range st.itemList {
+		si := &c2t_obj.ShopItem{
+			UUID:  v.GetUUID(),
+			Price: v.GetValue() * fo.ShopPriceRate,
+		}
+		switch po := v.(type) {
+		case gamei.EquipObjI:
+			si.Equip = po.ToPacket_EquipClient()
+		case gamei.PotionI:
+			si.Potion = ap.MaskPotionClient(kn, po.ToPacket_PotionClient())
+		case gamei.ScrollI:
+			si.Scroll = ap.MaskScrollClient(kn, po.ToPacket_ScrollClient())
+		}
+		rtn.ItemList = append(rtn.ItemList, si)
+	}
+	return rtn, c2t_error.None
+}
+
+func (f *Floor) aoActBuy(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp, aox, aoy int) {
+	act := aoactreqrsp.Act{Act: c2t_idcmd.Buy, UUID: arr.Req.UUID}
+	if ao.GetTurnData().Condition.TestByCondition(condition.Float) {
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	fo := f.getShopAt(aox, aoy)
+	if fo == nil {
+		f.log.Error("not at Shop FieldObj %v %v", f, ao)
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	st := f.getShopState(fo)
+	for i, po := range st.itemList {
+		if po.GetUUID() != arr.Req.UUID {
+			continue
+		}
+		if err := ao.DoBuyCarryObj(po, po.GetValue()*fo.ShopPriceRate); err != nil {
+			f.log.Debug("%v %v %v", f, ao, err)
+			arr.SetDone(act, c2t_error.InsufficientMoney)
+			return
+		}
+		st.itemList = append(st.itemList[:i], st.itemList[i+1:]...)
+		arr.SetDone(act, c2t_error.None)
+		return
+	}
+	arr.SetDone(act, c2t_error.ObjectNotFound)
+}
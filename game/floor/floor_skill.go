@@ -0,0 +1,144 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/go-abs"
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/config/gamedata"
+	"github.com/kasworld/goguelike/config/skilldata"
+	"github.com/kasworld/goguelike/config/slippperydata"
+	"github.com/kasworld/goguelike/config/viewportdata"
+	"github.com/kasworld/goguelike/enum/condition"
+	"github.com/kasworld/goguelike/enum/dangertype"
+	"github.com/kasworld/goguelike/enum/skilltype"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/dangerobject"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+)
+
+func (f *Floor) addCast(ao gamei.ActiveObjectI, arr *aoactreqrsp.ActReqRsp) {
+	st := arr.Req.Skill
+	act := aoactreqrsp.Act{
+		Act:   c2t_idcmd.Cast,
+		Dir:   arr.Req.Dir,
+		Skill: st,
+		X:     arr.Req.X,
+		Y:     arr.Req.Y,
+	}
+	if ec := ao.CanCastSkill(st); ec != c2t_error.None {
+		arr.SetDone(act, ec)
+		return
+	}
+	aox, aoy, exist := f.aoPosMan.GetXYByUUID(ao.GetUUID())
+	if !exist {
+		f.log.Error("ao not in currentfloor %v %v", f, ao)
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+	sk := &gamedata.SkillList[st]
+	if sk.DangerType != dangertype.None && f.terrain.GetTileWrapped(aox, aoy).NoBattle() {
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+
+	cx, cy := aox, aoy
+	switch sk.Target {
+	case skilldata.TargetDir:
+		if ao.GetTurnData().Condition.TestByCondition(condition.Drunken) {
+			turnmod := slippperydata.Drunken[f.rnd.Intn(len(slippperydata.Drunken))]
+			act.Dir = act.Dir.TurnDir(turnmod)
+		}
+		if !act.Dir.IsValid() || act.Dir == way9type.Center {
+			arr.SetDone(act, c2t_error.InvalidDirection)
+			return
+		}
+		cx, cy = f.terrain.WrapXY(aox+act.Dir.Dx(), aoy+act.Dir.Dy())
+	case skilldata.TargetPos:
+		dx, dy := way9type.CalcDxDyWrapped(act.X-aox, act.Y-aoy, f.w, f.h)
+		if abs.Absi(dx) > sk.CastRange || abs.Absi(dy) > sk.CastRange {
+			arr.SetDone(act, c2t_error.ActionProhibited)
+			return
+		}
+		if !f.isInSightOf(ao, aox, aoy, dx, dy) {
+			// not cast through wall
+			arr.SetDone(act, c2t_error.ActionProhibited)
+			return
+		}
+		cx, cy = f.terrain.WrapXY(act.X, act.Y)
+	}
+	if sk.DangerType == dangertype.Projectile && f.projectileBlocked(cx, cy) {
+		arr.SetDone(act, c2t_error.ActionProhibited)
+		return
+	}
+
+	ao.CastSkill(st)
+	switch sk.DangerType {
+	case dangertype.None:
+		// self buff only
+	case dangertype.Projectile:
+		do := dangerobject.NewAOProjectile(ao, aox, aoy, act.Dir,
+			gameconst.ProjectileSpeed, sk.CastRange-1)
+		do.Skill = st
+		if err := f.addDangerObj(do, cx, cy); err != nil {
+			f.log.Fatal("fail to AddToXY %v", err)
+		}
+	default:
+		for _, v := range sk.Area {
+			dstX, dstY := f.terrain.WrapXY(cx+v[0], cy+v[1])
+			if f.terrain.GetTiles()[dstX][dstY].NoBattle() {
+				continue
+			}
+			if err := f.addDangerObj(
+				dangerobject.NewAOSkill(ao, st, aox, aoy),
+				dstX, dstY); err != nil {
+				f.log.Fatal("fail to AddToXY %v", err)
+			}
+		}
+	}
+	arr.SetDone(act, c2t_error.None)
+}
+
+// applySkillTargetBuff caster not affected by own skill
+// harmful buff not applied to party, faction not allowed to attack
+func (f *Floor) applySkillTargetBuff(do *dangerobject.DangerObject, dstao gamei.ActiveObjectI) {
+	if do.Skill == skilltype.None || dstao.GetUUID() == do.Owner.GetUUID() {
+		return
+	}
+	sk := &gamedata.SkillList[do.Skill]
+	if !sk.ForRecover {
+		if owner, ok := do.Owner.(gamei.ActiveObjectI); ok {
+			if owner.IsPartyOf(dstao) {
+				return
+			}
+			if f.tower.GetDiplomacy().AttackRate(
+				owner.GetBattleFaction(), dstao.GetBattleFaction()) <= 0 {
+				return
+			}
+		}
+	}
+	if tb := sk.TargetBuff; tb != nil {
+		dstao.GetBuffManager().Add(do.Skill.String(), true, true, tb)
+	}
+}
+
+// isInSightOf dx,dy from ao at aox,aoy is in sight, not blocked by wall or dark
+func (f *Floor) isInSightOf(ao gamei.ActiveObjectI, aox, aoy, dx, dy int) bool {
+	i, exist := viewportdata.ViewportXY2Index[[2]int{dx, dy}]
+	if !exist {
+		return false
+	}
+	return f.getSightMat(aox, aoy)[i] <= float32(ao.GetTurnData().Sight)
+}
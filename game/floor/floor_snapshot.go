@@ -0,0 +1,145 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/enum/dangertype"
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/game/dangerobject"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/game/towersnapshot"
+	"github.com/kasworld/goguelike/lib/uuidposman"
+)
+
+// ToSnapshot make runtime state to restore floor on tower restart
+// scope is described in towersnapshot package
+func (f *Floor) ToSnapshot() *towersnapshot.FloorSnapshot {
+	fs := &towersnapshot.FloorSnapshot{
+		Name:    f.GetName(),
+		Seed:    f.seed,
+		Script:  f.terrain.GetScript(),
+		Bias:    f.bias,
+		Terrain: f.terrain.ToSnapshot(),
+	}
+	f.poPosMan.IterAll(func(o uuidposman.UUIDPosI, x, y int) bool {
+		cs, err := carryingobject.ToSnapshot(o)
+		if err != nil {
+			f.log.Fatal("%v", err)
+			return false
+		}
+		cs.X, cs.Y = x, y
+		fs.CarryObjList = append(fs.CarryObjList, cs)
+		return false
+	})
+	f.foPosMan.IterAll(func(o uuidposman.UUIDPosI, x, y int) bool {
+		fo, ok := o.(*fieldobject.FieldObject)
+		if !ok || fo.ActType != fieldobjacttype.Shop {
+			return false
+		}
+		st, exist := f.foID2Shop[fo.ID]
+		if !exist {
+			return false
+		}
+		ss := towersnapshot.ShopSnapshot{X: x, Y: y, RemainRestock: st.remainRestock}
+		for _, po := range st.itemList {
+			cs, err := carryingobject.ToSnapshot(po)
+			if err != nil {
+				f.log.Fatal("%v", err)
+				continue
+			}
+			ss.ItemList = append(ss.ItemList, cs)
+		}
+		fs.ShopList = append(fs.ShopList, ss)
+		return false
+	})
+	for _, v := range f.getDangerObjListInOrder() {
+		do := v.DO
+		if do.DangerType != dangertype.Projectile || do.Hit || do.RemainRange <= 0 {
+			continue // del at next turn
+		}
+		fs.ProjectileList = append(fs.ProjectileList, towersnapshot.ProjectileSnapshot{
+			X:           v.X,
+			Y:           v.Y,
+			OwnerUUID:   do.Owner.GetUUID(),
+			OwnerX:      do.OwnerX,
+			OwnerY:      do.OwnerY,
+			Skill:       do.Skill,
+			Dir:         do.Dir,
+			Speed:       do.Speed,
+			RemainRange: do.RemainRange,
+		})
+	}
+	return fs
+}
+
+// snapshotOwner owner of restored projectile, ao may not entered floor yet
+type snapshotOwner string
+
+func (o snapshotOwner) GetUUID() string {
+	return string(o)
+}
+
+// RestoreSnapshot overwrite runtime state made by Init
+func (f *Floor) RestoreSnapshot(fs *towersnapshot.FloorSnapshot) error {
+	if fs.Name != f.GetName() {
+		return fmt.Errorf("floor name mismatch %v %v", f, fs.Name)
+	}
+	if err := f.terrain.RestoreSnapshot(fs.Terrain); err != nil {
+		return err
+	}
+	f.bias = fs.Bias
+	f.removeDoorKeys() // placed by Init, replaced by snapshot
+	for _, cs := range fs.CarryObjList {
+		po, err := carryingobject.NewFromSnapshot(cs)
+		if err != nil {
+			f.log.Fatal("%v", err)
+			continue
+		}
+		if !f.canCarryObjPlaceAt(cs.X, cs.Y) {
+			f.log.Warn("skip carryobj at NonCharPlaceable tile %v %v %v", f, cs.X, cs.Y)
+			continue
+		}
+		if err := f.placeCarryObj2FloorAt(cs.X, cs.Y, po); err != nil {
+			f.log.Error("fail to restore carryobj %v %v", f, err)
+		}
+	}
+	for _, ss := range fs.ShopList {
+		fo := f.getShopAt(ss.X, ss.Y)
+		if fo == nil {
+			f.log.Warn("skip shop stock, shop not found %v %v %v", f, ss.X, ss.Y)
+			continue
+		}
+		st := f.getShopState(fo)
+		st.remainRestock = ss.RemainRestock
+		st.itemList = st.itemList[:0]
+		for _, cs := range ss.ItemList {
+			po, err := carryingobject.NewFromSnapshot(cs)
+			if err != nil {
+				f.log.Fatal("%v", err)
+				continue
+			}
+			st.itemList = append(st.itemList, po)
+		}
+	}
+	for _, ps := range fs.ProjectileList {
+		do := dangerobject.NewAOProjectile(snapshotOwner(ps.OwnerUUID),
+			ps.OwnerX, ps.OwnerY, ps.Dir, ps.Speed, ps.RemainRange)
+		do.Skill = ps.Skill
+		if err := f.addDangerObj(do, ps.X, ps.Y); err != nil {
+			f.log.Error("fail to restore projectile %v %v", f, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/enum/dangertype"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/dangerobject"
+)
+
+func TestSnapshotProjectile(t *testing.T) {
+	script := []string{
+		"NewTerrain w=32 h=32 name=SnapshotTest",
+		"ResourceFillRect resource=Soil amount=64 x=0 y=0 w=32 h=32",
+		"FinalizeTerrain",
+	}
+	f := New(1, script, &testTower{})
+	if err := f.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer f.Cleanup()
+	f.addDangerObj(dangerobject.NewAOProjectile(posObj("shooter"), 5, 5, way9type.East, 2, 6), 6, 5)
+	hit := dangerobject.NewAOProjectile(posObj("shooter"), 5, 5, way9type.West, 2, 6)
+	hit.Hit = true
+	f.addDangerObj(hit, 4, 5)
+
+	rf := New(1, script, &testTower{})
+	if err := rf.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Cleanup()
+	if err := rf.RestoreSnapshot(f.ToSnapshot()); err != nil {
+		t.Fatal(err)
+	}
+	doList := rf.getDangerObjListInOrder()
+	if len(doList) != 1 {
+		t.Fatalf("restored projectile %v", doList)
+	}
+	do := doList[0].DO
+	if doList[0].X != 6 || doList[0].Y != 5 ||
+		do.DangerType != dangertype.Projectile || do.Owner.GetUUID() != "shooter" ||
+		do.Dir != way9type.East || do.Speed != 2 || do.RemainRange != 6 {
+		t.Errorf("projectile not restored %v at %v %v", do, doList[0].X, doList[0].Y)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_serveconnbyte"
+)
+
+// sendNoti2Spectator send noti of followed ao to spectators
+func (f *Floor) sendNoti2Spectator(connList []*c2t_serveconnbyte.ServeConnByte,
+	idnoti c2t_idnoti.NotiID, body interface{}) {
+	for _, conn := range connList {
+		if err := conn.SendNotiPacket(idnoti, body); err != nil {
+			f.log.Error("%v %v", f, err)
+		}
+	}
+}
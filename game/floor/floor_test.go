@@ -0,0 +1,74 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"github.com/kasworld/goguelike/config/towerconfig"
+	"github.com/kasworld/goguelike/game/bias"
+	"github.com/kasworld/goguelike/game/diplomacy"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/spectatorman"
+	"github.com/kasworld/goguelike/game/turnrecorder"
+	"github.com/kasworld/goguelike/lib/g2log"
+)
+
+// testTower provide config, log, floor depth, diplomacy, recorder to floor
+type testTower struct {
+	gamei.TowerI
+	depth     int
+	diplomacy *diplomacy.Diplomacy
+	recorder  *turnrecorder.Recorder // nil if not recording
+}
+
+func (tw *testTower) Config() *towerconfig.TowerConfig {
+	return &towerconfig.TowerConfig{ConcurrentConnections: 1, TurnPerSec: 1}
+}
+
+func (tw *testTower) Log() *g2log.LogBase {
+	return g2log.GlobalLogger
+}
+
+func (tw *testTower) GetBias() bias.Bias {
+	return bias.Bias{}
+}
+
+func (tw *testTower) GetDiplomacy() *diplomacy.Diplomacy {
+	return tw.diplomacy
+}
+
+func (tw *testTower) GetTurnRecorder() *turnrecorder.Recorder {
+	return tw.recorder
+}
+
+func (tw *testTower) GetSpectatorManager() *spectatorman.SpectatorManager {
+	return spectatorman.New()
+}
+
+func (tw *testTower) GetFloorManager() gamei.FloorManagerI {
+	return testFloorManager{depth: tw.depth}
+}
+
+type testFloorManager struct {
+	gamei.FloorManagerI
+	depth int
+}
+
+func (fm testFloorManager) GetFloorIndexByName(id string) (int, error) {
+	return fm.depth, nil
+}
+
+// posObj occupy tile in posman
+type posObj string
+
+func (o posObj) GetUUID() string {
+	return string(o)
+}
@@ -0,0 +1,242 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/config/leveldata"
+	"github.com/kasworld/goguelike/enum/tradestate"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/identify"
+	"github.com/kasworld/goguelike/game/inventory"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+	"github.com/kasworld/uuidstr"
+)
+
+func (tr *aoTrade) String() string {
+	return fmt.Sprintf("aoTrade[%v %v %v]", tr.id, tr.aoList[0], tr.aoList[1])
+}
+
+// aoTrade offer of 2 near ao, carryobj stay in inventory until exchange
+type aoTrade struct {
+	id           string
+	aoList       [2]gamei.ActiveObjectI
+	poidList     [2][]string
+	money        [2]float64
+	acceptedList [2]bool
+}
+
+func (tr *aoTrade) sideOf(ao gamei.ActiveObjectI) int {
+	if tr.aoList[0].GetUUID() == ao.GetUUID() {
+		return 0
+	}
+	return 1
+}
+
+func (f *Floor) Call_APITrade(
+	ao gamei.ActiveObjectI, reqPk interface{}) c2t_error.ErrorCode {
+
+	if f.aoPosMan.GetByUUID(ao.GetUUID()) == nil {
+		f.log.Warn("ActiveObj not in floor %v %v", f, ao)
+		return c2t_error.ActionProhibited
+	}
+	tr := f.aoUUID2Trade[ao.GetUUID()]
+	switch pk := reqPk.(type) {
+	default:
+		f.log.Fatal("unknown trade packet %v %#v", f, reqPk)
+		return c2t_error.ActionProhibited
+
+	case *c2t_obj.ReqTradePropose_data:
+		if tr != nil {
+			return c2t_error.ActionProhibited
+		}
+		dstAO, ok := f.aoPosMan.GetByUUID(pk.DstUUID).(gamei.ActiveObjectI)
+		if !ok || dstAO.GetUUID() == ao.GetUUID() {
+			return c2t_error.ObjectNotFound
+		}
+		if _, exist := f.aoUUID2Trade[dstAO.GetUUID()]; exist {
+			return c2t_error.ActionProhibited
+		}
+		if !ao.IsAlive() || !dstAO.IsAlive() {
+			return c2t_error.FailByDeath
+		}
+		if !f.isNearActiveObj(ao, dstAO) {
+			return c2t_error.ActionProhibited
+		}
+		if _, err := inventory.OfferWeight(ao.GetInven(), pk.CarryObjUUIDList, pk.Money); err != nil {
+			return c2t_error.ObjectNotFound
+		}
+		tr = &aoTrade{
+			id:     uuidstr.New(),
+			aoList: [2]gamei.ActiveObjectI{ao, dstAO},
+		}
+		tr.poidList[0] = pk.CarryObjUUIDList
+		tr.money[0] = pk.Money
+		f.aoUUID2Trade[ao.GetUUID()] = tr
+		f.aoUUID2Trade[dstAO.GetUUID()] = tr
+		f.sendTradeNoti(tr, tradestate.Proposed)
+
+	case *c2t_obj.ReqTradeAmend_data:
+		if tr == nil {
+			return c2t_error.ObjectNotFound
+		}
+		if _, err := inventory.OfferWeight(ao.GetInven(), pk.CarryObjUUIDList, pk.Money); err != nil {
+			return c2t_error.ObjectNotFound
+		}
+		side := tr.sideOf(ao)
+		tr.poidList[side] = pk.CarryObjUUIDList
+		tr.money[side] = pk.Money
+		tr.acceptedList = [2]bool{}
+		f.sendTradeNoti(tr, tradestate.Amended)
+
+	case *c2t_obj.ReqTradeAccept_data:
+		if tr == nil {
+			return c2t_error.ObjectNotFound
+		}
+		tr.acceptedList[tr.sideOf(ao)] = true
+		if !tr.acceptedList[0] || !tr.acceptedList[1] {
+			f.sendTradeNoti(tr, tradestate.Accepted)
+			return c2t_error.None
+		}
+		return f.exchangeTrade(tr)
+
+	case *c2t_obj.ReqTradeCancel_data:
+		if tr == nil {
+			return c2t_error.ObjectNotFound
+		}
+		f.endTrade(tr, tradestate.Canceled)
+	}
+	return c2t_error.None
+}
+
+// exchangeTrade validate again and exchange, trade end
+func (f *Floor) exchangeTrade(tr *aoTrade) c2t_error.ErrorCode {
+	ao0, ao1 := tr.aoList[0], tr.aoList[1]
+	if !ao0.IsAlive() || !ao1.IsAlive() {
+		f.endTrade(tr, tradestate.Canceled)
+		return c2t_error.FailByDeath
+	}
+	if !f.isNearActiveObj(ao0, ao1) {
+		f.endTrade(tr, tradestate.Canceled)
+		return c2t_error.ActionCanceled
+	}
+	var giveWeight [2]float64
+	for side, ao := range tr.aoList {
+		w, err := inventory.OfferWeight(ao.GetInven(), tr.poidList[side], tr.money[side])
+		if err != nil {
+			f.endTrade(tr, tradestate.Canceled)
+			return c2t_error.ObjectNotFound
+		}
+		giveWeight[side] = w
+	}
+	for side, ao := range tr.aoList {
+		curWeight := ao.GetInven().GetTotalWeight()
+		newWeight := curWeight - giveWeight[side] + giveWeight[1-side]
+		wLimit := leveldata.WeightLimit(int(ao.GetTurnData().Level))
+		if newWeight > wLimit && newWeight > curWeight {
+			f.endTrade(tr, tradestate.Canceled)
+			return c2t_error.ActionProhibited
+		}
+	}
+	if err := inventory.Exchange(
+		ao0.GetInven(), tr.poidList[0], tr.money[0],
+		ao1.GetInven(), tr.poidList[1], tr.money[1]); err != nil {
+		f.log.Error("%v %v %v", f, tr, err)
+		f.endTrade(tr, tradestate.Canceled)
+		return c2t_error.ActionCanceled
+	}
+	f.endTrade(tr, tradestate.Done)
+	return c2t_error.None
+}
+
+func (f *Floor) endTrade(tr *aoTrade, st tradestate.TradeState) {
+	f.sendTradeNoti(tr, st)
+	for _, ao := range tr.aoList {
+		delete(f.aoUUID2Trade, ao.GetUUID())
+	}
+}
+
+// cancelTradeOf cancel trade of ao leaving floor
+func (f *Floor) cancelTradeOf(ao gamei.ActiveObjectI) {
+	if tr, exist := f.aoUUID2Trade[ao.GetUUID()]; exist {
+		f.endTrade(tr, tradestate.Canceled)
+	}
+}
+
+func (f *Floor) isNearActiveObj(ao1, ao2 gamei.ActiveObjectI) bool {
+	x1, y1, exist := f.aoPosMan.GetXYByUUID(ao1.GetUUID())
+	if !exist {
+		return false
+	}
+	x2, y2, exist := f.aoPosMan.GetXYByUUID(ao2.GetUUID())
+	if !exist {
+		return false
+	}
+	for dir := way9type.Way9Type(1); dir < way9type.Way9Type_Count; dir++ {
+		x, y := f.terrain.WrapXY(x1+dir.Dx(), y1+dir.Dy())
+		if x == x2 && y == y2 {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Floor) sendTradeNoti(tr *aoTrade, st tradestate.TradeState) {
+	ap := f.tower.GetAppearance()
+	for side, ao := range tr.aoList {
+		aoconn := ao.GetClientConn()
+		if aoconn == nil {
+			continue
+		}
+		if err := aoconn.SendNotiPacket(
+			c2t_idnoti.TradeState,
+			&c2t_obj.NotiTradeState_data{
+				TradeID: tr.id,
+				State:   st,
+				Self:    tr.toPacket_TradeOffer(side, ao, ap),
+				Other:   tr.toPacket_TradeOffer(1-side, ao, ap),
+			},
+		); err != nil {
+			f.log.Error("%v %v %v", f, ao, err)
+		}
+	}
+}
+
+// toPacket_TradeOffer offer of side, potion, scroll masked by knowledge of viewer
+func (tr *aoTrade) toPacket_TradeOffer(side int,
+	viewer gamei.ActiveObjectI, ap *identify.Appearance) *c2t_obj.TradeOffer {
+	ao := tr.aoList[side]
+	kn := viewer.GetIdentifyKnowledge()
+	rtn := &c2t_obj.TradeOffer{
+		ActiveObjUUID: ao.GetUUID(),
+		NickName:      ao.GetNickName(),
+		Money:         tr.money[side],
+		Accepted:      tr.acceptedList[side],
+	}
+	for _, poid := range tr.poidList[side] {
+		switch po := ao.GetInven().GetByUUID(poid).(type) {
+		case gamei.EquipObjI:
+			rtn.EquipList = append(rtn.EquipList, po.ToPacket_EquipClient())
+		case gamei.PotionI:
+			rtn.PotionList = append(rtn.PotionList,
+				ap.MaskPotionClient(kn, po.ToPacket_PotionClient()))
+		case gamei.ScrollI:
+			rtn.ScrollList = append(rtn.ScrollList,
+				ap.MaskScrollClient(kn, po.ToPacket_ScrollClient()))
+		}
+	}
+	return rtn
+}
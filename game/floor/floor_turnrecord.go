@@ -0,0 +1,165 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kasworld/g2rand"
+	"github.com/kasworld/goguelike/game/activeobject"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/turnrecorder"
+	"github.com/kasworld/goguelike/lib/uuidposman"
+)
+
+// beginTurnRecord reseed rnd and make record if recording or replaying
+// return nil if not
+func (f *Floor) beginTurnRecord(
+	turnTime time.Time,
+	aoListToProcessInTurn []gamei.ActiveObjectI,
+	ao2ActReqRsp map[gamei.ActiveObjectI]*aoactreqrsp.ActReqRsp,
+) *turnrecorder.TurnRecord {
+
+	var turnSeed int64
+	if f.replayRecord != nil {
+		turnSeed = f.replayRecord.TurnSeed
+	} else if f.tower.GetTurnRecorder() != nil {
+		turnSeed = f.rnd.Int63()
+	} else {
+		return nil
+	}
+	f.rnd = g2rand.NewWithSeed(turnSeed)
+
+	tr := &turnrecorder.TurnRecord{
+		FloorName: f.GetName(),
+		TurnTime:  turnTime,
+		TurnSeed:  turnSeed,
+		AOList:    make([]turnrecorder.AOStartState, 0, len(aoListToProcessInTurn)),
+		ActList:   make([]turnrecorder.ActRecord, 0, len(ao2ActReqRsp)),
+	}
+	for _, ao := range aoListToProcessInTurn {
+		tr.AOList = append(tr.AOList, turnrecorder.AOStartState{
+			AOState: f.makeAOState(ao),
+			Detail:  ao.ToReplayDetail(),
+		})
+		if arr, exist := ao2ActReqRsp[ao]; exist {
+			tr.ActList = append(tr.ActList, turnrecorder.ActRecord{
+				AOUUID: ao.GetUUID(),
+				Act:    arr.Req,
+			})
+		}
+	}
+	return tr
+}
+
+// endTurnRecord write record or keep replay result
+func (f *Floor) endTurnRecord(
+	tr *turnrecorder.TurnRecord,
+	aoListToProcessInTurn []gamei.ActiveObjectI,
+	ao2ActReqRsp map[gamei.ActiveObjectI]*aoactreqrsp.ActReqRsp,
+) {
+	if tr == nil {
+		return
+	}
+	tr.ResultList = make([]turnrecorder.ActResult, 0, len(aoListToProcessInTurn))
+	for _, ao := range aoListToProcessInTurn {
+		ar := turnrecorder.ActResult{
+			AOState: f.makeAOState(ao),
+		}
+		if arr, exist := ao2ActReqRsp[ao]; exist {
+			ar.Done = arr.Done
+			ar.Error = arr.Error
+		}
+		tr.ResultList = append(tr.ResultList, ar)
+	}
+	if f.replayRecord != nil {
+		f.replayResult = tr.ResultList
+		return
+	}
+	if err := f.tower.GetTurnRecorder().Write(tr); err != nil {
+		f.log.Error("fail to write turn record %v %v", f, err)
+	}
+}
+
+func sortAOListByUUID(aoList []gamei.ActiveObjectI) {
+	sort.Slice(aoList, func(i, j int) bool {
+		return aoList[i].GetUUID() < aoList[j].GetUUID()
+	})
+}
+
+func (f *Floor) makeAOState(ao gamei.ActiveObjectI) turnrecorder.AOState {
+	x, y, _ := f.aoPosMan.GetXYByUUID(ao.GetUUID())
+	return turnrecorder.AOState{
+		UUID:     ao.GetUUID(),
+		NickName: ao.GetNickName(),
+		X:        x,
+		Y:        y,
+		HP:       ao.GetHP(),
+		AP:       ao.GetAP(),
+		Alive:    ao.IsAlive(),
+	}
+}
+
+// ReplayTurn sync ao to record, run turn with recorded act and seed
+// return not matched result
+// carryobj made in turn (drop, loot) get new uuid, so act to it may not match
+func (f *Floor) ReplayTurn(tr *turnrecorder.TurnRecord) ([]string, error) {
+	if tr.FloorName != f.GetName() {
+		return nil, fmt.Errorf("floor name mismatch %v %v", f, tr.FloorName)
+	}
+	id2state := make(map[string]turnrecorder.AOStartState, len(tr.AOList))
+	for _, v := range tr.AOList {
+		id2state[v.UUID] = v
+	}
+	if err := f.aoPosMan.DelByFilter(func(o uuidposman.UUIDPosI, x, y int) bool {
+		_, exist := id2state[o.GetUUID()]
+		return !exist
+	}); err != nil {
+		return nil, err
+	}
+	for _, st := range tr.AOList {
+		ao, ok := f.aoPosMan.GetByUUID(st.UUID).(*activeobject.ActiveObject)
+		if !ok {
+			ao = activeobject.NewReplayActiveObj(st.UUID, st.NickName, f, f.log)
+			if err := f.aoPosMan.AddToXY(ao, st.X, st.Y); err != nil {
+				return nil, err
+			}
+			ao.Noti_EnterFloor(f)
+		} else if x, y, _ := f.aoPosMan.GetXYByUUID(st.UUID); x != st.X || y != st.Y {
+			if err := f.aoPosMan.UpdateToXY(ao, st.X, st.Y); err != nil {
+				return nil, err
+			}
+		}
+		ao.SetStateForReplay(st)
+	}
+	for _, v := range tr.ActList {
+		ao, ok := f.aoPosMan.GetByUUID(v.AOUUID).(gamei.ActiveObjectI)
+		if !ok {
+			return nil, fmt.Errorf("act ao not found %v %v", f, v.AOUUID)
+		}
+		act := v.Act
+		ao.SetReq2Handle(&act)
+	}
+
+	f.replayRecord = tr
+	f.replayResult = nil
+	err := f.processTurn(tr.TurnTime)
+	f.aiWG.Wait()
+	f.replayRecord = nil
+	if err != nil {
+		return nil, err
+	}
+	return turnrecorder.CompareResult(tr.ResultList, f.replayResult), nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package floor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/activeobject"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/diplomacy"
+	"github.com/kasworld/goguelike/game/turnrecorder"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+)
+
+func newReplayTestFloor(t *testing.T, rc *turnrecorder.Recorder) *Floor {
+	dp := diplomacy.New()
+	if err := dp.ExecCmdline("DiplomacyDefault relation=Hostile"); err != nil {
+		t.Fatal(err)
+	}
+	f := New(1, []string{
+		"NewTerrain w=32 h=32 name=ReplayTest",
+		"ResourceFillRect resource=Soil amount=64 x=0 y=0 w=32 h=32",
+		"FinalizeTerrain",
+	}, &testTower{diplomacy: dp, recorder: rc})
+	if err := f.Init(); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestReplayCombatTurn(t *testing.T) {
+	// attack each other and move to same tile, many rnd use in a turn
+	actList := []struct {
+		uuid string
+		x, y int
+		act  aoactreqrsp.Act
+	}{
+		{"a", 5, 5, aoactreqrsp.Act{Act: c2t_idcmd.Attack, Dir: way9type.East}},
+		{"b", 6, 5, aoactreqrsp.Act{Act: c2t_idcmd.Attack, Dir: way9type.East}},
+		{"c", 7, 5, aoactreqrsp.Act{Act: c2t_idcmd.AttackWide, Dir: way9type.West}},
+		{"d", 6, 6, aoactreqrsp.Act{Act: c2t_idcmd.Attack, Dir: way9type.North}},
+		{"e", 10, 10, aoactreqrsp.Act{Act: c2t_idcmd.Move, Dir: way9type.East}},
+		{"f", 12, 10, aoactreqrsp.Act{Act: c2t_idcmd.Move, Dir: way9type.West}},
+	}
+	filename := filepath.Join(t.TempDir(), "replay.turnrecord")
+	rc, err := turnrecorder.New(filename, turnrecorder.Header{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := newReplayTestFloor(t, rc)
+	defer f.Cleanup()
+	for _, v := range actList {
+		ao := activeobject.NewReplayActiveObj(v.uuid, v.uuid, f, f.log)
+		if err := f.aoPosMan.AddToXY(ao, v.x, v.y); err != nil {
+			t.Fatal(err)
+		}
+		ao.Noti_EnterFloor(f)
+		ao.SetStateForReplay(turnrecorder.AOStartState{
+			AOState: turnrecorder.AOState{UUID: v.uuid, HP: 100, AP: 1, Alive: true},
+			Detail:  ao.ToReplayDetail(),
+		})
+		act := v.act
+		ao.SetReq2Handle(&act)
+	}
+	if err := f.processTurn(time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	f.aiWG.Wait()
+	if err := rc.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rd, err := turnrecorder.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Close()
+	tr, err := rd.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	damaged := false
+	for _, v := range tr.ResultList {
+		if v.HP < 100 {
+			damaged = true
+		}
+	}
+	if !damaged {
+		t.Fatalf("no damage in recorded turn %v", tr.ResultList)
+	}
+
+	rf := newReplayTestFloor(t, nil)
+	defer rf.Cleanup()
+	for i := 0; i < 10; i++ {
+		mismatch, err := rf.ReplayTurn(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(mismatch) != 0 {
+			t.Fatalf("replay %v not match %v", i, mismatch)
+		}
+		for _, v := range tr.ResultList {
+			x, y, _ := rf.aoPosMan.GetXYByUUID(v.UUID)
+			ao := rf.aoPosMan.GetByUUID(v.UUID).(*activeobject.ActiveObject)
+			if x != v.X || y != v.Y || ao.GetHP() != v.HP {
+				t.Errorf("replay %v %v at %v %v hp %v, recorded %+v",
+					i, v.UUID, x, y, ao.GetHP(), v.AOState)
+			}
+		}
+	}
+}
@@ -12,6 +12,7 @@
 package floor
 
 import (
+	"github.com/kasworld/findnear"
 	"github.com/kasworld/goguelike/config/gameconst"
 	"github.com/kasworld/goguelike/config/viewportdata"
 	"github.com/kasworld/goguelike/enum/condition"
@@ -20,10 +21,20 @@ import (
 	"github.com/kasworld/goguelike/game/dangerobject"
 	"github.com/kasworld/goguelike/game/fieldobject"
 	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/identify"
 	"github.com/kasworld/goguelike/lib/uuidposman"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
 )
 
+// notiTileChangedAt send tile noti to ao near x,y, after terrain changed at runtime
+func (f *Floor) notiTileChangedAt(x, y int) {
+	f.aoPosMan.IterByXYLenList(viewportdata.ViewportXYLenList, x, y, len(viewportdata.ViewportXYLenList),
+		func(o uuidposman.UUIDPosI, x, y int, i int, xylen findnear.XYLen) bool {
+			o.(gamei.ActiveObjectI).SetNeedTANoti()
+			return false
+		})
+}
+
 func (f *Floor) makeViewportTiles2(centerX, centerY int, sightMat *viewportdata.ViewportSight2,
 	sight float32) *viewportdata.ViewportTileArea2 {
 
@@ -70,8 +81,10 @@ func (f *Floor) makeViewportActiveObjs2(
 
 func (f *Floor) makeViewportCarryObjs2(
 	vpixyolist []uuidposman.VPIXYObj,
-	sightMat *viewportdata.ViewportSight2, sight float32) []*c2t_obj.CarryObjClientOnFloor {
+	sightMat *viewportdata.ViewportSight2, sight float32,
+	kn *identify.Knowledge) []*c2t_obj.CarryObjClientOnFloor {
 
+	ap := f.tower.GetAppearance()
 	maxobj := gameconst.CarryObjCountInViewportLimit
 	rtn := make([]*c2t_obj.CarryObjClientOnFloor, 0, len(vpixyolist))
 	for _, v := range vpixyolist {
@@ -79,7 +92,7 @@ func (f *Floor) makeViewportCarryObjs2(
 			continue
 		}
 		ww := v.O.(gamei.CarryingObjectI)
-		rtn = append(rtn, ww.ToPacket_CarryObjClientOnFloor(v.X, v.Y))
+		rtn = append(rtn, ap.MaskCarryObjClientOnFloor(kn, ww.ToPacket_CarryObjClientOnFloor(v.X, v.Y)))
 		maxobj--
 		if maxobj < 0 {
 			f.statPacketObjOver.Inc()
@@ -141,6 +141,10 @@ func (f *Floor) MakeImage(zoom int) *image.RGBA {
 					co = color.RGBA{rgb[0], rgb[1], rgb[2], 0xff}
 				case gamei.MoneyI:
 					co = color.RGBA{0xff, 0xd7, 0x00, 0xff} // gold color
+				case gamei.AmmoI:
+					co = color.RGBA{0xc0, 0xc0, 0xc0, 0xff} // silver color
+				case gamei.KeyI:
+					co = color.RGBA{0xb8, 0x86, 0x0b, 0xff} // darkgoldenrod color
 				}
 			} else if fo := f.foPosMan.Get1stObjAt(srcX, srcY); fo != nil {
 				ww, ok := fo.(*fieldobject.FieldObject)
@@ -14,6 +14,7 @@ package floormanager
 import (
 	"fmt"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/kasworld/g2rand"
@@ -23,6 +24,7 @@ import (
 	"github.com/kasworld/goguelike/game/floor"
 	"github.com/kasworld/goguelike/game/gamei"
 	"github.com/kasworld/goguelike/game/towerscript"
+	"github.com/kasworld/goguelike/game/towersnapshot"
 	"github.com/kasworld/goguelike/lib/g2log"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_const"
 )
@@ -78,7 +80,77 @@ func (fm *FloorManager) Init(rnd *g2rand.G2Rand) error {
 		}(i, v)
 	}
 	wg.Wait()
+	return fm.registerFloorList(tmpFloorList)
+}
+
+// InitFromSnapshot make floor of current tower script
+// floor with same script in snapshot is made by snapshot seed and restore runtime state
+// changed or new floor is made new
+func (fm *FloorManager) InitFromSnapshot(rnd *g2rand.G2Rand, ts *towersnapshot.TowerSnapshot) error {
+	fsList := make([]*towersnapshot.FloorSnapshot, len(fm.terrainScript))
+	seedList := make([]int64, len(fm.terrainScript))
+	for i, v := range fm.terrainScript {
+		fsList[i] = ts.GetFloorByScript(v)
+		if fsList[i] != nil {
+			seedList[i] = fsList[i].Seed
+		} else {
+			fm.log.Warn("floor script changed, make new floor %v", v)
+			seedList[i] = rnd.Int63()
+		}
+	}
+	return fm.initFloorList(fm.terrainScript, seedList, fsList)
+}
+
+// InitFromRecord make floor by script and seed in snapshot of turn record, for replay
+func (fm *FloorManager) InitFromRecord(ts *towersnapshot.TowerSnapshot) error {
+	fm.terrainScript = make(towerscript.TowerScript, len(ts.FloorList))
+	seedList := make([]int64, len(ts.FloorList))
+	for i, fs := range ts.FloorList {
+		fm.terrainScript[i] = fs.Script
+		seedList[i] = fs.Seed
+	}
+	return fm.initFloorList(fm.terrainScript, seedList, ts.FloorList)
+}
+
+// initFloorList make floor by script and seed, restore snapshot if not nil
+func (fm *FloorManager) initFloorList(scriptList towerscript.TowerScript,
+	seedList []int64, fsList []*towersnapshot.FloorSnapshot) error {
+
+	tmpFloorList := make([]gamei.FloorI, len(scriptList))
+	var wg sync.WaitGroup
+	for i, v := range scriptList {
+		wg.Add(1)
+		go func(i int, v []string, fs *towersnapshot.FloorSnapshot) {
+			defer wg.Done()
+			f := floor.New(seedList[i], v, fm.tower)
+			if err := f.Init(); err != nil {
+				fm.log.Fatal("floor init fail, %v", err)
+			}
+			if fs != nil && f.Initialized() {
+				if err := f.RestoreSnapshot(fs); err != nil {
+					fm.log.Fatal("floor restore fail, %v", err)
+				}
+			}
+			tmpFloorList[i] = f
+		}(i, v, fsList[i])
+	}
+	wg.Wait()
+	return fm.registerFloorList(tmpFloorList)
+}
+
+func (fm *FloorManager) ToSnapshot() *towersnapshot.TowerSnapshot {
+	ts := &towersnapshot.TowerSnapshot{
+		Version:   towersnapshot.Version,
+		SaveTime:  time.Now(),
+		TowerName: fm.tower.Config().TowerName,
+	}
+	for _, f := range fm.floorList {
+		ts.FloorList = append(ts.FloorList, f.ToSnapshot())
+	}
+	return ts
+}
 
+func (fm *FloorManager) registerFloorList(tmpFloorList []gamei.FloorI) error {
 	for i, f := range tmpFloorList {
 		if !f.Initialized() {
 			fm.log.Warn("skip not initialized floor %v", fm.terrainScript[i])
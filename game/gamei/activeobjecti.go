@@ -14,19 +14,32 @@ package gamei
 import (
 	"time"
 
+	"github.com/kasworld/goguelike/config/bossdata"
+	"github.com/kasworld/goguelike/config/craftdata"
+	"github.com/kasworld/goguelike/config/questdata"
 	"github.com/kasworld/goguelike/config/viewportdata"
 	"github.com/kasworld/goguelike/enum/achievetype_vector"
 	"github.com/kasworld/goguelike/enum/aotype"
+	"github.com/kasworld/goguelike/enum/chattype"
 	"github.com/kasworld/goguelike/enum/condition_vector"
+	"github.com/kasworld/goguelike/enum/factiontype"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype_vector"
+	"github.com/kasworld/goguelike/enum/petorder"
 	"github.com/kasworld/goguelike/enum/potiontype_vector"
 	"github.com/kasworld/goguelike/enum/scrolltype_vector"
+	"github.com/kasworld/goguelike/enum/skilltype"
 	"github.com/kasworld/goguelike/game/activeobject/activebuff"
 	"github.com/kasworld/goguelike/game/activeobject/aoturndata"
 	"github.com/kasworld/goguelike/game/activeobject/turnresult"
 	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/aopersistent"
+	"github.com/kasworld/goguelike/game/aoquest"
 	"github.com/kasworld/goguelike/game/aoscore"
 	"github.com/kasworld/goguelike/game/bias"
+	"github.com/kasworld/goguelike/game/identify"
+	"github.com/kasworld/goguelike/game/noise"
+	"github.com/kasworld/goguelike/game/objlistdelta"
+	"github.com/kasworld/goguelike/game/turnrecorder"
 	"github.com/kasworld/goguelike/game/visitarea"
 	"github.com/kasworld/goguelike/lib/scriptparse"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
@@ -38,7 +51,7 @@ import (
 type ActiveObjectI interface {
 	Cleanup()
 	GetUUID() string
-	// GetNickName() string
+	GetNickName() string
 	String() string
 
 	GetInven() InventoryI
@@ -85,7 +98,22 @@ type ActiveObjectI interface {
 	GetBuffManager() *activebuff.BuffManager
 
 	GetClientConn() *c2t_serveconnbyte.ServeConnByte
+	GetObjListSender() *objlistdelta.Sender
 	GetActiveObjType() aotype.ActiveObjType
+	GetBoss() *bossdata.Boss
+
+	IsSummoned() bool
+	GetPetOwner() ActiveObjectI
+	SetPetOwner(owner ActiveObjectI)
+	GetPetOrder() (petorder.PetOrder, string)
+	SetPetOrder(order petorder.PetOrder, target string)
+	AddPet(pet ActiveObjectI) error
+	RemovePet(pet ActiveObjectI) error
+	ReleaseAllPet()
+	GetPetList() []ActiveObjectI
+	GetPetByUUID(id string) ActiveObjectI
+	GetBattleFaction() factiontype.FactionType
+	IsPartyOf(dst ActiveObjectI) bool
 
 	IsAIUse() bool
 	SetUseAI(b bool)
@@ -93,6 +121,11 @@ type ActiveObjectI interface {
 
 	GetChat() string
 	SetChat(c string)
+	CheckChatInterval(ct chattype.ChatType, now time.Time) bool
+
+	GetLearnedSkillList() []skilltype.SkillType
+	CanCastSkill(st skilltype.SkillType) c2t_error.ErrorCode
+	CastSkill(st skilltype.SkillType)
 
 	GetRemainTurn2Rebirth() int
 	TryRebirth() error
@@ -106,11 +139,17 @@ type ActiveObjectI interface {
 	ApplyDamageFromDangerObj() bool
 	ApplyHPSPDecByActOnTile(hp, sp float64)
 	Kill(dst ActiveObjectI)
+	WearEquipByHit(attack bool)
 
 	DoEquip(poid string) error
 	DoUnEquip(poid string) error
 	DoUseCarryObj(poid string) error
 	DoRecycleCarryObj(poid string) error
+	DoCraftCarryObj(rcp *craftdata.Recipe) ([]CarryingObjectI, error)
+	DoBuyCarryObj(po CarryingObjectI, price float64) error
+	DoRepairEquip(eq EquipObjI) error
+	DoAcceptQuest(q *questdata.Quest) error
+	DoCompleteQuest(q *questdata.Quest) error
 	DoAIOnOff(onoff bool) error
 	DoPickup(po CarryingObjectI) error
 
@@ -120,11 +159,25 @@ type ActiveObjectI interface {
 	ToPacket_ActiveObjClient(x, y int) *c2t_obj.ActiveObjClient
 	ToPacket_PlayerActiveObjInfo() *c2t_obj.PlayerActiveObjInfo
 	To_ActiveObjScore() *aoscore.ActiveObjScore
+	ToPersistent() *aopersistent.AOPersistent
+	ToReplayDetail() turnrecorder.AODetail
+	SetSessionUUID(sessionuuid string)
+	GetSessionUUID() string
+	ToPacket_QuestClient(q *questdata.Quest) *c2t_obj.QuestClient
+	ToPacket_QuestClientList() []*c2t_obj.QuestClient
 
 	GetAchieveStat() *achievetype_vector.AchieveTypeVector
 	GetFieldObjActStat() *fieldobjacttype_vector.FieldObjActTypeVector
 	GetPotionStat() *potiontype_vector.PotionTypeVector
 	GetScrollStat() *scrolltype_vector.ScrollTypeVector
+	GetIdentifyKnowledge() *identify.Knowledge
+	GetQuestProgressList() []*aoquest.Progress
+	NeedDiplomacyNoti(version int) bool
+	SetHeardNoiseList(hl []noise.Heard)
+	GetLightRadius() float64
+	GetHeardNoiseList() []noise.Heard
+	SetSightMat(sightMat *viewportdata.ViewportSight2)
+	GetSightMat() *viewportdata.ViewportSight2
 	GetActStat() *c2t_idcmd_stats.CommandIDStat
 	GetConditionStat() *condition_vector.ConditionVector
 
@@ -38,7 +38,15 @@ type EquipObjI interface {
 
 	// bias, faction
 	GetEquipType() equipslottype.EquipSlotType
+	GetMaterial() string
 	GetBias() bias.Bias
+	GetLightRadius() float64
+
+	// durability
+	GetDurability() int
+	DecDurability(n int) int
+	GetRepairCost() float64
+	Repair()
 }
 
 type PotionI interface {
@@ -53,6 +61,16 @@ type MoneyI interface {
 	Sub(po2 MoneyI) MoneyI
 }
 
+type AmmoI interface {
+	CarryingObjectI
+	GetCount() int
+}
+
+type KeyI interface {
+	CarryingObjectI
+	GetKeyID() string
+}
+
 type ScrollI interface {
 	CarryingObjectI
 	GetScrollType() scrolltype.ScrollType
@@ -16,9 +16,12 @@ import (
 	"net/http"
 
 	"github.com/kasworld/actpersec"
+	"github.com/kasworld/goguelike/config/bossdata"
 	"github.com/kasworld/goguelike/game/bias"
 	"github.com/kasworld/goguelike/game/fieldobject"
 	"github.com/kasworld/goguelike/game/terraini"
+	"github.com/kasworld/goguelike/game/towersnapshot"
+	"github.com/kasworld/goguelike/game/turnrecorder"
 	"github.com/kasworld/goguelike/lib/uuidposman"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
 )
@@ -53,6 +56,7 @@ type FloorI interface {
 	TotalCarryObjCount() int
 	SearchRandomActiveObjPos() (int, int, error)
 	SearchRandomActiveObjPosInRoomOrRandPos() (int, int, error)
+	SearchBossPos(bs *bossdata.Boss) (int, int, error)
 
 	FindPath(dstx, dsty, srcx, srcy int, limit int) [][2]int
 
@@ -64,4 +68,7 @@ type FloorI interface {
 	ToPacket_FloorInfo() *c2t_obj.FloorInfo
 
 	FindUsablePortalPairAt(x, y int) (*fieldobject.FieldObject, *fieldobject.FieldObject, error)
+
+	ToSnapshot() *towersnapshot.FloorSnapshot
+	ReplayTurn(tr *turnrecorder.TurnRecord) ([]string, error)
 }
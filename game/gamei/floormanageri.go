@@ -19,5 +19,6 @@ type FloorManagerI interface {
 	GetFloorCount() int
 	GetFloorList() []FloorI
 	GetFloorByName(name string) FloorI
+	GetFloorIndexByName(id string) (int, error)
 	FindPortalByID(id string) *fieldobject.FieldObject
 }
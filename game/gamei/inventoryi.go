@@ -11,7 +11,10 @@
 
 package gamei
 
-import "github.com/kasworld/goguelike/enum/equipslottype"
+import (
+	"github.com/kasworld/goguelike/config/craftdata"
+	"github.com/kasworld/goguelike/enum/equipslottype"
+)
 
 type InventoryI interface {
 	GetEquipSlot() [equipslottype.EquipSlotType_Count]EquipObjI
@@ -27,11 +30,26 @@ type InventoryI interface {
 	AddToBag(po CarryingObjectI) error
 
 	RecycleCarryObjByID(poid string) (float64, error)
+	Craft(rcp *craftdata.Recipe) ([]CarryingObjectI, error)
+	CountMaterial(mt craftdata.Material) int
+	RemoveMaterial(mtList []craftdata.Material) error
+	BuyCarryObj(po CarryingObjectI, price float64) error
+	WearEquipByHit(attack bool) []EquipObjI
+	RepairEquip(eq EquipObjI) (float64, error)
 
 	AddToWallet(po MoneyI) error
 	SubFromWallet(po MoneyI) error
 	GetWalletValue() float64
 
+	AddAmmo(count int)
+	UseAmmo(count int) error
+	GetAmmoCount() int
+
+	AddKey(keyID string)
+	HasKey(keyID string) bool
+	GetKeyList() []string
+	RemoveAllKey() []string
+
 	EquipFromBagByUUID(id string) error
 	UnEquipToBagByUUID(id string) (EquipObjI, error)
 }
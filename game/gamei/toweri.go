@@ -12,8 +12,13 @@
 package gamei
 
 import (
+	"github.com/kasworld/goguelike/config/questdata"
 	"github.com/kasworld/goguelike/config/towerconfig"
 	"github.com/kasworld/goguelike/game/bias"
+	"github.com/kasworld/goguelike/game/diplomacy"
+	"github.com/kasworld/goguelike/game/identify"
+	"github.com/kasworld/goguelike/game/spectatorman"
+	"github.com/kasworld/goguelike/game/turnrecorder"
 	"github.com/kasworld/goguelike/lib/g2log"
 )
 
@@ -23,9 +28,14 @@ type TowerI interface {
 
 	GetReqCh() chan<- interface{}
 	GetBias() bias.Bias
+	GetAppearance() *identify.Appearance
+	GetQuestList() []*questdata.Quest
+	GetDiplomacy() *diplomacy.Diplomacy
 
 	GetFloorManager() FloorManagerI
 	GetExpRanking() []ActiveObjectI
+	GetTurnRecorder() *turnrecorder.Recorder // nil if not recording
+	GetSpectatorManager() *spectatorman.SpectatorManager
 
 	Config() *towerconfig.TowerConfig
 	Log() *g2log.LogBase
@@ -64,8 +64,7 @@ type Ground struct {
 	serviceInfo c2t_obj.ServiceInfo
 	twMan       *TowerManager
 
-	mutexHighScore sync.RWMutex               `prettystring:"hide"`
-	highScore      aoscore.ActiveObjScoreList `prettystring:"simple"`
+	scoreStore *ScoreStore `prettystring:"simple"`
 
 	RecvStat *actpersec.ActPerSec `prettystring:"simple"`
 	SendStat *actpersec.ActPerSec `prettystring:"simple"`
@@ -151,30 +150,17 @@ func (grd *Ground) ServiceInit() error {
 		grd.twMan = NewTowerManager(grd.sconfig, grd.log, towerdata.Default)
 	}
 
-	if err := grd.highScore.LoadJSON(grd.sconfig.MakeHighScoreFileFullpath()); err != nil {
-		grd.log.Warn("fail to load high score %v, make new %v",
-			err, grd.sconfig.MakeHighScoreFileFullpath())
-
-		for lv := 1; lv <= 10; lv++ {
-			bornFaction := factiontype.FactionType(grd.rnd.Intn(factiontype.FactionType_Count))
-			CurrentBias := bias.Bias{
-				grd.rnd.Float64() - 0.5,
-				grd.rnd.Float64() - 0.5,
-				grd.rnd.Float64() - 0.5,
-			}.MakeAbsSumTo(gameconst.ActiveObjBaseBiasLen)
-
-			grd.highScore = append(grd.highScore,
-				aoscore.NewActiveObjScoreByLevel(lv, bornFaction, CurrentBias))
-		}
-
-		grd.highScore.SortByExp()
-		grd.highScore.TrimLen(groundconst.HighScoreLen)
-
-		if err := grd.highScore.SaveJSON(grd.sconfig.MakeHighScoreFileFullpath()); err != nil {
-			grd.log.Error("fail to save high score %v %v",
-				grd.sconfig.MakeHighScoreFileFullpath(), err)
-		}
+	grd.scoreStore, err = OpenScoreStore(
+		grd.sconfig.MakeScoreLogFileFullpath(), grd.sconfig.ScoreSeasonMonth)
+	if err != nil {
+		grd.log.Fatal("fail to open score store %v %v",
+			grd.sconfig.MakeScoreLogFileFullpath(), err)
+		return err
+	}
+	if grd.scoreStore.Count() == 0 {
+		grd.initScoreStore()
 	}
+	grd.log.TraceService("%v", grd.scoreStore)
 
 	grd.initAdminWeb()
 	grd.initServiceWeb()
@@ -221,6 +207,9 @@ loop:
 func (grd *Ground) ServiceCleanup() {
 	grd.log.TraceService("Start ServiceCleanup %v", grd)
 	defer func() { grd.log.TraceService("End ServiceCleanup %v", grd) }()
+	if err := grd.scoreStore.Close(); err != nil {
+		grd.log.Error("fail to close score store %v", err)
+	}
 }
 
 func (grd *Ground) GetTowerManager() *TowerManager {
@@ -235,22 +224,51 @@ func (grd *Ground) GetServiceInfo() c2t_obj.ServiceInfo {
 	return grd.serviceInfo
 }
 
-func (grd *Ground) AddActiveObj2HighScoreAndSort(aos *aoscore.ActiveObjScore) {
-	scoreFilename := grd.sconfig.MakeHighScoreFileFullpath()
+// initScoreStore import old high score file or make unnamed scores
+func (grd *Ground) initScoreStore() {
+	var highScore aoscore.ActiveObjScoreList
+	if err := highScore.LoadJSON(grd.sconfig.MakeHighScoreFileFullpath()); err != nil {
+		grd.log.Warn("fail to load high score %v, make new %v",
+			err, grd.sconfig.MakeHighScoreFileFullpath())
 
-	grd.mutexHighScore.Lock()
-	grd.highScore.AddOrUpdate(aos)
-	grd.highScore.SortByExp()
-	grd.highScore.TrimLen(groundconst.HighScoreLen)
-	err := grd.highScore.SaveJSON(scoreFilename)
-	grd.mutexHighScore.Unlock()
+		for lv := 1; lv <= 10; lv++ {
+			bornFaction := factiontype.FactionType(grd.rnd.Intn(factiontype.FactionType_Count))
+			CurrentBias := bias.Bias{
+				grd.rnd.Float64() - 0.5,
+				grd.rnd.Float64() - 0.5,
+				grd.rnd.Float64() - 0.5,
+			}.MakeAbsSumTo(gameconst.ActiveObjBaseBiasLen)
 
-	if err != nil {
+			highScore = append(highScore,
+				aoscore.NewActiveObjScoreByLevel(lv, bornFaction, CurrentBias))
+		}
+	}
+	for _, aos := range highScore {
+		if err := grd.scoreStore.Add(aos); err != nil {
+			grd.log.Error("fail to add score %v %v", aos, err)
+		}
+	}
+	grd.saveHighScoreFile()
+}
+
+// saveHighScoreFile save top of all time board, for old client
+func (grd *Ground) saveHighScoreFile() {
+	scoreFilename := grd.sconfig.MakeHighScoreFileFullpath()
+	highScore := grd.scoreStore.GetBoard(AllTime, "").GetPage(0, groundconst.HighScoreLen)
+	if err := highScore.SaveJSON(scoreFilename); err != nil {
 		grd.log.Error("fail to save high score %v %v",
 			scoreFilename, err)
 	}
 }
 
+func (grd *Ground) AddActiveObj2HighScoreAndSort(aos *aoscore.ActiveObjScore) {
+	if err := grd.scoreStore.Add(aos); err != nil {
+		grd.log.Error("fail to add score %v %v", aos, err)
+		return
+	}
+	grd.saveHighScoreFile()
+}
+
 // ControlTower control tower process
 // cmd : start,stop,restart,forcestart,logreopen (default "start")
 func (grd *Ground) ControlTower(te *TowerRunning, cmd string) error {
@@ -63,6 +63,9 @@ func (grd *Ground) initServiceWeb() {
 	)
 	webMux.HandleFunc("/towerlist.json", grd.json_TowerList)
 	webMux.HandleFunc("/highscore.json", grd.json_HighScore)
+	webMux.HandleFunc("/scoreboard.json", grd.json_ScoreBoard)
+	webMux.HandleFunc("/scoreseason.json", grd.json_ScoreSeason)
+	webMux.HandleFunc("/scorehistory.json", grd.json_ScoreHistory)
 
 	grd.clientWeb = &http.Server{
 		Handler: webMux,
@@ -141,7 +144,9 @@ func (grd *Ground) web_KillSelf(w http.ResponseWriter, r *http.Request) {
 }
 
 func (grd *Ground) web_HighScore(w http.ResponseWriter, r *http.Request) {
-	allActiveObj := aoscore.ActiveObjScoreList(grd.highScore)
+	season := weblib.GetStringByName("season", AllTime, w, r)
+	tower := weblib.GetStringByName("tower", "", w, r)
+	allActiveObj := grd.scoreStore.GetBoard(season, tower)
 	page := weblib.GetPage(w, r)
 	listActiveObj := allActiveObj.GetPage(page, 40)
 	aoscore.ActiveObjScoreList(listActiveObj).ToWeb(w, r)
@@ -211,7 +216,7 @@ func (grd *Ground) json_TowerList(w http.ResponseWriter, r *http.Request) {
 }
 
 func (grd *Ground) json_HighScore(w http.ResponseWriter, r *http.Request) {
-	allActiveObj := aoscore.ActiveObjScoreList(grd.highScore)
+	allActiveObj := grd.scoreStore.GetBoard(AllTime, "")
 	page := weblib.GetPage(w, r)
 	listActiveObj := allActiveObj.GetPage(page, 40)
 	w.Header().Set("Access-Control-Allow-Origin", "*")
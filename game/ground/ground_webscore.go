@@ -0,0 +1,89 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ground
+
+import (
+	"net/http"
+
+	"github.com/kasworld/goguelike/game/aoscore"
+	"github.com/kasworld/weblib"
+)
+
+const (
+	scorePageSizeDefault = 40
+	scorePageSizeMax     = 200
+)
+
+// ScorePage paginated score list for json endpoint
+type ScorePage struct {
+	Season   string // AllTime(empty) or season name
+	Tower    string // empty for global
+	NickName string // for history
+	Page     int
+	PageSize int
+	Total    int
+	List     aoscore.ActiveObjScoreList
+}
+
+func getScorePage(all aoscore.ActiveObjScoreList,
+	w http.ResponseWriter, r *http.Request) ScorePage {
+	page := weblib.GetPage(w, r)
+	pagesize := weblib.GetIntByName("pagesize", scorePageSizeDefault, w, r)
+	if pagesize < 1 || pagesize > scorePageSizeMax {
+		pagesize = scorePageSizeDefault
+	}
+	sp := ScorePage{
+		Page:     page,
+		PageSize: pagesize,
+		Total:    len(all),
+		List:     make(aoscore.ActiveObjScoreList, 0),
+	}
+	if page*pagesize < len(all) {
+		sp.List = all.GetPage(page, pagesize)
+	}
+	return sp
+}
+
+// json_ScoreBoard ?season=&tower=&page=&pagesize=
+// season : empty for all time, "current" for current season, or season name like 2020-01
+func (grd *Ground) json_ScoreBoard(w http.ResponseWriter, r *http.Request) {
+	season := weblib.GetStringByName("season", AllTime, w, r)
+	if season == "current" {
+		season = grd.scoreStore.CurrentSeason().Name
+	}
+	tower := weblib.GetStringByName("tower", "", w, r)
+	sp := getScorePage(grd.scoreStore.GetBoard(season, tower), w, r)
+	sp.Season = season
+	sp.Tower = tower
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	weblib.ServeJSON2HTTP(sp, w)
+}
+
+// json_ScoreSeason season list, latest first
+func (grd *Ground) json_ScoreSeason(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	weblib.ServeJSON2HTTP(grd.scoreStore.GetSeasonList(), w)
+}
+
+// json_ScoreHistory ?name=&page=&pagesize=
+// all record of player, oldest first
+func (grd *Ground) json_ScoreHistory(w http.ResponseWriter, r *http.Request) {
+	name := weblib.GetStringByName("name", "", w, r)
+	if name == "" {
+		http.Error(w, "invalid name", http.StatusNotFound)
+		return
+	}
+	sp := getScorePage(grd.scoreStore.GetHistory(name), w, r)
+	sp.NickName = name
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	weblib.ServeJSON2HTTP(sp, w)
+}
@@ -0,0 +1,255 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ground
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kasworld/goguelike/game/aoscore"
+)
+
+// AllTime season name of not reset board
+const AllTime = ""
+
+// Season score board reset period
+type Season struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// MakeSeason return season include t, season start at first day of month
+// seasonMonth : month count of a season
+func MakeSeason(t time.Time, seasonMonth int) Season {
+	if seasonMonth < 1 {
+		seasonMonth = 1
+	}
+	t = t.UTC()
+	m := (int(t.Month()) - 1) / seasonMonth * seasonMonth
+	st := time.Date(t.Year(), time.Month(m+1), 1, 0, 0, 0, 0, time.UTC)
+	return Season{
+		Name:  st.Format("2006-01"),
+		Start: st,
+		End:   st.AddDate(0, seasonMonth, 0),
+	}
+}
+
+type scoreBoardKey struct {
+	Season string // AllTime or season name
+	Tower  string // empty for global
+}
+
+// scoreBoard keep best score of each player
+type scoreBoard struct {
+	name2Score map[string]*aoscore.ActiveObjScore
+	sorted     aoscore.ActiveObjScoreList // nil if need sort
+}
+
+func newScoreBoard() *scoreBoard {
+	return &scoreBoard{
+		name2Score: make(map[string]*aoscore.ActiveObjScore),
+	}
+}
+
+func (sb *scoreBoard) add(aos *aoscore.ActiveObjScore) {
+	if old, exist := sb.name2Score[aos.NickName]; exist && old.Exp >= aos.Exp {
+		return
+	}
+	sb.name2Score[aos.NickName] = aos
+	sb.sorted = nil
+}
+
+func (sb *scoreBoard) getSorted() aoscore.ActiveObjScoreList {
+	if sb.sorted == nil {
+		sb.sorted = make(aoscore.ActiveObjScoreList, 0, len(sb.name2Score))
+		for _, v := range sb.name2Score {
+			sb.sorted = append(sb.sorted, v)
+		}
+		sb.sorted.SortByExp()
+	}
+	return sb.sorted
+}
+
+func (ss *ScoreStore) String() string {
+	return fmt.Sprintf("ScoreStore[%v record:%v board:%v player:%v]",
+		ss.filename, ss.recordCount, len(ss.boards), len(ss.name2History))
+}
+
+// ScoreStore append only score log with ranking boards made from it
+// board : all time/season x global/tower
+type ScoreStore struct {
+	mutex       sync.RWMutex `prettystring:"hide"`
+	filename    string
+	fd          *os.File      `prettystring:"hide"`
+	enc         *json.Encoder `prettystring:"hide"`
+	seasonMonth int
+	recordCount int
+
+	boards       map[scoreBoardKey]*scoreBoard         `prettystring:"hide"`
+	seasonList   []Season                              `prettystring:"simple"` // sorted by start
+	name2History map[string]aoscore.ActiveObjScoreList `prettystring:"hide"`
+}
+
+// OpenScoreStore load all record in file and open to append
+func OpenScoreStore(filename string, seasonMonth int) (*ScoreStore, error) {
+	ss := &ScoreStore{
+		filename:     filename,
+		seasonMonth:  seasonMonth,
+		boards:       make(map[scoreBoardKey]*scoreBoard),
+		name2History: make(map[string]aoscore.ActiveObjScoreList),
+	}
+	if err := ss.load(); err != nil {
+		return nil, err
+	}
+	fd, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	ss.fd = fd
+	ss.enc = json.NewEncoder(fd)
+	return ss, nil
+}
+
+func (ss *ScoreStore) load() error {
+	fd, err := os.Open(ss.filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	dec := json.NewDecoder(fd)
+	var goodOffset int64
+	for {
+		aos := &aoscore.ActiveObjScore{}
+		err := dec.Decode(aos)
+		if err == io.EOF {
+			return nil
+		}
+		if err == io.ErrUnexpectedEOF {
+			// last record broken by crash, cut it not to break next append
+			return os.Truncate(ss.filename, goodOffset)
+		}
+		if err != nil {
+			return fmt.Errorf("%v record %v %v", ss.filename, ss.recordCount, err)
+		}
+		goodOffset = dec.InputOffset()
+		ss.addNolock(aos)
+	}
+}
+
+func (ss *ScoreStore) Close() error {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	if ss.fd == nil {
+		return nil
+	}
+	err := ss.fd.Close()
+	ss.fd = nil
+	ss.enc = nil
+	return err
+}
+
+// Add append to file and update boards
+func (ss *ScoreStore) Add(aos *aoscore.ActiveObjScore) error {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	if ss.enc == nil {
+		return fmt.Errorf("store closed %v", ss)
+	}
+	if err := ss.enc.Encode(aos); err != nil {
+		return err
+	}
+	ss.addNolock(aos)
+	return nil
+}
+
+func (ss *ScoreStore) addNolock(aos *aoscore.ActiveObjScore) {
+	ss.recordCount++
+	season := ss.getSeasonNolock(aos.RecordTime)
+	for _, k := range []scoreBoardKey{
+		{AllTime, ""},
+		{AllTime, aos.TowerName},
+		{season.Name, ""},
+		{season.Name, aos.TowerName},
+	} {
+		sb, exist := ss.boards[k]
+		if !exist {
+			sb = newScoreBoard()
+			ss.boards[k] = sb
+		}
+		sb.add(aos)
+	}
+	ss.name2History[aos.NickName] = append(ss.name2History[aos.NickName], aos)
+}
+
+func (ss *ScoreStore) getSeasonNolock(t time.Time) Season {
+	season := MakeSeason(t, ss.seasonMonth)
+	i := sort.Search(len(ss.seasonList), func(i int) bool {
+		return !ss.seasonList[i].Start.Before(season.Start)
+	})
+	if i < len(ss.seasonList) && ss.seasonList[i].Name == season.Name {
+		return season
+	}
+	ss.seasonList = append(ss.seasonList, Season{})
+	copy(ss.seasonList[i+1:], ss.seasonList[i:])
+	ss.seasonList[i] = season
+	return season
+}
+
+func (ss *ScoreStore) Count() int {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+	return ss.recordCount
+}
+
+// CurrentSeason season of now
+func (ss *ScoreStore) CurrentSeason() Season {
+	return MakeSeason(time.Now(), ss.seasonMonth)
+}
+
+// GetSeasonList return season has record, latest first
+func (ss *ScoreStore) GetSeasonList() []Season {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+	rtn := make([]Season, len(ss.seasonList))
+	for i, v := range ss.seasonList {
+		rtn[len(rtn)-1-i] = v
+	}
+	return rtn
+}
+
+// GetBoard return sorted by exp, best score of each player
+// season : AllTime or season name, tower : empty for global
+func (ss *ScoreStore) GetBoard(season, tower string) aoscore.ActiveObjScoreList {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	sb, exist := ss.boards[scoreBoardKey{season, tower}]
+	if !exist {
+		return nil
+	}
+	return sb.getSorted()
+}
+
+// GetHistory return all record of player, oldest first
+func (ss *ScoreStore) GetHistory(nickname string) aoscore.ActiveObjScoreList {
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+	return ss.name2History[nickname]
+}
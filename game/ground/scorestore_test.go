@@ -0,0 +1,190 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ground
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kasworld/goguelike/game/aoscore"
+)
+
+func newTestScoreFile(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "scorestore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "score.json")
+}
+
+func testScore(nick, tower string, exp float64, t time.Time) *aoscore.ActiveObjScore {
+	return &aoscore.ActiveObjScore{
+		TowerName:  tower,
+		RecordTime: t,
+		NickName:   nick,
+		Exp:        exp,
+	}
+}
+
+func TestScoreStore_LoadBrokenTail(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		tail      string // written after 2 good record
+		wantCount int
+		truncated bool
+		wantErr   bool
+	}{
+		{"no tail", "", 2, false, false},
+		{"blank tail", "\n\n", 2, false, false},
+		{"open brace", "{", 2, true, false},
+		{"half record", `{"TowerName":"t1","NickName":"c","Ex`, 2, true, false},
+		{"half string", `{"TowerName":"t1`, 2, true, false},
+		{"broken record", "}\n", 0, false, true},
+	} {
+		filename := newTestScoreFile(t)
+		ss, err := OpenScoreStore(filename, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		now := time.Now()
+		for _, aos := range []*aoscore.ActiveObjScore{
+			testScore("a", "t1", 10, now),
+			testScore("b", "t1", 20, now),
+		} {
+			if err := ss.Add(aos); err != nil {
+				t.Fatal(err)
+			}
+		}
+		ss.Close()
+		fi, err := os.Stat(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		goodSize := fi.Size()
+
+		// crash while writing next record
+		fd, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fd.WriteString(tc.tail); err != nil {
+			t.Fatal(err)
+		}
+		fd.Close()
+
+		ss, err = OpenScoreStore(filename, 1)
+		if tc.wantErr {
+			if err == nil {
+				ss.Close()
+				t.Errorf("%v: loaded broken file", tc.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: %v", tc.name, err)
+			continue
+		}
+		if ss.Count() != tc.wantCount {
+			t.Errorf("%v: count %v want %v", tc.name, ss.Count(), tc.wantCount)
+		}
+		fi, err = os.Stat(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tc.truncated && fi.Size() >= goodSize {
+			t.Errorf("%v: not truncated size %v good %v", tc.name, fi.Size(), goodSize)
+		}
+
+		// append after recovery must be readable
+		if err := ss.Add(testScore("c", "t1", 30, now)); err != nil {
+			t.Fatal(err)
+		}
+		ss.Close()
+		ss, err = OpenScoreStore(filename, 1)
+		if err != nil {
+			t.Errorf("%v: reload after append %v", tc.name, err)
+			continue
+		}
+		if ss.Count() != tc.wantCount+1 {
+			t.Errorf("%v: count after append %v want %v", tc.name, ss.Count(), tc.wantCount+1)
+		}
+		ss.Close()
+	}
+}
+
+func TestScoreStore_BoardOrder(t *testing.T) {
+	filename := newTestScoreFile(t)
+	ss, err := OpenScoreStore(filename, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jan := time.Date(2020, 1, 10, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC)
+	for _, aos := range []*aoscore.ActiveObjScore{
+		testScore("a", "t1", 10, jan),
+		testScore("b", "t2", 30, jan),
+		testScore("a", "t1", 5, feb), // not best of a
+		testScore("c", "t2", 20, feb),
+		testScore("a", "t2", 40, feb),
+	} {
+		if err := ss.Add(aos); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ss.Close()
+
+	// reload make same board
+	ss, err = OpenScoreStore(filename, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ss.Close()
+	for _, tc := range []struct {
+		season string
+		tower  string
+		want   []string // nickname by exp
+	}{
+		{AllTime, "", []string{"a", "b", "c"}},
+		{AllTime, "t1", []string{"a"}},
+		{AllTime, "t2", []string{"a", "b", "c"}},
+		{"2020-01", "", []string{"b", "a"}},
+		{"2020-01", "t1", []string{"a"}},
+		{"2020-02", "", []string{"a", "c"}},
+		{"2020-02", "t1", []string{"a"}},
+		{"2020-02", "t2", []string{"a", "c"}},
+		{"2020-03", "", nil},
+	} {
+		board := ss.GetBoard(tc.season, tc.tower)
+		if len(board) != len(tc.want) {
+			t.Errorf("board %v %v len %v want %v", tc.season, tc.tower, len(board), tc.want)
+			continue
+		}
+		for i, v := range board {
+			if v.NickName != tc.want[i] {
+				t.Errorf("board %v %v [%v] %v want %v", tc.season, tc.tower, i, v.NickName, tc.want[i])
+			}
+			if i > 0 && board[i-1].Exp < v.Exp {
+				t.Errorf("board %v %v not sorted %v", tc.season, tc.tower, board)
+			}
+		}
+	}
+	if sl := ss.GetSeasonList(); len(sl) != 2 || sl[0].Name != "2020-02" {
+		t.Errorf("season list %v", sl)
+	}
+	if hl := ss.GetHistory("a"); len(hl) != 3 || hl[2].Exp != 40 {
+		t.Errorf("history %v", hl)
+	}
+}
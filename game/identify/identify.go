@@ -0,0 +1,162 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package identify hide true type of potion, scroll until ao identify it
+// appearance : per tower random name of each type
+// knowledge : per ao identified type, kept in aopersistent
+package identify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kasworld/g2rand"
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+var potionLooks = []string{
+	"Red", "Orange", "Yellow", "Green", "Blue", "Indigo", "Violet", "Black",
+	"White", "Brown", "Pink", "Cloudy", "Murky", "Bubbly", "Smoky", "Fizzy",
+	"Golden", "Silver", "Milky", "Oily", "Glowing", "Dark", "Sparkling", "Amber",
+}
+
+var scrollSyllables = []string{
+	"ZEL", "GO", "MER", "FOO", "BAR", "XIX", "DAI", "YEN", "ELAM", "KER",
+	"NOD", "VE", "PRA", "TY", "LEP", "JUY", "RO", "ABRA", "KA", "DAB",
+}
+
+// Appearance name shown to ao not identified the type
+type Appearance struct {
+	potionName [potiontype.PotionType_Count]string
+	scrollName [scrolltype.ScrollType_Count]string
+}
+
+// NewAppearanceBySeed same seed make same appearance
+// seed is random per tower and kept in tower snapshot
+func NewAppearanceBySeed(seed int64) *Appearance {
+	return NewAppearance(g2rand.NewWithSeed(seed))
+}
+
+func NewAppearance(rnd *g2rand.G2Rand) *Appearance {
+	ap := &Appearance{}
+	looks := append([]string(nil), potionLooks...)
+	rnd.Shuffle(len(looks), func(i, j int) {
+		looks[i], looks[j] = looks[j], looks[i]
+	})
+	for i := range ap.potionName {
+		if i < len(looks) {
+			ap.potionName[i] = looks[i] + " potion"
+		} else {
+			ap.potionName[i] = fmt.Sprintf("Strange potion %v", i)
+		}
+	}
+	used := make(map[string]bool)
+	for i := range ap.scrollName {
+		name := makeScrollName(rnd)
+		for used[name] {
+			name = makeScrollName(rnd)
+		}
+		used[name] = true
+		ap.scrollName[i] = fmt.Sprintf("scroll labeled %v", name)
+	}
+	return ap
+}
+
+// makeScrollName 2 word of 1~2 syllable
+func makeScrollName(rnd *g2rand.G2Rand) string {
+	var words []string
+	for w := 0; w < 2; w++ {
+		var sb strings.Builder
+		for s := 1 + rnd.Intn(2); s > 0; s-- {
+			sb.WriteString(scrollSyllables[rnd.Intn(len(scrollSyllables))])
+		}
+		words = append(words, sb.String())
+	}
+	return strings.Join(words, " ")
+}
+
+func (ap *Appearance) PotionName(pt potiontype.PotionType) string {
+	return ap.potionName[pt]
+}
+
+func (ap *Appearance) ScrollName(st scrolltype.ScrollType) string {
+	return ap.scrollName[st]
+}
+
+// MaskPotionClient hide PotionType if not identified
+func (ap *Appearance) MaskPotionClient(kn *Knowledge, pc *c2t_obj.PotionClient) *c2t_obj.PotionClient {
+	if !kn.KnowPotion(pc.PotionType) {
+		pc.Appearance = ap.potionName[pc.PotionType]
+		pc.PotionType = potiontype.Empty
+	}
+	return pc
+}
+
+// MaskScrollClient hide ScrollType if not identified
+func (ap *Appearance) MaskScrollClient(kn *Knowledge, sc *c2t_obj.ScrollClient) *c2t_obj.ScrollClient {
+	if !kn.KnowScroll(sc.ScrollType) {
+		sc.Appearance = ap.scrollName[sc.ScrollType]
+		sc.ScrollType = scrolltype.Empty
+	}
+	return sc
+}
+
+// MaskCarryObjClientOnFloor hide PotionType, ScrollType if not identified
+func (ap *Appearance) MaskCarryObjClientOnFloor(kn *Knowledge, co *c2t_obj.CarryObjClientOnFloor) *c2t_obj.CarryObjClientOnFloor {
+	switch co.CarryingObjectType {
+	case carryingobjecttype.Potion:
+		if !kn.KnowPotion(co.PotionType) {
+			co.Appearance = ap.potionName[co.PotionType]
+			co.PotionType = potiontype.Empty
+		}
+	case carryingobjecttype.Scroll:
+		if !kn.KnowScroll(co.ScrollType) {
+			co.Appearance = ap.scrollName[co.ScrollType]
+			co.ScrollType = scrolltype.Empty
+		}
+	}
+	return co
+}
+
+// Knowledge identified type of an ao, Empty is always known
+type Knowledge struct {
+	Potion [potiontype.PotionType_Count]bool
+	Scroll [scrolltype.ScrollType_Count]bool
+}
+
+func (kn *Knowledge) KnowPotion(pt potiontype.PotionType) bool {
+	return pt == potiontype.Empty || kn.Potion[pt]
+}
+
+func (kn *Knowledge) KnowScroll(st scrolltype.ScrollType) bool {
+	return st == scrolltype.Empty || kn.Scroll[st]
+}
+
+// IdentifyPotion return true if newly identified
+func (kn *Knowledge) IdentifyPotion(pt potiontype.PotionType) bool {
+	if kn.KnowPotion(pt) {
+		return false
+	}
+	kn.Potion[pt] = true
+	return true
+}
+
+// IdentifyScroll return true if newly identified
+func (kn *Knowledge) IdentifyScroll(st scrolltype.ScrollType) bool {
+	if kn.KnowScroll(st) {
+		return false
+	}
+	kn.Scroll[st] = true
+	return true
+}
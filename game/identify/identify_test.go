@@ -0,0 +1,69 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identify
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+func TestAppearanceUnique(t *testing.T) {
+	ap := NewAppearanceBySeed(1)
+	used := make(map[string]bool)
+	for i := 0; i < potiontype.PotionType_Count; i++ {
+		name := ap.PotionName(potiontype.PotionType(i))
+		if used[name] {
+			t.Errorf("dup potion appearance %v", name)
+		}
+		used[name] = true
+	}
+	for i := 0; i < scrolltype.ScrollType_Count; i++ {
+		name := ap.ScrollName(scrolltype.ScrollType(i))
+		if used[name] {
+			t.Errorf("dup scroll appearance %v", name)
+		}
+		used[name] = true
+	}
+	ap2 := NewAppearanceBySeed(1)
+	if *ap != *ap2 {
+		t.Errorf("same seed make different appearance")
+	}
+}
+
+func TestMask(t *testing.T) {
+	ap := NewAppearanceBySeed(1)
+	kn := &Knowledge{}
+
+	pc := ap.MaskPotionClient(kn, &c2t_obj.PotionClient{PotionType: potiontype.RecoverHP10})
+	if pc.PotionType != potiontype.Empty || pc.Appearance != ap.PotionName(potiontype.RecoverHP10) {
+		t.Errorf("not masked %v", pc)
+	}
+	pc = ap.MaskPotionClient(kn, &c2t_obj.PotionClient{PotionType: potiontype.Empty})
+	if pc.Appearance != "" {
+		t.Errorf("Empty masked %v", pc)
+	}
+
+	if !kn.IdentifyScroll(scrolltype.Teleport) || kn.IdentifyScroll(scrolltype.Teleport) {
+		t.Errorf("invalid IdentifyScroll result")
+	}
+	co := ap.MaskCarryObjClientOnFloor(kn, &c2t_obj.CarryObjClientOnFloor{
+		CarryingObjectType: carryingobjecttype.Scroll,
+		ScrollType:         scrolltype.Teleport,
+	})
+	if co.ScrollType != scrolltype.Teleport || co.Appearance != "" {
+		t.Errorf("identified masked %v", co)
+	}
+}
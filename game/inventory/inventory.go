@@ -28,6 +28,8 @@ type Inventory struct {
 	mutexBag         sync.RWMutex `prettystring:"hide"`
 	bag              map[string]gamei.CarryingObjectI
 	wallet           float64
+	ammo             int
+	keyring          map[string]bool // door id
 	poTotalWeight    float64
 	poTotalValue     float64
 }
@@ -36,14 +38,15 @@ func New(towerAchieveStat *towerachieve_vector.TowerAchieveVector) *Inventory {
 	return &Inventory{
 		bag:              make(map[string]gamei.CarryingObjectI),
 		wallet:           0,
+		keyring:          make(map[string]bool),
 		towerAchieveStat: towerAchieveStat,
 	}
 }
 
 func (inv *Inventory) String() string {
 	return fmt.Sprintf(
-		"Inventory[equip:%v bag:%v wallet:%v weight:%v]",
-		inv.GetEquipedCount(), len(inv.bag), inv.wallet, inv.GetTotalWeight())
+		"Inventory[equip:%v bag:%v wallet:%v ammo:%v key:%v weight:%v]",
+		inv.GetEquipedCount(), len(inv.bag), inv.wallet, inv.ammo, inv.getKeyCount(), inv.GetTotalWeight())
 }
 
 func (inv *Inventory) TotalCarryObjCount() int {
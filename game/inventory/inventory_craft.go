@@ -0,0 +1,273 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kasworld/goguelike/config/craftdata"
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/enum/equipslottype"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/game/gamei"
+)
+
+// Craft consume material in bag (not equipped) and add made carryobj to bag
+// nothing changed on error
+func (inv *Inventory) Craft(rcp *craftdata.Recipe) ([]gamei.CarryingObjectI, error) {
+	out := rcp.Output
+	switch out.CarryingObjectType {
+	default:
+		return nil, fmt.Errorf("not craftable output %v", rcp)
+	case carryingobjecttype.Equip, carryingobjecttype.Potion, carryingobjecttype.Scroll:
+	}
+	// select, check and change in one lock, nothing taken before all checked
+	inv.mutexBag.Lock()
+	materialList, err := inv.selectCraftMaterialNolock(rcp.Input)
+	if err != nil {
+		inv.mutexBag.Unlock()
+		return nil, err
+	}
+	madeList := makeCraftOutput(out, materialList)
+	for _, v := range madeList {
+		if _, exist := inv.bag[v.GetUUID()]; exist {
+			inv.mutexBag.Unlock()
+			return nil, fmt.Errorf("already owned %v", v)
+		}
+	}
+	for _, v := range materialList {
+		delete(inv.bag, v.GetUUID())
+	}
+	for _, v := range madeList {
+		inv.bag[v.GetUUID()] = v
+	}
+	inv.mutexBag.Unlock()
+	for _, v := range materialList {
+		inv.afterRemoveFromBag(v)
+	}
+	for _, v := range madeList {
+		inv.afterAddToBag(v) // made equip, potion, scroll only
+	}
+	return madeList, nil
+}
+
+// makeCraftOutput new carryobj of recipe output
+func makeCraftOutput(out craftdata.Material, materialList []gamei.CarryingObjectI) []gamei.CarryingObjectI {
+	var madeList []gamei.CarryingObjectI
+	switch out.CarryingObjectType {
+	case carryingobjecttype.Equip:
+		var usedEquip []gamei.EquipObjI
+		for _, v := range materialList {
+			if eq, ok := v.(gamei.EquipObjI); ok {
+				usedEquip = append(usedEquip, eq)
+			}
+		}
+		madeList = append(madeList, makeCraftEquip(usedEquip))
+	case carryingobjecttype.Potion:
+		for i := 0; i < out.Count; i++ {
+			madeList = append(madeList, carryingobject.NewPotion(out.PotionType))
+		}
+	case carryingobjecttype.Scroll:
+		for i := 0; i < out.Count; i++ {
+			madeList = append(madeList, carryingobject.NewScroll(out.ScrollType))
+		}
+	}
+	return madeList
+}
+
+// CountMaterial count potion, scroll in bag match material
+func (inv *Inventory) CountMaterial(mt craftdata.Material) int {
+	inv.mutexBag.RLock()
+	defer inv.mutexBag.RUnlock()
+	count := 0
+	for _, v := range inv.bag {
+		if matchCraftMaterial(mt, v) {
+			count++
+		}
+	}
+	return count
+}
+
+// RemoveMaterial remove all or nothing
+func (inv *Inventory) RemoveMaterial(mtList []craftdata.Material) error {
+	_, err := inv.takeCraftMaterial(mtList)
+	return err
+}
+
+// takeCraftMaterial select and remove material from bag in one lock
+// all or nothing
+func (inv *Inventory) takeCraftMaterial(mtList []craftdata.Material) ([]gamei.CarryingObjectI, error) {
+	inv.mutexBag.Lock()
+	materialList, err := inv.selectCraftMaterialNolock(mtList)
+	if err != nil {
+		inv.mutexBag.Unlock()
+		return nil, err
+	}
+	for _, v := range materialList {
+		delete(inv.bag, v.GetUUID())
+	}
+	inv.mutexBag.Unlock()
+	for _, v := range materialList {
+		inv.afterRemoveFromBag(v)
+	}
+	return materialList, nil
+}
+
+// makeCraftEquip same slot, faction of material, name of strongest
+func makeCraftEquip(usedEquip []gamei.EquipObjI) gamei.EquipObjI {
+	bestEquip := usedEquip[0]
+	best := bestEquip.ToPacket_EquipClient()
+	biasLen := 0.0
+	for _, v := range usedEquip {
+		ec := v.ToPacket_EquipClient()
+		biasLen += ec.BiasLen
+		if ec.BiasLen > best.BiasLen {
+			bestEquip, best = v, ec
+		}
+	}
+	return carryingobject.NewEquipObj(best.Name, bestEquip.GetMaterial(), best.Faction, best.EquipType,
+		biasLen*gameconst.CraftEquipBiasLenRate, gameconst.EquipDurabilityMax)
+}
+
+// selectCraftMaterialNolock return material in bag, error if insufficient
+// exact type material is selected before AnyType,
+// potion, scroll of lowest value, most common is selected first
+func (inv *Inventory) selectCraftMaterialNolock(mtList []craftdata.Material) ([]gamei.CarryingObjectI, error) {
+	bagList := inv.getBagListByValueNolock()
+	orderedList := make([]craftdata.Material, 0, len(mtList))
+	for _, mt := range mtList {
+		if !mt.AnyType {
+			orderedList = append(orderedList, mt)
+		}
+	}
+	for _, mt := range mtList {
+		if mt.AnyType {
+			orderedList = append(orderedList, mt)
+		}
+	}
+
+	selected := make(map[string]bool)
+	var rtn []gamei.CarryingObjectI
+	for _, mt := range orderedList {
+		var candidate []gamei.CarryingObjectI
+		if mt.CarryingObjectType == carryingobjecttype.Equip {
+			candidate = findCraftEquip(bagList, mt.Count)
+		} else {
+			for _, v := range bagList {
+				if !selected[v.GetUUID()] && matchCraftMaterial(mt, v) {
+					candidate = append(candidate, v)
+				}
+			}
+		}
+		if len(candidate) < mt.Count {
+			return nil, fmt.Errorf("insufficient material %v", mt)
+		}
+		for _, v := range candidate[:mt.Count] {
+			selected[v.GetUUID()] = true
+			rtn = append(rtn, v)
+		}
+	}
+	return rtn, nil
+}
+
+// getBagListByValueNolock sorted by value, common (high make rate) first,
+// then uuid to select same on same bag
+func (inv *Inventory) getBagListByValueNolock() []gamei.CarryingObjectI {
+	rtn := make([]gamei.CarryingObjectI, 0, len(inv.bag))
+	for _, v := range inv.bag {
+		rtn = append(rtn, v)
+	}
+	sort.Slice(rtn, func(i, j int) bool {
+		if rtn[i].GetValue() != rtn[j].GetValue() {
+			return rtn[i].GetValue() < rtn[j].GetValue()
+		}
+		if mi, mj := makeRateOf(rtn[i]), makeRateOf(rtn[j]); mi != mj {
+			return mi > mj
+		}
+		return rtn[i].GetUUID() < rtn[j].GetUUID()
+	})
+	return rtn
+}
+
+func makeRateOf(po gamei.CarryingObjectI) int {
+	switch o := po.(type) {
+	case gamei.PotionI:
+		return o.GetPotionType().MakeRate()
+	case gamei.ScrollI:
+		return o.GetScrollType().MakeRate()
+	}
+	return 0
+}
+
+// findCraftEquip return strongest count equip of same slot, faction
+// bagList sorted, first group in bagList win on same strength
+func findCraftEquip(bagList []gamei.CarryingObjectI, count int) []gamei.CarryingObjectI {
+	type eqKey struct {
+		slot    equipslottype.EquipSlotType
+		faction factiontype.FactionType
+	}
+	var keyList []eqKey
+	group := make(map[eqKey][]gamei.EquipObjI)
+	for _, v := range bagList {
+		eq, ok := v.(gamei.EquipObjI)
+		if !ok {
+			continue
+		}
+		ec := eq.ToPacket_EquipClient()
+		k := eqKey{ec.EquipType, ec.Faction}
+		if _, exist := group[k]; !exist {
+			keyList = append(keyList, k)
+		}
+		group[k] = append(group[k], eq)
+	}
+	biasLen := func(eq gamei.EquipObjI) float64 {
+		return eq.ToPacket_EquipClient().BiasLen
+	}
+	var best []gamei.EquipObjI
+	bestLen := 0.0
+	for _, k := range keyList {
+		eqList := group[k]
+		if len(eqList) < count {
+			continue
+		}
+		sort.SliceStable(eqList, func(i, j int) bool {
+			return biasLen(eqList[i]) > biasLen(eqList[j])
+		})
+		sumLen := 0.0
+		for _, v := range eqList[:count] {
+			sumLen += biasLen(v)
+		}
+		if best == nil || sumLen > bestLen {
+			best, bestLen = eqList[:count], sumLen
+		}
+	}
+	rtn := make([]gamei.CarryingObjectI, 0, len(best))
+	for _, v := range best {
+		rtn = append(rtn, v)
+	}
+	return rtn
+}
+
+func matchCraftMaterial(mt craftdata.Material, po gamei.CarryingObjectI) bool {
+	switch o := po.(type) {
+	case gamei.PotionI:
+		return mt.CarryingObjectType == carryingobjecttype.Potion &&
+			(mt.AnyType || o.GetPotionType() == mt.PotionType)
+	case gamei.ScrollI:
+		return mt.CarryingObjectType == carryingobjecttype.Scroll &&
+			(mt.AnyType || o.GetScrollType() == mt.ScrollType)
+	}
+	return false
+}
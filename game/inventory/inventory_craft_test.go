@@ -0,0 +1,68 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/config/craftdata"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/towerachieve_vector"
+	"github.com/kasworld/goguelike/game/carryingobject"
+)
+
+func TestCraft_ConsumeMaterial(t *testing.T) {
+	inv := New(new(towerachieve_vector.TowerAchieveVector))
+	for _, pt := range []potiontype.PotionType{
+		potiontype.RecoverHPFull, potiontype.RecoverHP10, potiontype.RecoverHP10,
+	} {
+		if err := inv.AddToBag(carryingobject.NewPotion(pt)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rcp, err := craftdata.ParseRecipe("HP50 Potion,Potion:RecoverHP10 Potion:RecoverHP50")
+	if err != nil {
+		t.Fatal(err)
+	}
+	madeList, err := inv.Craft(rcp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(madeList) != 1 {
+		t.Fatalf("made %v", madeList)
+	}
+	// exact type taken first, rare potion not used as any type
+	count := make(map[potiontype.PotionType]int)
+	for _, v := range inv.GetPotionList() {
+		count[v.GetPotionType()]++
+	}
+	if len(inv.GetPotionList()) != 2 ||
+		count[potiontype.RecoverHPFull] != 1 || count[potiontype.RecoverHP50] != 1 {
+		t.Errorf("bag after craft %v", count)
+	}
+}
+
+func TestCraft_InsufficientNoChange(t *testing.T) {
+	inv := New(new(towerachieve_vector.TowerAchieveVector))
+	inv.AddToBag(carryingobject.NewPotion(potiontype.RecoverHP10))
+	inv.AddToBag(carryingobject.NewPotion(potiontype.Empty))
+	rcp, err := craftdata.ParseRecipe("HP50 Potion:RecoverHP10,Potion:Empty*2 Potion:RecoverHP50")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inv.Craft(rcp); err == nil {
+		t.Fatal("craft without material")
+	}
+	if n := inv.GetBagCount(); n != 2 {
+		t.Errorf("material consumed on fail %v", n)
+	}
+}
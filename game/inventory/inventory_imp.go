@@ -13,6 +13,7 @@ package inventory
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/kasworld/goguelike/config/gameconst"
 	"github.com/kasworld/goguelike/enum/equipslottype"
@@ -144,22 +145,31 @@ func (inv *Inventory) GetScrollList() []gamei.ScrollI {
 
 func (inv *Inventory) GetTotalWeight() float64 {
 	rtn := float64(inv.wallet)*gameconst.MoneyGram +
+		float64(inv.ammo)*gameconst.AmmoGram +
+		float64(inv.getKeyCount())*gameconst.KeyGram +
 		inv.poTotalWeight
 	return rtn
 }
 
 func (inv *Inventory) GetTotalValue() float64 {
-	rtn := float64(inv.wallet) + inv.poTotalValue
+	rtn := float64(inv.wallet) + float64(inv.ammo)*gameconst.AmmoValue +
+		inv.poTotalValue
 	return rtn
 }
 
 func (inv *Inventory) AddToBag(po gamei.CarryingObjectI) error {
 	inv.mutexBag.Lock()
 	if _, exist := inv.bag[po.GetUUID()]; exist {
+		inv.mutexBag.Unlock()
 		return fmt.Errorf("already owned %v", po)
 	}
 	inv.bag[po.GetUUID()] = po
 	inv.mutexBag.Unlock()
+	return inv.afterAddToBag(po)
+}
+
+// afterAddToBag update sum, stat of added carryobj
+func (inv *Inventory) afterAddToBag(po gamei.CarryingObjectI) error {
 	inv.poTotalWeight += po.GetWeight()
 	inv.poTotalValue += po.GetValue()
 	switch po.(type) {
@@ -193,6 +203,54 @@ func (inv *Inventory) RecycleCarryObjByID(poid string) (float64, error) {
 	inv.AddToWallet(carryingobject.NewMoney(recycleValue))
 	return recycleValue, nil
 }
+
+// BuyCarryObj pay price from wallet and add to bag
+func (inv *Inventory) BuyCarryObj(po gamei.CarryingObjectI, price float64) error {
+	if err := inv.SubFromWallet(carryingobject.NewMoney(price)); err != nil {
+		return err
+	}
+	if err := inv.AddToBag(po); err != nil {
+		inv.AddToWallet(carryingobject.NewMoney(price))
+		return err
+	}
+	inv.towerAchieveStat.Inc(towerachieve.ShopBuy)
+	inv.towerAchieveStat.Add(towerachieve.ShopMoneyOut, price)
+	return nil
+}
+
+// WearEquipByHit dec durability of equipped attack(hit give) or defence(hit take) slot
+// broken equip removed from inventory and returned
+func (inv *Inventory) WearEquipByHit(attack bool) []gamei.EquipObjI {
+	var broken []gamei.EquipObjI
+	for i, po := range inv.GetEquipSlot() {
+		if po == nil {
+			continue
+		}
+		eqslot := equipslottype.EquipSlotType(i)
+		if attack && !eqslot.Attack() || !attack && !eqslot.Defence() {
+			continue
+		}
+		if po.DecDurability(1) > 0 {
+			continue
+		}
+		inv.RemoveByUUID(po.GetUUID())
+		inv.towerAchieveStat.Inc(towerachieve.EquipBroken)
+		broken = append(broken, po)
+	}
+	return broken
+}
+
+// RepairEquip pay repair cost from wallet and make durability max
+func (inv *Inventory) RepairEquip(eq gamei.EquipObjI) (float64, error) {
+	cost := eq.GetRepairCost()
+	if err := inv.SubFromWallet(carryingobject.NewMoney(cost)); err != nil {
+		return 0, err
+	}
+	eq.Repair()
+	inv.towerAchieveStat.Add(towerachieve.RepairMoneyOut, cost)
+	return cost, nil
+}
+
 func (inv *Inventory) AddToWallet(po gamei.MoneyI) error {
 	inv.wallet += po.GetValue()
 	inv.towerAchieveStat.Add(towerachieve.MoneyIn, float64(po.GetValue()))
@@ -210,6 +268,62 @@ func (inv *Inventory) GetWalletValue() float64 {
 	return inv.wallet
 }
 
+func (inv *Inventory) AddAmmo(count int) {
+	inv.ammo += count
+}
+func (inv *Inventory) UseAmmo(count int) error {
+	if inv.ammo < count {
+		return fmt.Errorf("insufficient ammo %v %v", inv.ammo, count)
+	}
+	inv.ammo -= count
+	return nil
+}
+func (inv *Inventory) GetAmmoCount() int {
+	return inv.ammo
+}
+
+func (inv *Inventory) AddKey(keyID string) {
+	inv.mutexBag.Lock()
+	defer inv.mutexBag.Unlock()
+	inv.keyring[keyID] = true
+}
+func (inv *Inventory) HasKey(keyID string) bool {
+	inv.mutexBag.RLock()
+	defer inv.mutexBag.RUnlock()
+	return inv.keyring[keyID]
+}
+
+func (inv *Inventory) getKeyCount() int {
+	inv.mutexBag.RLock()
+	defer inv.mutexBag.RUnlock()
+	return len(inv.keyring)
+}
+
+// GetKeyList sorted door id
+func (inv *Inventory) GetKeyList() []string {
+	inv.mutexBag.RLock()
+	rtn := make([]string, 0, len(inv.keyring))
+	for k := range inv.keyring {
+		rtn = append(rtn, k)
+	}
+	inv.mutexBag.RUnlock()
+	sort.Strings(rtn)
+	return rtn
+}
+
+// RemoveAllKey empty keyring, return removed door id sorted
+func (inv *Inventory) RemoveAllKey() []string {
+	inv.mutexBag.Lock()
+	rtn := make([]string, 0, len(inv.keyring))
+	for k := range inv.keyring {
+		rtn = append(rtn, k)
+	}
+	inv.keyring = make(map[string]bool)
+	inv.mutexBag.Unlock()
+	sort.Strings(rtn)
+	return rtn
+}
+
 func (inv *Inventory) EquipFromBagByUUID(id string) error {
 	inv.mutexBag.Lock()
 	defer inv.mutexBag.Unlock()
@@ -255,12 +369,17 @@ func (inv *Inventory) RemoveByUUID(poid string) gamei.CarryingObjectI {
 	}
 	delete(inv.bag, poid)
 	inv.mutexBag.Unlock()
+	inv.afterRemoveFromBag(po)
+	return po
+}
+
+// afterRemoveFromBag update sum, stat of removed carryobj
+func (inv *Inventory) afterRemoveFromBag(po gamei.CarryingObjectI) {
 	inv.poTotalWeight -= po.GetWeight()
 	inv.poTotalValue -= po.GetValue()
 	switch po.(type) {
 	default:
 		fmt.Printf("unknown obj %v", po)
-		return po
 	case gamei.EquipObjI:
 		inv.towerAchieveStat.Inc(towerachieve.EquipOut)
 	case gamei.PotionI:
@@ -268,5 +387,4 @@ func (inv *Inventory) RemoveByUUID(poid string) gamei.CarryingObjectI {
 	case gamei.ScrollI:
 		inv.towerAchieveStat.Inc(towerachieve.ScrollOut)
 	}
-	return po
 }
@@ -0,0 +1,141 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/game/carryingobject"
+	"github.com/kasworld/goguelike/game/gamei"
+)
+
+// OfferWeight return weight of carryobj and money to give
+// error if not owned
+func OfferWeight(inv gamei.InventoryI, poidList []string, money float64) (float64, error) {
+	if money < 0 || math.IsNaN(money) || math.IsInf(money, 0) {
+		return 0, fmt.Errorf("invalid money %v", money)
+	}
+	if inv.GetWalletValue() < money {
+		return 0, fmt.Errorf("insufficient money %v %v", inv.GetWalletValue(), money)
+	}
+	weight := money * gameconst.MoneyGram
+	checked := make(map[string]bool, len(poidList))
+	for _, poid := range poidList {
+		if checked[poid] {
+			return 0, fmt.Errorf("duplicate carryobj %v", poid)
+		}
+		checked[poid] = true
+		po := inv.GetByUUID(poid)
+		if po == nil {
+			return 0, fmt.Errorf("not in inventory %v", poid)
+		}
+		weight += po.GetWeight()
+	}
+	return weight, nil
+}
+
+// Exchange give offer of each inventory to other
+// both offer validated before any change, restored if fail while change
+// weight limit is checked by caller
+func Exchange(
+	inv1 gamei.InventoryI, poidList1 []string, money1 float64,
+	inv2 gamei.InventoryI, poidList2 []string, money2 float64) error {
+
+	if _, err := OfferWeight(inv1, poidList1, money1); err != nil {
+		return err
+	}
+	if _, err := OfferWeight(inv2, poidList2, money2); err != nil {
+		return err
+	}
+	if err := checkReceivable(inv1, inv2, poidList1); err != nil {
+		return err
+	}
+	if err := checkReceivable(inv2, inv1, poidList2); err != nil {
+		return err
+	}
+	poList1 := removeList(inv1, poidList1)
+	poList2 := removeList(inv2, poidList2)
+	if added, err := addList(inv2, poList1); err != nil {
+		removeList(inv2, getUUIDList(poList1[:added]))
+		restoreList(inv1, poList1)
+		restoreList(inv2, poList2)
+		return err
+	}
+	if added, err := addList(inv1, poList2); err != nil {
+		removeList(inv1, getUUIDList(poList2[:added]))
+		removeList(inv2, getUUIDList(poList1))
+		restoreList(inv1, poList1)
+		restoreList(inv2, poList2)
+		return err
+	}
+	if money1 > 0 {
+		m := carryingobject.NewMoney(money1)
+		inv1.SubFromWallet(m)
+		inv2.AddToWallet(m)
+	}
+	if money2 > 0 {
+		m := carryingobject.NewMoney(money2)
+		inv2.SubFromWallet(m)
+		inv1.AddToWallet(m)
+	}
+	return nil
+}
+
+// checkReceivable check carryobj of src can be added to bag of dst
+func checkReceivable(src, dst gamei.InventoryI, poidList []string) error {
+	for _, poid := range poidList {
+		switch po := src.GetByUUID(poid); po.(type) {
+		default:
+			return fmt.Errorf("not tradable %v", po)
+		case gamei.EquipObjI, gamei.PotionI, gamei.ScrollI:
+		}
+		if dst.GetByUUID(poid) != nil {
+			return fmt.Errorf("already owned %v", poid)
+		}
+	}
+	return nil
+}
+
+func removeList(inv gamei.InventoryI, poidList []string) []gamei.CarryingObjectI {
+	rtn := make([]gamei.CarryingObjectI, 0, len(poidList))
+	for _, poid := range poidList {
+		rtn = append(rtn, inv.RemoveByUUID(poid))
+	}
+	return rtn
+}
+
+// addList return added count
+func addList(inv gamei.InventoryI, poList []gamei.CarryingObjectI) (int, error) {
+	for i, po := range poList {
+		if err := inv.AddToBag(po); err != nil {
+			return i, err
+		}
+	}
+	return len(poList), nil
+}
+
+// restoreList add back removed carryobj, equipped is restored to bag
+func restoreList(inv gamei.InventoryI, poList []gamei.CarryingObjectI) {
+	for _, po := range poList {
+		inv.AddToBag(po)
+	}
+}
+
+func getUUIDList(poList []gamei.CarryingObjectI) []string {
+	rtn := make([]string, 0, len(poList))
+	for _, po := range poList {
+		rtn = append(rtn, po.GetUUID())
+	}
+	return rtn
+}
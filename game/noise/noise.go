@@ -0,0 +1,96 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package noise server side noise made by ao act and fieldobj
+// heard level = radius - distance - sum of BlockNoise of tiles between, heard if > 0
+package noise
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/kasworld/goguelike/enum/noisetype"
+	"github.com/kasworld/goguelike/game/tilearea"
+)
+
+func (ns Noise) String() string {
+	return fmt.Sprintf("Noise[%v [%v %v] %v]", ns.NoiseType, ns.X, ns.Y, ns.Radius)
+}
+
+// Noise made in floor in a turn
+type Noise struct {
+	NoiseType noisetype.NoiseType
+	X, Y      int
+	Radius    float64
+	SrcUUID   string // ao made noise, not heard by self
+	Hidden    bool   // source not visible even in sight, invisible ao
+}
+
+// Heard noise heard by ao
+type Heard struct {
+	NoiseType noisetype.NoiseType
+	X, Y      int
+	Level     float64
+}
+
+// wrapDelta shortest delta in wrapped length l
+func wrapDelta(d, l int) int {
+	d %= l
+	if d > l/2 {
+		d -= l
+	} else if d < -l/2 {
+		d += l
+	}
+	return d
+}
+
+// LevelAt heard level at x,y in wrapped tiles, <= 0 not heard
+func (ns Noise) LevelAt(tiles tilearea.TileArea, x, y int) float64 {
+	w, h := len(tiles), len(tiles[0])
+	dx := wrapDelta(x-ns.X, w)
+	dy := wrapDelta(y-ns.Y, h)
+	dist := math.Sqrt(float64(dx*dx + dy*dy))
+	level := ns.Radius - dist
+	if level <= 0 {
+		return level
+	}
+	steps := dx
+	if steps < 0 {
+		steps = -steps
+	}
+	if ady := int(math.Abs(float64(dy))); ady > steps {
+		steps = ady
+	}
+	// tiles between source and listener, not include both end
+	for i := 1; i < steps; i++ {
+		px := ns.X + int(math.Round(float64(dx*i)/float64(steps)))
+		py := ns.Y + int(math.Round(float64(dy*i)/float64(steps)))
+		px = (px%w + w) % w
+		py = (py%h + h) % h
+		level -= tiles[px][py].BlockNoise()
+		if level <= 0 {
+			return level
+		}
+	}
+	return level
+}
+
+// SortHeard loud first, trim to max
+func SortHeard(hl []Heard, max int) []Heard {
+	sort.Slice(hl, func(i, j int) bool {
+		return hl[i].Level > hl[j].Level
+	})
+	if len(hl) > max {
+		hl = hl[:max]
+	}
+	return hl
+}
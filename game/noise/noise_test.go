@@ -0,0 +1,50 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package noise
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/enum/noisetype"
+	"github.com/kasworld/goguelike/enum/tile"
+	"github.com/kasworld/goguelike/game/tilearea"
+)
+
+func TestLevelAt(t *testing.T) {
+	ta := tilearea.New(32, 32)
+	ns := Noise{NoiseType: noisetype.Attack, X: 1, Y: 1, Radius: 8}
+	if lv := ns.LevelAt(ta, 5, 1); lv != 4 {
+		t.Errorf("open level %v", lv)
+	}
+	// wrapped distance
+	if lv := ns.LevelAt(ta, 30, 1); lv != 5 {
+		t.Errorf("wrapped level %v", lv)
+	}
+	if lv := ns.LevelAt(ta, 20, 1); lv > 0 {
+		t.Errorf("far level %v", lv)
+	}
+	ta[3][1].SetByTile(tile.Wall)
+	if lv := ns.LevelAt(ta, 5, 1); lv > 0 {
+		t.Errorf("wall level %v", lv)
+	}
+	ta[3][1].OverrideBits(tile.Window)
+	if lv := ns.LevelAt(ta, 5, 1); lv != 1 {
+		t.Errorf("window level %v", lv)
+	}
+}
+
+func TestSortHeard(t *testing.T) {
+	hl := SortHeard([]Heard{{Level: 1}, {Level: 3}, {Level: 2}}, 2)
+	if len(hl) != 2 || hl[0].Level != 3 || hl[1].Level != 2 {
+		t.Errorf("%v", hl)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package objlistdelta make/apply delta of ObjectList noti
+// server send delta from last ObjectList client acked, or full keyframe
+package objlistdelta
+
+import (
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+// MakeDelta make delta to change base to ol
+func MakeDelta(base, ol *c2t_obj.NotiObjectList_data) *c2t_obj.NotiObjectListDelta_data {
+	d := &c2t_obj.NotiObjectListDelta_data{
+		Time:      ol.Time,
+		FloorName: ol.FloorName,
+		Seq:       ol.Seq,
+		BaseSeq:   base.Seq,
+		ActiveObj: ol.ActiveObj,
+	}
+
+	oldAO := make(map[string]*c2t_obj.ActiveObjClient, len(base.ActiveObjList))
+	for _, v := range base.ActiveObjList {
+		oldAO[v.UUID] = v
+	}
+	for _, v := range ol.ActiveObjList {
+		if o, exist := oldAO[v.UUID]; !exist || !equalActiveObjClient(o, v) {
+			d.ActiveObjList = append(d.ActiveObjList, v)
+		}
+		delete(oldAO, v.UUID)
+	}
+	for id := range oldAO {
+		d.ActiveObjDelList = append(d.ActiveObjDelList, id)
+	}
+
+	oldCO := make(map[string]*c2t_obj.CarryObjClientOnFloor, len(base.CarryObjList))
+	for _, v := range base.CarryObjList {
+		oldCO[v.UUID] = v
+	}
+	for _, v := range ol.CarryObjList {
+		if o, exist := oldCO[v.UUID]; !exist || *o != *v {
+			d.CarryObjList = append(d.CarryObjList, v)
+		}
+		delete(oldCO, v.UUID)
+	}
+	for id := range oldCO {
+		d.CarryObjDelList = append(d.CarryObjDelList, id)
+	}
+
+	oldFO := make(map[string]*c2t_obj.FieldObjClient, len(base.FieldObjList))
+	for _, v := range base.FieldObjList {
+		oldFO[v.ID] = v
+	}
+	for _, v := range ol.FieldObjList {
+		if o, exist := oldFO[v.ID]; !exist || *o != *v {
+			d.FieldObjList = append(d.FieldObjList, v)
+		}
+		delete(oldFO, v.ID)
+	}
+	for id := range oldFO {
+		d.FieldObjDelList = append(d.FieldObjDelList, id)
+	}
+
+	oldDO := make(map[string]*c2t_obj.DangerObjClient, len(base.DangerObjList))
+	for _, v := range base.DangerObjList {
+		oldDO[v.UUID] = v
+	}
+	for _, v := range ol.DangerObjList {
+		if o, exist := oldDO[v.UUID]; !exist || *o != *v {
+			d.DangerObjList = append(d.DangerObjList, v)
+		}
+		delete(oldDO, v.UUID)
+	}
+	for id := range oldDO {
+		d.DangerObjDelList = append(d.DangerObjDelList, id)
+	}
+	return d
+}
+
+// ApplyDelta make new ObjectList from base and delta, base not changed
+func ApplyDelta(base *c2t_obj.NotiObjectList_data, d *c2t_obj.NotiObjectListDelta_data) *c2t_obj.NotiObjectList_data {
+	ol := &c2t_obj.NotiObjectList_data{
+		Time:      d.Time,
+		FloorName: d.FloorName,
+		Seq:       d.Seq,
+		ActiveObj: d.ActiveObj,
+	}
+
+	delAO := makeIDSet(d.ActiveObjDelList)
+	updateAO := make(map[string]*c2t_obj.ActiveObjClient, len(d.ActiveObjList))
+	for _, v := range d.ActiveObjList {
+		updateAO[v.UUID] = v
+	}
+	for _, v := range base.ActiveObjList {
+		if delAO[v.UUID] {
+			continue
+		}
+		if n, exist := updateAO[v.UUID]; exist {
+			v = n
+			delete(updateAO, v.UUID)
+		}
+		ol.ActiveObjList = append(ol.ActiveObjList, v)
+	}
+	for _, v := range d.ActiveObjList {
+		if _, exist := updateAO[v.UUID]; exist {
+			ol.ActiveObjList = append(ol.ActiveObjList, v)
+		}
+	}
+
+	delCO := makeIDSet(d.CarryObjDelList)
+	updateCO := make(map[string]*c2t_obj.CarryObjClientOnFloor, len(d.CarryObjList))
+	for _, v := range d.CarryObjList {
+		updateCO[v.UUID] = v
+	}
+	for _, v := range base.CarryObjList {
+		if delCO[v.UUID] {
+			continue
+		}
+		if n, exist := updateCO[v.UUID]; exist {
+			v = n
+			delete(updateCO, v.UUID)
+		}
+		ol.CarryObjList = append(ol.CarryObjList, v)
+	}
+	for _, v := range d.CarryObjList {
+		if _, exist := updateCO[v.UUID]; exist {
+			ol.CarryObjList = append(ol.CarryObjList, v)
+		}
+	}
+
+	delFO := makeIDSet(d.FieldObjDelList)
+	updateFO := make(map[string]*c2t_obj.FieldObjClient, len(d.FieldObjList))
+	for _, v := range d.FieldObjList {
+		updateFO[v.ID] = v
+	}
+	for _, v := range base.FieldObjList {
+		if delFO[v.ID] {
+			continue
+		}
+		if n, exist := updateFO[v.ID]; exist {
+			v = n
+			delete(updateFO, v.ID)
+		}
+		ol.FieldObjList = append(ol.FieldObjList, v)
+	}
+	for _, v := range d.FieldObjList {
+		if _, exist := updateFO[v.ID]; exist {
+			ol.FieldObjList = append(ol.FieldObjList, v)
+		}
+	}
+
+	delDO := makeIDSet(d.DangerObjDelList)
+	updateDO := make(map[string]*c2t_obj.DangerObjClient, len(d.DangerObjList))
+	for _, v := range d.DangerObjList {
+		updateDO[v.UUID] = v
+	}
+	for _, v := range base.DangerObjList {
+		if delDO[v.UUID] {
+			continue
+		}
+		if n, exist := updateDO[v.UUID]; exist {
+			v = n
+			delete(updateDO, v.UUID)
+		}
+		ol.DangerObjList = append(ol.DangerObjList, v)
+	}
+	for _, v := range d.DangerObjList {
+		if _, exist := updateDO[v.UUID]; exist {
+			ol.DangerObjList = append(ol.DangerObjList, v)
+		}
+	}
+	return ol
+}
+
+func makeIDSet(idList []string) map[string]bool {
+	rtn := make(map[string]bool, len(idList))
+	for _, v := range idList {
+		rtn[v] = true
+	}
+	return rtn
+}
+
+// equalActiveObjClient compare all field, EquippedPo by value
+// add new field here, TestEqualActiveObjClientAllField fail if missing
+func equalActiveObjClient(a, b *c2t_obj.ActiveObjClient) bool {
+	if a.UUID != b.UUID ||
+		a.NickName != b.NickName ||
+		a.Faction != b.Faction ||
+		a.Conditions != b.Conditions ||
+		a.X != b.X ||
+		a.Y != b.Y ||
+		a.Alive != b.Alive ||
+		a.Chat != b.Chat ||
+		a.OwnerUUID != b.OwnerUUID ||
+		a.Act != b.Act ||
+		a.Dir != b.Dir ||
+		a.Result != b.Result ||
+		a.DamageGive != b.DamageGive ||
+		a.DamageTake != b.DamageTake {
+		return false
+	}
+	if len(a.EquippedPo) != len(b.EquippedPo) {
+		return false
+	}
+	for i, v := range a.EquippedPo {
+		if *v != *b.EquippedPo[i] {
+			return false
+		}
+	}
+	return true
+}
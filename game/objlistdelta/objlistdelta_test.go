@@ -0,0 +1,141 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objlistdelta
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+func TestMakeApplyDelta(t *testing.T) {
+	base := &c2t_obj.NotiObjectList_data{
+		FloorName: "test",
+		Seq:       1,
+		ActiveObjList: []*c2t_obj.ActiveObjClient{
+			{UUID: "ao1", X: 1, Y: 1},
+			{UUID: "ao2", X: 2, Y: 2},
+		},
+		CarryObjList: []*c2t_obj.CarryObjClientOnFloor{
+			{UUID: "co1", X: 3, Y: 3},
+		},
+	}
+	ol := &c2t_obj.NotiObjectList_data{
+		FloorName: "test",
+		Seq:       2,
+		ActiveObjList: []*c2t_obj.ActiveObjClient{
+			{UUID: "ao1", X: 1, Y: 1},
+			{UUID: "ao2", X: 2, Y: 3},
+			{UUID: "ao3", X: 4, Y: 4},
+		},
+	}
+	d := MakeDelta(base, ol)
+	if len(d.ActiveObjList) != 2 || len(d.CarryObjDelList) != 1 {
+		t.Fatalf("invalid delta %+v", d)
+	}
+	rd := NewReceiver()
+	rd.AddKeyframe(base)
+	ol2, err := rd.Apply(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ol2.ActiveObjList) != 3 || len(ol2.CarryObjList) != 0 {
+		t.Fatalf("invalid apply %+v", ol2)
+	}
+	for i, v := range ol2.ActiveObjList {
+		if !equalActiveObjClient(v, ol.ActiveObjList[i]) {
+			t.Errorf("not same %v %v", v, ol.ActiveObjList[i])
+		}
+	}
+}
+
+func TestDeltaPetOwnerChange(t *testing.T) {
+	base := &c2t_obj.NotiObjectList_data{
+		FloorName: "test",
+		Seq:       1,
+		ActiveObjList: []*c2t_obj.ActiveObjClient{
+			{UUID: "owner", X: 1, Y: 1},
+			{UUID: "pet", X: 2, Y: 2},
+		},
+	}
+	// pet bound, nothing else changed
+	ol := &c2t_obj.NotiObjectList_data{
+		FloorName: "test",
+		Seq:       2,
+		ActiveObjList: []*c2t_obj.ActiveObjClient{
+			{UUID: "owner", X: 1, Y: 1},
+			{UUID: "pet", X: 2, Y: 2, OwnerUUID: "owner"},
+		},
+	}
+	d := MakeDelta(base, ol)
+	if len(d.ActiveObjList) != 1 || d.ActiveObjList[0].OwnerUUID != "owner" {
+		t.Fatalf("owner change not in delta %+v", d)
+	}
+	rd := NewReceiver()
+	rd.AddKeyframe(base)
+	ol2, err := rd.Apply(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ol2.ActiveObjList[1].OwnerUUID != "owner" {
+		t.Errorf("owner not applied %v", ol2.ActiveObjList[1])
+	}
+}
+
+// TestEqualActiveObjClientAllField fail if a field of ActiveObjClient or EquipClient
+// added but not compared in equalActiveObjClient
+func TestEqualActiveObjClientAllField(t *testing.T) {
+	newAO := func() *c2t_obj.ActiveObjClient {
+		return &c2t_obj.ActiveObjClient{
+			EquippedPo: []*c2t_obj.EquipClient{{}},
+		}
+	}
+	if !equalActiveObjClient(newAO(), newAO()) {
+		t.Fatal("same ao not equal")
+	}
+	aoType := reflect.TypeOf(c2t_obj.ActiveObjClient{})
+	for i := 0; i < aoType.NumField(); i++ {
+		b := newAO()
+		setNotZero(t, reflect.ValueOf(b).Elem().Field(i), aoType.Field(i).Name)
+		if equalActiveObjClient(newAO(), b) {
+			t.Errorf("ActiveObjClient.%v not compared", aoType.Field(i).Name)
+		}
+	}
+	eqType := reflect.TypeOf(c2t_obj.EquipClient{})
+	for i := 0; i < eqType.NumField(); i++ {
+		b := newAO()
+		setNotZero(t, reflect.ValueOf(b.EquippedPo[0]).Elem().Field(i), eqType.Field(i).Name)
+		if equalActiveObjClient(newAO(), b) {
+			t.Errorf("EquipClient.%v not compared", eqType.Field(i).Name)
+		}
+	}
+}
+
+func setNotZero(t *testing.T, v reflect.Value, name string) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("changed")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	case reflect.Slice:
+		v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+	default:
+		t.Fatalf("field %v kind %v not handled, add to test", name, v.Kind())
+	}
+}
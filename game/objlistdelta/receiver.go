@@ -0,0 +1,70 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objlistdelta
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+// Receiver keep ObjectList received, used by client to apply delta
+type Receiver struct {
+	seq2List map[int]*c2t_obj.NotiObjectList_data
+}
+
+func NewReceiver() *Receiver {
+	return &Receiver{
+		seq2List: make(map[int]*c2t_obj.NotiObjectList_data),
+	}
+}
+
+// AddKeyframe keep full ObjectList as delta base
+func (r *Receiver) AddKeyframe(ol *c2t_obj.NotiObjectList_data) {
+	if ol.Seq == 0 {
+		return // not delta mode
+	}
+	r.add(ol)
+}
+
+// Apply make full ObjectList from delta
+func (r *Receiver) Apply(d *c2t_obj.NotiObjectListDelta_data) (*c2t_obj.NotiObjectList_data, error) {
+	base, exist := r.seq2List[d.BaseSeq]
+	if !exist {
+		return nil, fmt.Errorf("base ObjectList not found %v %v", d.BaseSeq, d.Seq)
+	}
+	ol := ApplyDelta(base, d)
+	// server not use base older than acked
+	for k := range r.seq2List {
+		if k < d.BaseSeq {
+			delete(r.seq2List, k)
+		}
+	}
+	r.add(ol)
+	return ol, nil
+}
+
+func (r *Receiver) add(ol *c2t_obj.NotiObjectList_data) {
+	r.seq2List[ol.Seq] = ol
+	if len(r.seq2List) > maxUnacked {
+		for k := range r.seq2List {
+			if k <= ol.Seq-maxUnacked {
+				delete(r.seq2List, k)
+			}
+		}
+	}
+}
+
+// Reset forget all, call on reconnect
+func (r *Receiver) Reset() {
+	r.seq2List = make(map[int]*c2t_obj.NotiObjectList_data)
+}
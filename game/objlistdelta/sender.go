@@ -0,0 +1,93 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objlistdelta
+
+import (
+	"sync"
+
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+// max ObjectList kept waiting ack
+const maxUnacked = 64
+
+// Sender keep ObjectList sent to a client, zero value is ready to use
+type Sender struct {
+	mutex         sync.Mutex `prettystring:"hide"`
+	lastSeq       int
+	ackSeq        int
+	sinceKeyframe int
+	seq2Sent      map[int]*c2t_obj.NotiObjectList_data `prettystring:"hide"`
+}
+
+// Make set Seq of ol and return noti to send
+// keyframeTurn : send full ObjectList at least every keyframeTurn, 0 to disable delta
+func (s *Sender) Make(ol *c2t_obj.NotiObjectList_data, keyframeTurn int) (
+	c2t_idnoti.NotiID, interface{}) {
+
+	if keyframeTurn <= 0 {
+		return c2t_idnoti.ObjectList, ol
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.seq2Sent == nil {
+		s.seq2Sent = make(map[int]*c2t_obj.NotiObjectList_data)
+	}
+	s.lastSeq++
+	ol.Seq = s.lastSeq
+	s.seq2Sent[ol.Seq] = ol
+	if len(s.seq2Sent) > maxUnacked {
+		// client not ack, forget oldest
+		for seq := range s.seq2Sent {
+			if seq <= ol.Seq-maxUnacked {
+				delete(s.seq2Sent, seq)
+			}
+		}
+	}
+
+	base, exist := s.seq2Sent[s.ackSeq]
+	if !exist || base.FloorName != ol.FloorName || s.sinceKeyframe >= keyframeTurn {
+		s.sinceKeyframe = 0
+		return c2t_idnoti.ObjectList, ol
+	}
+	s.sinceKeyframe++
+	return c2t_idnoti.ObjectListDelta, MakeDelta(base, ol)
+}
+
+// Ack client has ObjectList of seq, can be used as delta base
+func (s *Sender) Ack(seq int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if seq <= s.ackSeq {
+		return
+	}
+	if _, exist := s.seq2Sent[seq]; !exist {
+		return
+	}
+	s.ackSeq = seq
+	for k := range s.seq2Sent {
+		if k < seq {
+			delete(s.seq2Sent, k)
+		}
+	}
+}
+
+// Reset forget all sent, next Make send keyframe
+// call when client connection changed
+func (s *Sender) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.ackSeq = 0
+	s.sinceKeyframe = 0
+	s.seq2Sent = nil
+}
@@ -34,15 +34,23 @@ func Play(name string) {
 
 var act2name = map[c2t_idcmd.CommandID]string{
 	// c2t_idcmd.KillSelf:    "",
-	c2t_idcmd.Move:        "stepsound",
-	c2t_idcmd.Attack:      "attacksound",
-	c2t_idcmd.Pickup:      "pickupsound",
-	c2t_idcmd.Drop:        "dropsound",
-	c2t_idcmd.Equip:       "equipsound",
-	c2t_idcmd.UnEquip:     "unequipsound",
-	c2t_idcmd.DrinkPotion: "usesound",
-	c2t_idcmd.ReadScroll:  "usesound",
-	c2t_idcmd.Recycle:     "recyclesound",
+	c2t_idcmd.Move:          "stepsound",
+	c2t_idcmd.Attack:        "attacksound",
+	c2t_idcmd.Shoot:         "attacksound",
+	c2t_idcmd.Pickup:        "pickupsound",
+	c2t_idcmd.Drop:          "dropsound",
+	c2t_idcmd.Equip:         "equipsound",
+	c2t_idcmd.UnEquip:       "unequipsound",
+	c2t_idcmd.DrinkPotion:   "usesound",
+	c2t_idcmd.ReadScroll:    "usesound",
+	c2t_idcmd.Recycle:       "recyclesound",
+	c2t_idcmd.Craft:         "usesound",
+	c2t_idcmd.Buy:           "pickupsound",
+	c2t_idcmd.Repair:        "usesound",
+	c2t_idcmd.CompleteQuest: "pickupsound",
+	c2t_idcmd.OpenDoor:      "usesound",
+	c2t_idcmd.CloseDoor:     "usesound",
+	c2t_idcmd.CommandPet:    "usesound",
 	// c2t_idcmd.EnterPortal: "",
 }
 
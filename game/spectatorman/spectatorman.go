@@ -0,0 +1,138 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spectatorman manage spectator connection following activeobject
+// spectator has no activeobject, only receive noti of followed ao
+package spectatorman
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_serveconnbyte"
+)
+
+type spectator struct {
+	conn       *c2t_serveconnbyte.ServeConnByte
+	aoUUID     string
+	autoFollow bool // follow top of exp ranking
+}
+
+func (sm *SpectatorManager) String() string {
+	return fmt.Sprintf("SpectatorManager[%v]", sm.Count())
+}
+
+type SpectatorManager struct {
+	mutex      sync.RWMutex                                           `prettystring:"hide"`
+	conn2Spec  map[string]*spectator                                  `prettystring:"hide"`
+	ao2ConnMap map[string]map[string]*c2t_serveconnbyte.ServeConnByte `prettystring:"hide"`
+}
+
+func New() *SpectatorManager {
+	return &SpectatorManager{
+		conn2Spec:  make(map[string]*spectator),
+		ao2ConnMap: make(map[string]map[string]*c2t_serveconnbyte.ServeConnByte),
+	}
+}
+
+func (sm *SpectatorManager) Count() int {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+	return len(sm.conn2Spec)
+}
+
+// Follow set ao to follow, replace old one
+func (sm *SpectatorManager) Follow(connUUID string,
+	conn *c2t_serveconnbyte.ServeConnByte, aoUUID string, autoFollow bool) {
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	sm.delNolock(connUUID)
+	sm.conn2Spec[connUUID] = &spectator{
+		conn:       conn,
+		aoUUID:     aoUUID,
+		autoFollow: autoFollow,
+	}
+	sm.addToAONolock(connUUID, conn, aoUUID)
+}
+
+// Del remove spectator, call on disconnect
+func (sm *SpectatorManager) Del(connUUID string) {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	sm.delNolock(connUUID)
+}
+
+func (sm *SpectatorManager) delNolock(connUUID string) {
+	sp, exist := sm.conn2Spec[connUUID]
+	if !exist {
+		return
+	}
+	delete(sm.conn2Spec, connUUID)
+	sm.delFromAONolock(connUUID, sp.aoUUID)
+}
+
+func (sm *SpectatorManager) addToAONolock(connUUID string,
+	conn *c2t_serveconnbyte.ServeConnByte, aoUUID string) {
+	if aoUUID == "" {
+		return
+	}
+	connMap, exist := sm.ao2ConnMap[aoUUID]
+	if !exist {
+		connMap = make(map[string]*c2t_serveconnbyte.ServeConnByte)
+		sm.ao2ConnMap[aoUUID] = connMap
+	}
+	connMap[connUUID] = conn
+}
+
+func (sm *SpectatorManager) delFromAONolock(connUUID string, aoUUID string) {
+	connMap, exist := sm.ao2ConnMap[aoUUID]
+	if !exist {
+		return
+	}
+	delete(connMap, connUUID)
+	if len(connMap) == 0 {
+		delete(sm.ao2ConnMap, aoUUID)
+	}
+}
+
+// GetConnList return spectator connection following ao
+func (sm *SpectatorManager) GetConnList(aoUUID string) []*c2t_serveconnbyte.ServeConnByte {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+	connMap := sm.ao2ConnMap[aoUUID]
+	if len(connMap) == 0 {
+		return nil
+	}
+	rtn := make([]*c2t_serveconnbyte.ServeConnByte, 0, len(connMap))
+	for _, v := range connMap {
+		rtn = append(rtn, v)
+	}
+	return rtn
+}
+
+// UpdateAutoFollow change target of auto follow spectator to aoUUID
+// return connection changed target
+func (sm *SpectatorManager) UpdateAutoFollow(aoUUID string) []*c2t_serveconnbyte.ServeConnByte {
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	var rtn []*c2t_serveconnbyte.ServeConnByte
+	for connUUID, sp := range sm.conn2Spec {
+		if !sp.autoFollow || sp.aoUUID == aoUUID {
+			continue
+		}
+		sm.delFromAONolock(connUUID, sp.aoUUID)
+		sp.aoUUID = aoUUID
+		sm.addToAONolock(connUUID, sp.conn, aoUUID)
+		rtn = append(rtn, sp.conn)
+	}
+	return rtn
+}
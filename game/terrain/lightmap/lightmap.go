@@ -0,0 +1,65 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lightmap light level of each tile, 0 dark ~ 1 full lit
+package lightmap
+
+import (
+	"fmt"
+	"math"
+)
+
+type LightMap [][]float64
+
+func New(x, y int, ambient float64) LightMap {
+	lm := make(LightMap, x)
+	for i := range lm {
+		lm[i] = make([]float64, y)
+		for j := range lm[i] {
+			lm[i][j] = ambient
+		}
+	}
+	return lm
+}
+
+func (lm LightMap) String() string {
+	return fmt.Sprintf("LightMap[%v %v]", len(lm), len(lm[0]))
+}
+
+// AddSource light around x,y wrapped, 1 at center, 0 at radius
+func (lm LightMap) AddSource(x, y int, radius float64) {
+	if radius <= 0 {
+		return
+	}
+	w, h := len(lm), len(lm[0])
+	r := int(radius)
+	for dx := -r; dx <= r; dx++ {
+		for dy := -r; dy <= r; dy++ {
+			level := Level(dx, dy, radius)
+			if level <= 0 {
+				continue
+			}
+			tx := ((x+dx)%w + w) % w
+			ty := ((y+dy)%h + h) % h
+			if lm[tx][ty] < level {
+				lm[tx][ty] = level
+			}
+		}
+	}
+}
+
+// Level light level at dx,dy from source of radius
+func Level(dx, dy int, radius float64) float64 {
+	if radius <= 0 {
+		return 0
+	}
+	return 1 - math.Sqrt(float64(dx*dx+dy*dy))/radius
+}
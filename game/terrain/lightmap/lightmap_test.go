@@ -0,0 +1,28 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lightmap
+
+import "testing"
+
+func TestAddSource(t *testing.T) {
+	lm := New(16, 16, 0.1)
+	lm.AddSource(0, 0, 4)
+	if lm[0][0] != 1 {
+		t.Errorf("center %v", lm[0][0])
+	}
+	if lm[2][0] != 0.5 || lm[14][0] != 0.5 {
+		t.Errorf("wrapped %v %v", lm[2][0], lm[14][0])
+	}
+	if lm[8][8] != 0.1 {
+		t.Errorf("ambient %v", lm[8][8])
+	}
+}
@@ -24,6 +24,9 @@ var TerrainScriptFn = map[terraincmd.TerrainCmd]func(tr *Terrain, ca *scriptpars
 
 	terraincmd.ActiveObjectsRand: cmdActiveObjectsRand,
 	terraincmd.CarryObjectsRand:  cmdCarryObjectsRand,
+	terraincmd.LootTable:         cmdLootTable,
+	terraincmd.Light:             cmdLight,
+	terraincmd.WallHP:            cmdWallHP,
 
 	terraincmd.ResourceMazeWall:     cmdResourceMazeWall,
 	terraincmd.ResourceMazeWalk:     cmdResourceMazeWalk,
@@ -62,6 +65,23 @@ var TerrainScriptFn = map[terraincmd.TerrainCmd]func(tr *Terrain, ca *scriptpars
 	terraincmd.AddRecycler:            cmdAddRecycler,
 	terraincmd.AddRecyclerRand:        cmdAddRecyclerRand,
 	terraincmd.AddRecyclerInRoom:      cmdAddRecyclerRandInRoom,
+	terraincmd.AddCrafter:             cmdAddCrafter,
+	terraincmd.AddCrafterRand:         cmdAddCrafterRand,
+	terraincmd.AddCrafterInRoom:       cmdAddCrafterRandInRoom,
+	terraincmd.AddShop:                cmdAddShop,
+	terraincmd.AddShopRand:            cmdAddShopRand,
+	terraincmd.AddShopInRoom:          cmdAddShopRandInRoom,
+	terraincmd.AddRepairer:            cmdAddRepairer,
+	terraincmd.AddRepairerRand:        cmdAddRepairerRand,
+	terraincmd.AddRepairerInRoom:      cmdAddRepairerRandInRoom,
+	terraincmd.AddQuestGiver:          cmdAddQuestGiver,
+	terraincmd.AddQuestGiverRand:      cmdAddQuestGiverRand,
+	terraincmd.AddQuestGiverInRoom:    cmdAddQuestGiverRandInRoom,
+	terraincmd.AddDoor:                cmdAddDoor,
+	terraincmd.AddDoorsRand:           cmdAddDoorsRand,
+	terraincmd.AddBoulder:             cmdAddBoulder,
+	terraincmd.AddBouldersRand:        cmdAddBoulderRand,
+	terraincmd.AddBouldersInRoom:      cmdAddBoulderRandInRoom,
 	terraincmd.AddTrapTeleport:        cmdAddTrapTeleport,
 	terraincmd.AddTrapTeleportsRand:   cmdAddTrapTeleportRand,
 	terraincmd.AddTrapTeleportsInRoom: cmdAddTrapTeleportRandInRoom,
@@ -76,6 +96,9 @@ var TerrainScriptFn = map[terraincmd.TerrainCmd]func(tr *Terrain, ca *scriptpars
 	terraincmd.AddMine:       cmdAddMine,
 	terraincmd.AddMineRand:   cmdAddMineRand,
 	terraincmd.AddMineInRoom: cmdAddMineRandInRoom,
+
+	terraincmd.AddBoss:       cmdAddBoss,
+	terraincmd.AddBossInRoom: cmdAddBossInRoom,
 }
 
 func init() {
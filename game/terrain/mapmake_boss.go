@@ -0,0 +1,73 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terrain
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/config/bossdata"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/lib/scriptparse"
+)
+
+func cmdAddBoss(tr *Terrain, ca *scriptparse.CmdArgs) error {
+	var x, y int
+	var name string
+	var level int
+	var ft factiontype.FactionType
+	var aiplanStr, equipStr, lootStr string
+	var respawn int
+	if err := ca.GetArgs(&x, &y, &name, &level, &ft, &aiplanStr, &equipStr, &lootStr, &respawn); err != nil {
+		return err
+	}
+	bs, err := bossdata.New(name, level, ft, aiplanStr, equipStr, lootStr, respawn)
+	if err != nil {
+		return err
+	}
+	x, y = tr.WrapXY(x, y)
+	if !tr.serviceTileArea[x][y].CharPlaceable() {
+		return fmt.Errorf("can not add Boss at NonCharPlaceable tile %v %v", x, y)
+	}
+	bs.X, bs.Y = x, y
+	return tr.addBoss(bs)
+}
+
+func cmdAddBossInRoom(tr *Terrain, ca *scriptparse.CmdArgs) error {
+	var name string
+	var level int
+	var ft factiontype.FactionType
+	var aiplanStr, equipStr, lootStr string
+	var respawn int
+	if err := ca.GetArgs(&name, &level, &ft, &aiplanStr, &equipStr, &lootStr, &respawn); err != nil {
+		return err
+	}
+	if tr.roomManager.GetCount() == 0 {
+		return fmt.Errorf("no room to add Boss")
+	}
+	bs, err := bossdata.New(name, level, ft, aiplanStr, equipStr, lootStr, respawn)
+	if err != nil {
+		return err
+	}
+	bs.InRoom = true
+	return tr.addBoss(bs)
+}
+
+// addBoss boss name unique in terrain
+func (tr *Terrain) addBoss(bs *bossdata.Boss) error {
+	for _, v := range tr.BossList {
+		if v.Name == bs.Name {
+			return fmt.Errorf("duplicate boss %v", bs.Name)
+		}
+	}
+	tr.BossList = append(tr.BossList, bs)
+	return nil
+}
@@ -12,7 +12,10 @@
 package terrain
 
 import (
+	"fmt"
+
 	"github.com/kasworld/findnear"
+	"github.com/kasworld/goguelike/config/lootdata"
 	"github.com/kasworld/goguelike/game/terrain/corridor"
 	"github.com/kasworld/goguelike/game/terrain/resourcetilearea"
 	"github.com/kasworld/goguelike/game/terrain/roommanager"
@@ -50,6 +53,46 @@ func cmdCarryObjectsRand(tr *Terrain, ca *scriptparse.CmdArgs) error {
 	return nil
 }
 
+func cmdLootTable(tr *Terrain, ca *scriptparse.CmdArgs) error {
+	var typeStr, equipStr, potionStr, scrollStr string
+	var moneyMean, moneyStdDev, depthScale, deathDrop float64
+	if err := ca.GetArgs(&typeStr, &equipStr, &potionStr, &scrollStr,
+		&moneyMean, &moneyStdDev, &depthScale, &deathDrop); err != nil {
+		return err
+	}
+	lt, err := lootdata.New(typeStr, equipStr, potionStr, scrollStr,
+		moneyMean, moneyStdDev, depthScale, deathDrop)
+	if err != nil {
+		return err
+	}
+	tr.LootTable = lt
+	return nil
+}
+
+func cmdLight(tr *Terrain, ca *scriptparse.CmdArgs) error {
+	var ambient float64
+	if err := ca.GetArgs(&ambient); err != nil {
+		return err
+	}
+	if ambient < 0 || ambient > 1 {
+		return fmt.Errorf("invalid ambient %v", ambient)
+	}
+	tr.AmbientLight = ambient
+	return nil
+}
+
+func cmdWallHP(tr *Terrain, ca *scriptparse.CmdArgs) error {
+	var hp float64
+	if err := ca.GetArgs(&hp); err != nil {
+		return err
+	}
+	if hp < 0 {
+		return fmt.Errorf("invalid wall hp %v", hp)
+	}
+	tr.WallHP = hp
+	return nil
+}
+
 func cmdFinalizeTerrain(tr *Terrain, ca *scriptparse.CmdArgs) error {
 	tr.crpCache = nil
 	tr.findList = nil
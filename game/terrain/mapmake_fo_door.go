@@ -0,0 +1,98 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terrain
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/enum/fieldobjdisplaytype"
+	"github.com/kasworld/goguelike/enum/tile"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/lib/scriptparse"
+)
+
+func cmdAddDoor(tr *Terrain, ca *scriptparse.CmdArgs) error {
+	var x, y int
+	var dispType fieldobjdisplaytype.FieldObjDisplayType
+	var closed, locked bool
+	var message string
+	if err := ca.GetArgs(&x, &y, &dispType, &closed, &locked, &message); err != nil {
+		return err
+	}
+	return tr.addDoor(x, y, dispType, closed, locked, message)
+}
+
+func cmdAddDoorsRand(tr *Terrain, ca *scriptparse.CmdArgs) error {
+	var dispType fieldobjdisplaytype.FieldObjDisplayType
+	var count int
+	var closeRate, lockRate float64
+	var message string
+	if err := ca.GetArgs(&count, &dispType, &closeRate, &lockRate, &message); err != nil {
+		return err
+	}
+	posList := tr.emptyDoorPosList()
+	tr.rnd.Shuffle(len(posList), func(i, j int) {
+		posList[i], posList[j] = posList[j], posList[i]
+	})
+	if count > len(posList) {
+		tr.log.Warn("AddDoorsRand add insufficient %v/%v", len(posList), count)
+		count = len(posList)
+	}
+	for _, pos := range posList[:count] {
+		locked := tr.rnd.Float64() < lockRate
+		closed := locked || tr.rnd.Float64() < closeRate
+		if err := tr.addDoor(pos[0], pos[1], dispType, closed, locked, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emptyDoorPosList door tile pos of room without fieldobj
+func (tr *Terrain) emptyDoorPosList() [][2]int {
+	rtn := make([][2]int, 0)
+	added := make(map[[2]int]bool)
+	for _, r := range tr.roomManager.GetRoomList() {
+		for _, connPos := range r.ConnectPos {
+			x, y := tr.XWrap(connPos[0]), tr.YWrap(connPos[1])
+			if added[[2]int{x, y}] {
+				continue
+			}
+			if !tr.serviceTileArea[x][y].TestByTile(tile.Door) {
+				continue
+			}
+			if tr.foPosMan.Get1stObjAt(x, y) != nil {
+				continue
+			}
+			added[[2]int{x, y}] = true
+			rtn = append(rtn, [2]int{x, y})
+		}
+	}
+	return rtn
+}
+
+func (tr *Terrain) addDoor(x, y int, dispType fieldobjdisplaytype.FieldObjDisplayType,
+	closed, locked bool, message string) error {
+	x, y = tr.XWrap(x), tr.YWrap(y)
+	if !tr.serviceTileArea[x][y].TestByTile(tile.Door) {
+		return fmt.Errorf("can not add Door at not door tile %v %v", x, y)
+	}
+	if tr.foPosMan.Get1stObjAt(x, y) != nil {
+		return fmt.Errorf("can not add Door, fieldobj exist at %v %v", x, y)
+	}
+	doorID := fmt.Sprintf("Door_%v_%v_%v", tr.Name, x, y)
+	po := fieldobject.NewDoor(tr.Name, dispType, message, doorID, closed, locked)
+	tr.foPosMan.AddToXY(po, x, y)
+	tr.setBlockBits(x, y, po.DoorClosed)
+	tr.updateTileAt(x, y)
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terrain
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/config/craftdata"
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/fieldobjdisplaytype"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/game/terrain/roomsort"
+	"github.com/kasworld/goguelike/lib/scriptparse"
+)
+
+func cmdAddShop(tr *Terrain, ca *scriptparse.CmdArgs) error {
+	var x, y int
+	var dispType fieldobjdisplaytype.FieldObjDisplayType
+	var stockStr string
+	var priceRate float64
+	var restockTurn int
+	var message string
+	if err := ca.GetArgs(&x, &y, &dispType, &stockStr, &priceRate, &restockTurn, &message); err != nil {
+		return err
+	}
+	stock, err := parseShopArgs(stockStr, priceRate, restockTurn)
+	if err != nil {
+		return err
+	}
+	return tr.addShop(x, y, dispType, stock, priceRate, restockTurn, message)
+}
+
+func cmdAddShopRand(tr *Terrain, ca *scriptparse.CmdArgs) error {
+	var dispType fieldobjdisplaytype.FieldObjDisplayType
+	var count int
+	var stockStr string
+	var priceRate float64
+	var restockTurn int
+	var message string
+	if err := ca.GetArgs(&count, &dispType, &stockStr, &priceRate, &restockTurn, &message); err != nil {
+		return err
+	}
+	stock, err := parseShopArgs(stockStr, priceRate, restockTurn)
+	if err != nil {
+		return err
+	}
+	try := count
+	for count > 0 && try > 0 {
+		err := tr.addShopRand(dispType, stock, priceRate, restockTurn, message)
+		if err == nil {
+			count--
+		} else {
+			try--
+		}
+	}
+	if try == 0 {
+		tr.log.Warn("AddShopRand add insufficient")
+	}
+	return nil
+}
+
+func cmdAddShopRandInRoom(tr *Terrain, ca *scriptparse.CmdArgs) error {
+	var dispType fieldobjdisplaytype.FieldObjDisplayType
+	var count int
+	var stockStr string
+	var priceRate float64
+	var restockTurn int
+	var message string
+	if err := ca.GetArgs(&count, &dispType, &stockStr, &priceRate, &restockTurn, &message); err != nil {
+		return err
+	}
+	stock, err := parseShopArgs(stockStr, priceRate, restockTurn)
+	if err != nil {
+		return err
+	}
+	try := count
+	for count > 0 && try > 0 {
+		err := tr.addShopRandInRoom(dispType, stock, priceRate, restockTurn, message)
+		if err == nil {
+			count--
+		} else {
+			try--
+		}
+	}
+	if try == 0 {
+		tr.log.Warn("AddShopInRoom add insufficient")
+	}
+	return nil
+}
+
+// parseShopArgs price must over recycle rate, not to make money by buy and recycle
+func parseShopArgs(stockStr string, priceRate float64, restockTurn int) ([]craftdata.Material, error) {
+	stock, err := craftdata.ParseMaterialList(stockStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(stock) == 0 {
+		return nil, fmt.Errorf("empty shop stock %v", stockStr)
+	}
+	if priceRate <= gameconst.CarryObjRecycleRate {
+		return nil, fmt.Errorf("shop price %v must over CarryObjRecycleRate %v",
+			priceRate, gameconst.CarryObjRecycleRate)
+	}
+	if restockTurn <= 0 {
+		return nil, fmt.Errorf("invalid shop restock %v", restockTurn)
+	}
+	return stock, nil
+}
+
+func (tr *Terrain) addShop(x, y int, dispType fieldobjdisplaytype.FieldObjDisplayType,
+	stock []craftdata.Material, priceRate float64, restockTurn int, message string) error {
+	x, y = x%tr.Xlen, y%tr.Ylen
+	if !tr.canPlaceFieldObjAt(x, y) {
+		return fmt.Errorf("can not add Shop at NonCharPlaceable tile %v %v", x, y)
+	}
+	po := fieldobject.NewShop(tr.Name, dispType, stock, priceRate, restockTurn, message)
+	tr.foPosMan.AddToXY(po, x, y)
+
+	if r := tr.roomManager.GetRoomByPos(x, y); r != nil {
+		r.ShopCount++
+	}
+	return nil
+}
+
+func (tr *Terrain) addShopRand(dispType fieldobjdisplaytype.FieldObjDisplayType,
+	stock []craftdata.Material, priceRate float64, restockTurn int, message string) error {
+
+	for try := 10; try > 0; try-- {
+		x, y := tr.rnd.Intn(tr.Xlen), tr.rnd.Intn(tr.Ylen)
+		if !tr.canPlaceFieldObjAt(x, y) {
+			continue
+		}
+		return tr.addShop(x, y, dispType, stock, priceRate, restockTurn, message)
+	}
+	return fmt.Errorf("fail to addShopRand at NonCharPlaceable tile")
+}
+
+func (tr *Terrain) addShopRandInRoom(dispType fieldobjdisplaytype.FieldObjDisplayType,
+	stock []craftdata.Material, priceRate float64, restockTurn int, message string) error {
+
+	if tr.roomManager.GetCount() == 0 {
+		return fmt.Errorf("no room to add Shop")
+	}
+	roomList := tr.roomManager.GetRoomList()
+	for try := 100; try > 0; try-- {
+		tr.rnd.Shuffle(len(roomList), func(i, j int) {
+			roomList[i], roomList[j] = roomList[j], roomList[i]
+		})
+		rList := roomsort.ByShopCount(roomList)
+		rList.Sort()
+		r := rList[0]
+		x := tr.rnd.IntRange(r.Area.X, r.Area.X+r.Area.W)
+		y := tr.rnd.IntRange(r.Area.Y, r.Area.Y+r.Area.H)
+		if !tr.canPlaceFieldObjAt(x, y) {
+			continue
+		}
+		return tr.addShop(x, y, dispType, stock, priceRate, restockTurn, message)
+	}
+	return fmt.Errorf("cannot find pos in room")
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/kasworld/goguelike/lib/scriptparse"
 
 	"github.com/kasworld/goguelike/enum/decaytype"
+	"github.com/kasworld/goguelike/enum/factiontype"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype"
 	"github.com/kasworld/goguelike/enum/fieldobjdisplaytype"
 	"github.com/kasworld/goguelike/enum/resourcetype"
@@ -156,6 +157,19 @@ func SetDecayType(valStr string, dstValue interface{}) error {
 	return nil
 }
 
+func SetFactionType(valStr string, dstValue interface{}) error {
+	iv, ok := dstValue.(*factiontype.FactionType)
+	if !ok {
+		return fmt.Errorf("fail to cast FactionType %v", valStr)
+	}
+	ft, exist := factiontype.String2FactionType(valStr)
+	if !exist {
+		return fmt.Errorf("unknown FactionType %v", valStr)
+	}
+	*iv = ft
+	return nil
+}
+
 var Type2ConvFn = map[string]func(valStr string, dstValue interface{}) error{
 	"float":               SetFloat,
 	"int":                 SetInt,
@@ -167,4 +181,5 @@ var Type2ConvFn = map[string]func(valStr string, dstValue interface{}) error{
 	"TileFlag":            SetTileFlag,
 	"ResourceType":        SetResourceType,
 	"DecayType":           SetDecayType,
+	"FactionType":         SetFactionType,
 }
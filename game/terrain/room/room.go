@@ -34,10 +34,15 @@ type Room struct {
 	ConnectPos [][2]int // door outer pos , out of room area
 	// for sort
 	RecyclerCount         int
+	CrafterCount          int
+	ShopCount             int
+	RepairerCount         int
+	QuestGiverCount       int
 	PortalCount           int
 	TrapCount             int
 	RotateLineAttackCount int
 	MineCount             int
+	BoulderCount          int
 }
 
 func New(rt rect.Rect, bgTile tile_flag.TileFlag) *Room {
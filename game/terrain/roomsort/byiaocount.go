@@ -35,6 +35,78 @@ func (rl ByRecyclerCount) Sort() {
 	sort.Sort(rl)
 }
 
+type ByCrafterCount []*room.Room
+
+func (rl ByCrafterCount) Len() int { return len(rl) }
+func (rl ByCrafterCount) Swap(i, j int) {
+	rl[i], rl[j] = rl[j], rl[i]
+}
+func (rl ByCrafterCount) Less(i, j int) bool {
+	r1 := rl[i]
+	r2 := rl[j]
+	if r1.CrafterCount == r2.CrafterCount {
+		return r1.RecyclerCount < r2.RecyclerCount
+	}
+	return r1.CrafterCount < r2.CrafterCount
+}
+func (rl ByCrafterCount) Sort() {
+	sort.Sort(rl)
+}
+
+type ByShopCount []*room.Room
+
+func (rl ByShopCount) Len() int { return len(rl) }
+func (rl ByShopCount) Swap(i, j int) {
+	rl[i], rl[j] = rl[j], rl[i]
+}
+func (rl ByShopCount) Less(i, j int) bool {
+	r1 := rl[i]
+	r2 := rl[j]
+	if r1.ShopCount == r2.ShopCount {
+		return r1.RecyclerCount < r2.RecyclerCount
+	}
+	return r1.ShopCount < r2.ShopCount
+}
+func (rl ByShopCount) Sort() {
+	sort.Sort(rl)
+}
+
+type ByRepairerCount []*room.Room
+
+func (rl ByRepairerCount) Len() int { return len(rl) }
+func (rl ByRepairerCount) Swap(i, j int) {
+	rl[i], rl[j] = rl[j], rl[i]
+}
+func (rl ByRepairerCount) Less(i, j int) bool {
+	r1 := rl[i]
+	r2 := rl[j]
+	if r1.RepairerCount == r2.RepairerCount {
+		return r1.RecyclerCount < r2.RecyclerCount
+	}
+	return r1.RepairerCount < r2.RepairerCount
+}
+func (rl ByRepairerCount) Sort() {
+	sort.Sort(rl)
+}
+
+type ByQuestGiverCount []*room.Room
+
+func (rl ByQuestGiverCount) Len() int { return len(rl) }
+func (rl ByQuestGiverCount) Swap(i, j int) {
+	rl[i], rl[j] = rl[j], rl[i]
+}
+func (rl ByQuestGiverCount) Less(i, j int) bool {
+	r1 := rl[i]
+	r2 := rl[j]
+	if r1.QuestGiverCount == r2.QuestGiverCount {
+		return r1.RecyclerCount < r2.RecyclerCount
+	}
+	return r1.QuestGiverCount < r2.QuestGiverCount
+}
+func (rl ByQuestGiverCount) Sort() {
+	sort.Sort(rl)
+}
+
 type ByPortalCount []*room.Room
 
 func (rl ByPortalCount) Len() int { return len(rl) }
@@ -106,3 +178,21 @@ func (rl ByMineCount) Less(i, j int) bool {
 func (rl ByMineCount) Sort() {
 	sort.Sort(rl)
 }
+
+type ByBoulderCount []*room.Room
+
+func (rl ByBoulderCount) Len() int { return len(rl) }
+func (rl ByBoulderCount) Swap(i, j int) {
+	rl[i], rl[j] = rl[j], rl[i]
+}
+func (rl ByBoulderCount) Less(i, j int) bool {
+	r1 := rl[i]
+	r2 := rl[j]
+	if r1.BoulderCount == r2.BoulderCount {
+		return r1.RecyclerCount < r2.RecyclerCount
+	}
+	return r1.BoulderCount < r2.BoulderCount
+}
+func (rl ByBoulderCount) Sort() {
+	sort.Sort(rl)
+}
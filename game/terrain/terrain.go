@@ -18,8 +18,15 @@ import (
 
 	"github.com/kasworld/findnear"
 	"github.com/kasworld/g2rand"
+	"github.com/kasworld/goguelike/config/bossdata"
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/config/lootdata"
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/enum/resourcetype"
+	"github.com/kasworld/goguelike/enum/tile_flag"
 	"github.com/kasworld/goguelike/game/fieldobject"
 	"github.com/kasworld/goguelike/game/terrain/corridor"
+	"github.com/kasworld/goguelike/game/terrain/lightmap"
 	"github.com/kasworld/goguelike/game/terrain/resourcetilearea"
 	"github.com/kasworld/goguelike/game/terrain/roommanager"
 	"github.com/kasworld/goguelike/game/terrain/viewportcache"
@@ -71,6 +78,13 @@ type Terrain struct {
 
 	foPosMan *uuidposman.UUIDPosMan `prettystring:"simple"`
 
+	// destructible wall, damage accumulated and collapsed wall tile
+	wallDamage map[[2]int]float64            `prettystring:"simple"`
+	rubbleMap  map[[2]int]tile_flag.TileFlag `prettystring:"simple"`
+
+	// nil if AmbientLight >= 1, all lit
+	lightMap lightmap.LightMap `prettystring:"simple"`
+
 	Xlen     int
 	Ylen     int
 	XWrapper *wrapper.Wrapper `prettystring:"simple"`
@@ -82,6 +96,10 @@ type Terrain struct {
 	ActTurnBoost      float64
 	ActiveObjCount    int
 	CarryObjCount     int
+	BossList          []*bossdata.Boss    `prettystring:"simple"`
+	LootTable         *lootdata.LootTable `prettystring:"simple"` // nil : default carryobj make
+	AmbientLight      float64             // 0 dark ~ 1 full lit
+	WallHP            float64             // 0 : wall not destructible
 	MSPerAgeing       int64
 	ResetAfterNAgeing int64
 	Tile2Discover     int
@@ -92,6 +110,9 @@ func New(seed int64, script []string, dataDir string, l *g2log.LogBase) *Terrain
 		dataDir:       dataDir,
 		terrainScript: script,
 		log:           l,
+		AmbientLight:  1,
+		wallDamage:    make(map[[2]int]float64),
+		rubbleMap:     make(map[[2]int]tile_flag.TileFlag),
 	}
 	tr.viewportCache = viewportcache.New(tr)
 	tr.rnd = g2rand.NewWithSeed(seed)
@@ -115,6 +136,7 @@ func (tr *Terrain) Init() error {
 		return nil // skip no name terrain
 	}
 	tr.oriTiles = tr.resourceTileArea.Dup()
+	tr.makeLightMap() // include fieldobj added after FinalizeTerrain
 	return nil
 }
 
@@ -161,10 +183,13 @@ func (tr *Terrain) ResetAgeing() error {
 func (tr *Terrain) renderServiceTileArea() {
 	tr.resource2View()
 	tr.tileLayer2SeviceTileArea()
+	tr.applyRubble()
 	tr.openBlockedDoor()
+	tr.applyFieldObjTile()
 	tr.Tile2Discover = tr.serviceTileArea.CalcNotEmptyTileCount()
 	tr.viewportCache.Reset()
 	tr.ta4ff = tilearea4pathfind.New(tr.GetTiles())
+	tr.makeLightMap()
 	for _, o := range tr.foPosMan.GetAllList() {
 		fo, ok := o.(*fieldobject.FieldObject)
 		if !ok {
@@ -176,8 +201,35 @@ func (tr *Terrain) renderServiceTileArea() {
 			tr.log.Fatal("fieldobj not found %v", o)
 			continue
 		}
+		if fo.ActType == fieldobjacttype.Door || fo.ActType == fieldobjacttype.Boulder {
+			continue // closed door, boulder not CharPlaceable
+		}
 		if !tr.serviceTileArea[x][y].CharPlaceable() {
 			tr.log.Fatal("fieldobj placed at NonCharPlaceable tile %v", fo)
 		}
 	}
 }
+
+// makeLightMap by ambient, light emit tile, fire resource and fieldobj
+func (tr *Terrain) makeLightMap() {
+	if tr.AmbientLight >= 1 {
+		tr.lightMap = nil
+		return
+	}
+	lm := lightmap.New(tr.Xlen, tr.Ylen, tr.AmbientLight)
+	for x, xv := range tr.serviceTileArea {
+		for y, yv := range xv {
+			lm.AddSource(x, y, yv.LightRadius())
+			if tr.resourceTileArea[x][y][resourcetype.Fire] > gameconst.LightFireResource {
+				lm.AddSource(x, y, gameconst.LightFireRadius)
+			}
+		}
+	}
+	if tr.foPosMan != nil {
+		tr.foPosMan.IterAll(func(o uuidposman.UUIDPosI, x, y int) bool {
+			lm.AddSource(x, y, o.(*fieldobject.FieldObject).ActType.LightRadius())
+			return false
+		})
+	}
+	tr.lightMap = lm
+}
@@ -0,0 +1,58 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terrain
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/game/fieldobject"
+)
+
+// GetBoulderAt nil if no boulder at x,y
+func (tr *Terrain) GetBoulderAt(x, y int) *fieldobject.FieldObject {
+	x, y = tr.WrapXY(x, y)
+	fo, ok := tr.foPosMan.Get1stObjAt(x, y).(*fieldobject.FieldObject)
+	if !ok || fo.ActType != fieldobjacttype.Boulder {
+		return nil
+	}
+	return fo
+}
+
+// CanPushBoulderTo CharPlaceable tile without fieldobj
+// ao, carryobj at dst is not checked, floor check them
+func (tr *Terrain) CanPushBoulderTo(x, y int) bool {
+	x, y = tr.WrapXY(x, y)
+	return tr.serviceTileArea[x][y].CharPlaceable() &&
+		tr.foPosMan.Get1stObjAt(x, y) == nil
+}
+
+// MoveBoulder move boulder at src to dst, tile of src restored
+func (tr *Terrain) MoveBoulder(srcX, srcY, dstX, dstY int) error {
+	srcX, srcY = tr.WrapXY(srcX, srcY)
+	dstX, dstY = tr.WrapXY(dstX, dstY)
+	fo := tr.GetBoulderAt(srcX, srcY)
+	if fo == nil {
+		return fmt.Errorf("no boulder at %v %v", srcX, srcY)
+	}
+	if !tr.CanPushBoulderTo(dstX, dstY) {
+		return fmt.Errorf("can not move boulder to %v %v", dstX, dstY)
+	}
+	if err := tr.foPosMan.UpdateToXY(fo, dstX, dstY); err != nil {
+		return err
+	}
+	tr.setBlockBits(srcX, srcY, false)
+	tr.updateTileAt(srcX, srcY)
+	tr.setBlockBits(dstX, dstY, true)
+	tr.updateTileAt(dstX, dstY)
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terrain
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/game/fieldobject"
+)
+
+// GetDoorAt nil if no door at x,y
+func (tr *Terrain) GetDoorAt(x, y int) *fieldobject.FieldObject {
+	fo, ok := tr.foPosMan.Get1stObjAt(x, y).(*fieldobject.FieldObject)
+	if !ok || fo.ActType != fieldobjacttype.Door {
+		return nil
+	}
+	return fo
+}
+
+// SetDoorState change door at x,y, locked door is closed
+func (tr *Terrain) SetDoorState(x, y int, closed, locked bool) error {
+	x, y = tr.WrapXY(x, y)
+	fo := tr.GetDoorAt(x, y)
+	if fo == nil {
+		return fmt.Errorf("no door at %v %v", x, y)
+	}
+	fo.DoorLocked = locked
+	fo.DoorClosed = closed || locked
+	tr.setBlockBits(x, y, fo.DoorClosed)
+	tr.updateTileAt(x, y)
+	return nil
+}
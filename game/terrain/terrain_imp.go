@@ -12,7 +12,10 @@
 package terrain
 
 import (
+	"github.com/kasworld/goguelike/config/bossdata"
+	"github.com/kasworld/goguelike/config/lootdata"
 	"github.com/kasworld/goguelike/enum/tile_flag"
+	"github.com/kasworld/goguelike/game/terrain/lightmap"
 	"github.com/kasworld/goguelike/game/terrain/resourcetilearea"
 	"github.com/kasworld/goguelike/game/terrain/room"
 	"github.com/kasworld/goguelike/game/terrain/viewportcache"
@@ -98,6 +101,17 @@ func (tr *Terrain) GetActiveObjCount() int {
 func (tr *Terrain) GetCarryObjCount() int {
 	return tr.CarryObjCount
 }
+func (tr *Terrain) GetBossList() []*bossdata.Boss {
+	return tr.BossList
+}
+func (tr *Terrain) GetLootTable() *lootdata.LootTable {
+	return tr.LootTable
+}
+
+// GetLightMap nil if all lit
+func (tr *Terrain) GetLightMap() lightmap.LightMap {
+	return tr.lightMap
+}
 
 func (tr *Terrain) FindPath(dstx, dsty, srcx, srcy int, trylimit int) [][2]int {
 	return tr.ta4ff.FindPath(dstx, dsty, srcx, srcy, trylimit)
@@ -0,0 +1,122 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terrain
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/game/towersnapshot"
+	"github.com/kasworld/goguelike/lib/uuidposman"
+)
+
+func (tr *Terrain) ToSnapshot() towersnapshot.TerrainSnapshot {
+	ts := towersnapshot.TerrainSnapshot{
+		AgeingCount:      tr.ageingCount,
+		ResourceTileArea: tr.GetRcsTiles().Dup(),
+	}
+	tr.foPosMan.IterAll(func(o uuidposman.UUIDPosI, x, y int) bool {
+		fo, ok := o.(*fieldobject.FieldObject)
+		if !ok {
+			return false
+		}
+		switch fo.ActType {
+		case fieldobjacttype.Door:
+			ts.DoorList = append(ts.DoorList, towersnapshot.DoorSnapshot{
+				X: x, Y: y, Closed: fo.DoorClosed, Locked: fo.DoorLocked,
+			})
+		case fieldobjacttype.Boulder:
+			ts.BoulderList = append(ts.BoulderList, [2]int{x, y})
+		case fieldobjacttype.RotateLineAttack, fieldobjacttype.Mine:
+			ts.FieldObjList = append(ts.FieldObjList, towersnapshot.FieldObjSnapshot{
+				X: x, Y: y, ActType: fo.ActType, Degree: fo.Degree, Radius: fo.Radius,
+			})
+		}
+		return false
+	})
+	for pos, tl := range tr.rubbleMap {
+		ts.RubbleList = append(ts.RubbleList, towersnapshot.RubbleSnapshot{
+			X: pos[0], Y: pos[1], Tile: tl,
+		})
+	}
+	for pos, damage := range tr.wallDamage {
+		ts.WallDamageList = append(ts.WallDamageList, towersnapshot.WallDamageSnapshot{
+			X: pos[0], Y: pos[1], Damage: damage,
+		})
+	}
+	return ts
+}
+
+// RestoreSnapshot overwrite aged resource tiles, must call after Init
+func (tr *Terrain) RestoreSnapshot(ts towersnapshot.TerrainSnapshot) error {
+	w, h := ts.ResourceTileArea.GetXYLen()
+	if w != tr.Xlen || h != tr.Ylen {
+		return fmt.Errorf("snapshot size mismatch %v (%v %v)", tr, w, h)
+	}
+	if atomic.CompareAndSwapInt32(&tr.inAgeing, 0, 1) {
+		defer atomic.AddInt32(&tr.inAgeing, -1)
+		tr.resourceTileArea = ts.ResourceTileArea.Dup()
+		for _, v := range ts.DoorList {
+			if fo := tr.GetDoorAt(v.X, v.Y); fo != nil {
+				fo.DoorClosed, fo.DoorLocked = v.Closed, v.Locked
+			}
+		}
+		for _, v := range ts.FieldObjList {
+			fo, ok := tr.foPosMan.Get1stObjAt(v.X, v.Y).(*fieldobject.FieldObject)
+			if !ok || fo.ActType != v.ActType {
+				tr.log.Warn("skip fieldobj state, not found %v %v", tr, v)
+				continue
+			}
+			fo.Degree, fo.Radius = v.Degree, v.Radius
+		}
+		for _, v := range ts.RubbleList {
+			tr.rubbleMap[[2]int{v.X, v.Y}] = v.Tile
+		}
+		for _, v := range ts.WallDamageList {
+			tr.wallDamage[[2]int{v.X, v.Y}] = v.Damage
+		}
+		if err := tr.restoreBoulderPos(ts.BoulderList); err != nil {
+			tr.log.Error("%v %v", tr, err)
+		}
+		tr.renderServiceTileArea() // apply door, rubble, boulder
+		tr.ageingCount = ts.AgeingCount
+		return nil
+	} else {
+		return fmt.Errorf("skip RestoreSnapshot, in ageing %v", tr)
+	}
+}
+
+// restoreBoulderPos move boulders made by Init to saved pos
+func (tr *Terrain) restoreBoulderPos(posList [][2]int) error {
+	var boulderList []*fieldobject.FieldObject
+	for _, o := range tr.foPosMan.GetAllList() {
+		if fo, ok := o.(*fieldobject.FieldObject); ok && fo.ActType == fieldobjacttype.Boulder {
+			boulderList = append(boulderList, fo)
+		}
+	}
+	if len(boulderList) != len(posList) {
+		return fmt.Errorf("boulder count mismatch %v != %v", len(boulderList), len(posList))
+	}
+	for _, fo := range boulderList {
+		if err := tr.foPosMan.Del(fo); err != nil {
+			return err
+		}
+	}
+	for i, fo := range boulderList {
+		if err := tr.foPosMan.AddToXY(fo, posList[i][0], posList[i][1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terrain
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/enum/tile"
+	"github.com/kasworld/goguelike/enum/tile_flag"
+)
+
+// IsDestructibleWallAt wall tile without fieldobj in WallHP set terrain
+func (tr *Terrain) IsDestructibleWallAt(x, y int) bool {
+	x, y = tr.WrapXY(x, y)
+	return tr.WallHP > 0 &&
+		tr.serviceTileArea[x][y].TestByTile(tile.Wall) &&
+		tr.foPosMan.Get1stObjAt(x, y) == nil
+}
+
+// DamageWallAt add damage to wall, return true if collapsed to rubble
+func (tr *Terrain) DamageWallAt(x, y int, damage float64) (bool, error) {
+	x, y = tr.WrapXY(x, y)
+	if !tr.IsDestructibleWallAt(x, y) {
+		return false, fmt.Errorf("not destructible wall at %v %v", x, y)
+	}
+	pos := [2]int{x, y}
+	tr.wallDamage[pos] += damage
+	if tr.wallDamage[pos] < tr.WallHP {
+		return false, nil
+	}
+	delete(tr.wallDamage, pos)
+	tr.collapseWallAt(x, y, rubbleTileAt(x, y))
+	return true, nil
+}
+
+// rubbleTileAt rubble fixed by pos, not use rnd to make same in replay
+func rubbleTileAt(x, y int) tile.Tile {
+	if (x*31+y*17)%3 == 0 {
+		return tile.Soil
+	}
+	return tile.Stone
+}
+
+// collapseWallAt change wall to rubble, kept over ageing
+func (tr *Terrain) collapseWallAt(x, y int, rubble tile.Tile) {
+	var tl tile_flag.TileFlag
+	tl.SetByTile(rubble)
+	tr.rubbleMap[[2]int{x, y}] = tl
+	tr.serviceTileArea[x][y] = tl
+	tr.updateTileAt(x, y)
+}
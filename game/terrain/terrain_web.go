@@ -127,6 +127,12 @@ func (tr *Terrain) Web_TerrainInfo(w http.ResponseWriter, r *http.Request) {
 	<br/>
 	ActiveObj count {{.GetActiveObjCount}} start CarryObj count {{.GetCarryObjCount}} 
 	<br/>
+	{{with .GetLootTable}}{{.}}<br/>{{end}}
+	Boss List <br/>
+	{{range $i, $v := .GetBossList}}
+		{{$v}} {{if $v.InRoom}}InRoom{{else}}[{{$v.X}} {{$v.Y}}]{{end}} Respawn {{$v.Respawn}}
+		<br/>
+	{{end}}
 	<hr/> 
 	<table border=1 style="border-collapse:collapse;"> 
 	{{range $i, $v := .GetRoomList}}
@@ -12,7 +12,12 @@
 package terrain
 
 import (
+	"github.com/kasworld/goguelike/config/viewportdata"
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
 	"github.com/kasworld/goguelike/enum/tile"
+	"github.com/kasworld/goguelike/enum/tile_flag"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/lib/uuidposman"
 )
 
 func (tr *Terrain) resource2View() {
@@ -48,3 +53,43 @@ func (tr *Terrain) tileLayer2SeviceTileArea() {
 		}
 	}
 }
+
+// applyRubble collapsed wall override ageing
+func (tr *Terrain) applyRubble() {
+	for pos, tl := range tr.rubbleMap {
+		tr.serviceTileArea[pos[0]][pos[1]] = tl
+	}
+}
+
+// setBlockBits tile of closed door, boulder has wall, block sight and move
+func (tr *Terrain) setBlockBits(x, y int, block bool) {
+	if block {
+		tr.serviceTileArea[x][y].SetByTile(tile.Wall)
+	} else {
+		tr.serviceTileArea[x][y].ClearByTileFlag(tile_flag.WallFlag)
+	}
+}
+
+// applyFieldObjTile restore door, boulder tile after render serviceTileArea
+func (tr *Terrain) applyFieldObjTile() {
+	tr.foPosMan.IterAll(func(o uuidposman.UUIDPosI, x, y int) bool {
+		fo, ok := o.(*fieldobject.FieldObject)
+		if !ok {
+			return false
+		}
+		switch fo.ActType {
+		case fieldobjacttype.Door:
+			tr.setBlockBits(x, y, fo.DoorClosed)
+		case fieldobjacttype.Boulder:
+			tr.setBlockBits(x, y, true)
+		}
+		return false
+	})
+}
+
+// updateTileAt clear sight, pathfind cache around x,y
+// call after serviceTileArea changed at runtime, not ageing
+func (tr *Terrain) updateTileAt(x, y int) {
+	tr.viewportCache.ClearAt(x, y, viewportdata.ViewportXYLenList)
+	tr.ta4ff.ClearAt(x, y)
+}
@@ -15,7 +15,11 @@ import (
 	"net/http"
 
 	"github.com/kasworld/findnear"
+	"github.com/kasworld/goguelike/config/bossdata"
+	"github.com/kasworld/goguelike/config/lootdata"
 	"github.com/kasworld/goguelike/enum/tile_flag"
+	"github.com/kasworld/goguelike/game/fieldobject"
+	"github.com/kasworld/goguelike/game/terrain/lightmap"
 	"github.com/kasworld/goguelike/game/terrain/resourcetilearea"
 	"github.com/kasworld/goguelike/game/terrain/room"
 	"github.com/kasworld/goguelike/game/terrain/viewportcache"
@@ -51,6 +55,12 @@ type TerrainI interface {
 
 	GetActiveObjCount() int
 	GetCarryObjCount() int
+	GetBossList() []*bossdata.Boss
+	GetLootTable() *lootdata.LootTable
+	GetLightMap() lightmap.LightMap
+	GetDoorAt(x, y int) *fieldobject.FieldObject
+	SetDoorState(x, y int, closed, locked bool) error
+	GetBoulderAt(x, y int) *fieldobject.FieldObject
 	GetScript() []string
 
 	Search1stByXYLenList(
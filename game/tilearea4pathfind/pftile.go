@@ -38,18 +38,31 @@ type pfTile struct {
 	X     int
 	Y     int
 	Ta4pf *TileArea4PathFind
+
+	// cached, nil if not made or cleared by tile change
+	neighbors []astar.Pather
 }
 
 func (pft *pfTile) PathNeighbors() []astar.Pather {
-	rtn := make([]astar.Pather, 0, 8)
-	ta := pft.Ta4pf.tileArea
+	ta4pf := pft.Ta4pf
+	ta4pf.neighborsMutex.RLock()
+	rtn := pft.neighbors
+	ta4pf.neighborsMutex.RUnlock()
+	if rtn != nil {
+		return rtn
+	}
+	rtn = make([]astar.Pather, 0, 8)
+	ta := ta4pf.tileArea
 	fx, fy := pft.X, pft.Y
 	for _, v := range dir2vt[1:] {
-		x, y := pft.Ta4pf.WrapXY(v[0]+fx, v[1]+fy)
+		x, y := ta4pf.WrapXY(v[0]+fx, v[1]+fy)
 		if ta[x][y].CharPlaceable() {
-			rtn = append(rtn, pft.Ta4pf.newTile4PathFind(x, y))
+			rtn = append(rtn, ta4pf.newTile4PathFind(x, y))
 		}
 	}
+	ta4pf.neighborsMutex.Lock()
+	pft.neighbors = rtn
+	ta4pf.neighborsMutex.Unlock()
 	return rtn
 }
 
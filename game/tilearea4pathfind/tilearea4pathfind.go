@@ -28,6 +28,7 @@ type PFAreaI interface {
 type TileArea4PathFind struct {
 	tiles4PathFindMutex sync.Mutex        `prettystring:"hide"`
 	tiles4PathFind      [][]*pfTile       `prettystring:"simple"`
+	neighborsMutex      sync.RWMutex      `prettystring:"hide"`
 	tileArea            tilearea.TileArea `prettystring:"simple"`
 	w                   int
 	h                   int
@@ -86,6 +87,20 @@ func (ta4pf *TileArea4PathFind) newTile4PathFind(x, y int) *pfTile {
 	return tpf
 }
 
+// ClearAt clear cached neighbors around x,y, call after tile at x,y changed
+func (ta4pf *TileArea4PathFind) ClearAt(x, y int) {
+	ta4pf.tiles4PathFindMutex.Lock()
+	defer ta4pf.tiles4PathFindMutex.Unlock()
+	ta4pf.neighborsMutex.Lock()
+	defer ta4pf.neighborsMutex.Unlock()
+	for _, v := range dir2vt {
+		tx, ty := ta4pf.WrapXY(x+v[0], y+v[1])
+		if tpf := ta4pf.tiles4PathFind[tx][ty]; tpf != nil {
+			tpf.neighbors = nil
+		}
+	}
+}
+
 func (ta4pf *TileArea4PathFind) FindPath(dstx, dsty, srcx, srcy int, trylimit int) [][2]int {
 	p, count := astar.Path2(
 		ta4pf.newTile4PathFind(srcx, srcy),
@@ -0,0 +1,43 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tilearea4pathfind
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/enum/tile"
+	"github.com/kasworld/goguelike/enum/tile_flag"
+	"github.com/kasworld/goguelike/game/tilearea"
+)
+
+func TestClearAt(t *testing.T) {
+	ta := tilearea.New(16, 16)
+	for x := range ta {
+		for y := range ta[x] {
+			ta[x][y].SetByTile(tile.Room)
+		}
+	}
+	// wrapped, need 2 wall line to block
+	for y := range ta[8] {
+		ta[0][y].SetByTile(tile.Wall)
+		ta[8][y].SetByTile(tile.Wall)
+	}
+	ta4pf := New(ta)
+	if p := ta4pf.FindPath(12, 4, 4, 4, 1000); len(p) != 0 {
+		t.Errorf("path through wall %v", p)
+	}
+	ta[8][4].ClearByTileFlag(tile_flag.WallFlag)
+	ta4pf.ClearAt(8, 4)
+	if p := ta4pf.FindPath(12, 4, 4, 4, 1000); len(p) == 0 {
+		t.Errorf("no path after wall removed")
+	}
+}
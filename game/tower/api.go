@@ -19,12 +19,14 @@ import (
 
 	"github.com/kasworld/goguelike/config/authdata"
 	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/enum/chattype"
 	"github.com/kasworld/goguelike/game/activeobject"
 	"github.com/kasworld/goguelike/game/cmd2tower"
 	"github.com/kasworld/goguelike/game/gamei"
 	"github.com/kasworld/goguelike/lib/conndata"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_gob"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_packet"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_serveconnbyte"
@@ -62,14 +64,38 @@ func (tw *Tower) bytesAPIFn_ReqLogin(
 		ErrorCode: c2t_error.None,
 	}
 
+	connData := c2sc.GetConnData().(*conndata.ConnData)
+
+	if robj.Spectate {
+		// no session, no ao, read only
+		authdata.UpdateBySpectator(c2sc.GetAuthorCmdList())
+		connData.Spectator = true
+		if err := c2sc.SendNotiPacket(
+			c2t_idnoti.EnterTower,
+			&c2t_obj.NotiEnterTower_data{
+				TowerInfo: tw.towerInfo,
+			},
+		); err != nil {
+			return rhd, nil, err
+		}
+		acinfo := &c2t_obj.AccountInfo{
+			NickName: robj.NickName,
+			CmdList:  *c2sc.GetAuthorCmdList(),
+		}
+		return rhd, &c2t_obj.RspLogin_data{
+			ServiceInfo: tw.serviceInfo,
+			AccountInfo: acinfo,
+		}, nil
+	}
+
 	if err := authdata.UpdateByAuthKey(c2sc.GetAuthorCmdList(), robj.AuthKey); err != nil {
 		return rhd, nil, err
 	}
 
-	connData := c2sc.GetConnData().(*conndata.ConnData)
-
+	// session of client, may be lost by tower restart
+	clientSessionUUID := strings.TrimSpace(robj.SessionUUID)
 	ss := tw.sessionManager.UpdateOrNew(
-		robj.SessionUUID,
+		clientSessionUUID,
 		connData.RemoteAddr,
 		robj.NickName)
 
@@ -85,14 +111,22 @@ func (tw *Tower) bytesAPIFn_ReqLogin(
 	}
 	if tw.connManager.Get(ss.ConnUUID) != nil {
 		tw.log.Fatal("old connection online %v", ss)
-		return rhd, nil, err
+		return rhd, nil, fmt.Errorf("old connection online %v", ss)
+	}
+	oldAO, suspended := tw.findSessionActiveObj(
+		ss.ActiveObjUUID, clientSessionUUID)
+	if oldAO != nil && !suspended {
+		// not suspended yet, refuse not to make copy of ao
+		// session not bound to this conn, conn end not touch ao of other conn
+		return rhd, nil, fmt.Errorf("ao in play %v", ss)
 	}
 	ss.ConnUUID = connData.UUID
 	connData.Session = ss
 
-	oldAO, exist := tw.id2aoSuspend.GetByUUID(connData.Session.ActiveObjUUID)
-	if exist {
+	if oldAO != nil {
 		// connect to exist ao
+		oldAO.SetSessionUUID(connData.Session.GetUUID())
+		connData.Session.ActiveObjUUID = oldAO.GetUUID()
 		oldAO.Resume(c2sc)
 		rspCh := make(chan error, 1)
 		tw.GetReqCh() <- &cmd2tower.ActiveObjResumeTower{
@@ -108,20 +142,45 @@ func (tw *Tower) bytesAPIFn_ReqLogin(
 		} else {
 			homeFloor = tw.GetFloorManager().GetStartFloor()
 		}
-		newAO := activeobject.NewUserActiveObj(
-			tw.rnd.Int63(),
-			homeFloor,
-			connData.Session.NickName,
-			tw.log,
-			tw.towerAchieveStat,
-			c2sc)
+		var newAO *activeobject.ActiveObject
+		aop := tw.takeAOPersistent(clientSessionUUID)
+		if aop != nil {
+			// restore saved ao
+			newAO = activeobject.NewUserActiveObjFromPersistent(
+				tw.rnd.Int63(),
+				tw.GetFloorManager(),
+				aop,
+				tw.log,
+				tw.towerAchieveStat,
+				c2sc)
+		} else {
+			newAO = activeobject.NewUserActiveObj(
+				tw.rnd.Int63(),
+				homeFloor,
+				connData.Session.NickName,
+				tw.log,
+				tw.towerAchieveStat,
+				c2sc)
+		}
+		newAO.SetSessionUUID(connData.Session.GetUUID())
 		connData.Session.ActiveObjUUID = newAO.GetUUID()
+		if aop != nil {
+			// made before enter, not to read ao in play
+			aop = newAO.ToPersistent()
+		}
 		rspCh := make(chan error, 1)
 		tw.GetReqCh() <- &cmd2tower.ActiveObjEnterTower{
 			ActiveObj: newAO,
 			RspCh:     rspCh,
 		}
 		err = <-rspCh
+		if aop != nil {
+			if err != nil {
+				tw.returnAOPersistent(clientSessionUUID)
+			} else {
+				tw.commitAOPersistent(clientSessionUUID, aop)
+			}
+		}
 	}
 
 	if err != nil {
@@ -190,8 +249,23 @@ func (tw *Tower) bytesAPIFn_ReqChat(
 		if len(robj.Chat) > gameconst.MaxChatLen {
 			robj.Chat = robj.Chat[:gameconst.MaxChatLen]
 		}
-		ao.SetChat(robj.Chat)
-		return rhd, &c2t_obj.RspChat_data{}, nil
+		if !robj.ChatType.IsValid() {
+			return c2t_packet.Header{
+				ErrorCode: c2t_error.ActionProhibited,
+			}, &c2t_obj.RspChat_data{}, nil
+		}
+		if !ao.CheckChatInterval(robj.ChatType, time.Now()) {
+			return c2t_packet.Header{
+				ErrorCode: c2t_error.TooFrequent,
+			}, &c2t_obj.RspChat_data{}, nil
+		}
+		if robj.ChatType == chattype.Near {
+			ao.SetChat(robj.Chat)
+			return rhd, &c2t_obj.RspChat_data{}, nil
+		}
+		return c2t_packet.Header{
+			ErrorCode: tw.sendChannelChat(ao, robj.ChatType, robj.To, robj.Chat),
+		}, &c2t_obj.RspChat_data{}, nil
 	}
 }
 
@@ -221,6 +295,79 @@ func (tw *Tower) bytesAPIFn_ReqAchieveInfo(
 	return rhd, spacket, nil
 }
 
+func (tw *Tower) bytesAPIFn_ReqAckObjectList(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqAckObjectList_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	ao.GetObjListSender().Ack(robj.Seq)
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, &c2t_obj.RspAckObjectList_data{}, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqSpectate(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+
+	c2sc, ok := me.(*c2t_serveconnbyte.ServeConnByte)
+	if !ok {
+		panic(fmt.Sprintf("invalid me not c2t_serveconnbyte.ServeConnByte %#v", me))
+	}
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqSpectate_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	connData := c2sc.GetConnData().(*conndata.ConnData)
+	if !connData.Spectator {
+		return c2t_packet.Header{
+			ErrorCode: c2t_error.ActionProhibited,
+		}, nil, fmt.Errorf("not spectator %v", connData)
+	}
+
+	autoFollow := robj.UUID == "" && robj.NickName == ""
+	var ao gamei.ActiveObjectI
+	switch {
+	case robj.UUID != "":
+		ao, _ = tw.id2ao.GetByUUID(robj.UUID)
+	case robj.NickName != "":
+		ao = tw.findActiveObjByNickName(robj.NickName)
+	default:
+		if rank := tw.GetExpRanking(); len(rank) > 0 {
+			ao = rank[0]
+		}
+	}
+	if ao == nil {
+		return c2t_packet.Header{
+			ErrorCode: c2t_error.ObjectNotFound,
+		}, &c2t_obj.RspSpectate_data{}, nil
+	}
+	tw.spectatorMan.Follow(connData.UUID, c2sc, ao.GetUUID(), autoFollow)
+	tw.startSpectate(c2sc, ao)
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, &c2t_obj.RspSpectate_data{
+		ActiveObjUUID: ao.GetUUID(),
+		NickName:      ao.GetNickName(),
+		AutoFollow:    autoFollow,
+	}, nil
+}
+
 func (tw *Tower) bytesAPIFn_ReqRebirth(
 	me interface{}, hd c2t_packet.Header, rbody []byte) (
 	c2t_packet.Header, interface{}, error) {
@@ -164,6 +164,63 @@ func (tw *Tower) bytesAPIFn_ReqAttackLong(
 	}, spacket, nil
 }
 
+func (tw *Tower) bytesAPIFn_ReqShoot(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqShoot_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	spacket := &c2t_obj.RspShoot_data{}
+
+	ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act: c2t_idcmd.Shoot,
+		Dir: robj.Dir,
+	})
+
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, spacket, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqCast(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqCast_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	spacket := &c2t_obj.RspCast_data{}
+
+	ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act:   c2t_idcmd.Cast,
+		Dir:   robj.Dir,
+		Skill: robj.Skill,
+		X:     robj.X,
+		Y:     robj.Y,
+	})
+
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, spacket, nil
+}
+
 func (tw *Tower) bytesAPIFn_ReqPickup(
 	me interface{}, hd c2t_packet.Header, rbody []byte) (
 	c2t_packet.Header, interface{}, error) {
@@ -346,6 +403,136 @@ func (tw *Tower) bytesAPIFn_ReqRecycle(
 	}, spacket, nil
 }
 
+func (tw *Tower) bytesAPIFn_ReqCraft(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqCraft_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	spacket := &c2t_obj.RspCraft_data{}
+	ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act:    c2t_idcmd.Craft,
+		Recipe: robj.Recipe,
+	})
+
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, spacket, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqBuy(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqBuy_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	spacket := &c2t_obj.RspBuy_data{}
+	ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act:  c2t_idcmd.Buy,
+		UUID: robj.UUID,
+	})
+
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, spacket, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqRepair(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqRepair_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	spacket := &c2t_obj.RspRepair_data{}
+	ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act:  c2t_idcmd.Repair,
+		UUID: robj.UUID,
+	})
+
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, spacket, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqAcceptQuest(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqAcceptQuest_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	spacket := &c2t_obj.RspAcceptQuest_data{}
+	ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act:   c2t_idcmd.AcceptQuest,
+		Quest: robj.Name,
+	})
+
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, spacket, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqCompleteQuest(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqCompleteQuest_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	spacket := &c2t_obj.RspCompleteQuest_data{}
+	ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act:   c2t_idcmd.CompleteQuest,
+		Quest: robj.Name,
+	})
+
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, spacket, nil
+}
+
 func (tw *Tower) bytesAPIFn_ReqEnterPortal(
 	me interface{}, hd c2t_packet.Header, rbody []byte) (
 	c2t_packet.Header, interface{}, error) {
@@ -379,3 +566,83 @@ func (tw *Tower) bytesAPIFn_ReqActTeleport(
 		ErrorCode: c2t_error.None,
 	}, spacket, nil
 }
+
+func (tw *Tower) bytesAPIFn_ReqOpenDoor(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqOpenDoor_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	spacket := &c2t_obj.RspOpenDoor_data{}
+	ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act: c2t_idcmd.OpenDoor,
+		Dir: robj.Dir,
+	})
+
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, spacket, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqCloseDoor(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqCloseDoor_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	spacket := &c2t_obj.RspCloseDoor_data{}
+	ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act: c2t_idcmd.CloseDoor,
+		Dir: robj.Dir,
+	})
+
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, spacket, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqCommandPet(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqCommandPet_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	spacket := &c2t_obj.RspCommandPet_data{}
+	ao.SetReq2Handle(&aoactreqrsp.Act{
+		Act:      c2t_idcmd.CommandPet,
+		UUID:     robj.PetUUID,
+		PetOrder: robj.Order,
+		Target:   robj.TargetUUID,
+	})
+
+	return c2t_packet.Header{
+		ErrorCode: c2t_error.None,
+	}, spacket, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tower
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/game/cmd2floor"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_packet"
+)
+
+// bytesAPIFn_ReqListQuest quest progress read in floor goroutine, not to race with turn
+func (tw *Tower) bytesAPIFn_ReqListQuest(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return hd, nil, err
+	}
+	f := ao.GetCurrentFloor()
+	if f == nil {
+		return hd, nil, fmt.Errorf("user not in floor %v", me)
+	}
+	rspCh := make(chan cmd2floor.ListQuestResult, 1)
+	f.GetReqCh() <- &cmd2floor.APIListQuest{
+		ActiveObj: ao,
+		RspCh:     rspCh,
+	}
+	rs := <-rspCh
+	spacket := rs.Rsp
+	if spacket == nil {
+		spacket = &c2t_obj.RspListQuest_data{}
+	}
+	return c2t_packet.Header{
+		ErrorCode: rs.ErrorCode,
+	}, spacket, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tower
+
+import (
+	"fmt"
+
+	"github.com/kasworld/goguelike/game/cmd2floor"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_gob"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_packet"
+)
+
+// api_trade2floor trade processed in floor goroutine, not to race with turn
+func (tw *Tower) api_trade2floor(me interface{}, reqPk interface{}) (c2t_error.ErrorCode, error) {
+	ao, err := tw.api_me2ao(me)
+	if err != nil {
+		return c2t_error.None, err
+	}
+	f := ao.GetCurrentFloor()
+	if f == nil {
+		return c2t_error.None, fmt.Errorf("user not in floor %v", me)
+	}
+	rspCh := make(chan c2t_error.ErrorCode, 1)
+	f.GetReqCh() <- &cmd2floor.APITrade{
+		ActiveObj: ao,
+		ReqPk:     reqPk,
+		RspCh:     rspCh,
+	}
+	return <-rspCh, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqTradePropose(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqTradePropose_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ec, err := tw.api_trade2floor(me, robj)
+	if err != nil {
+		return hd, nil, err
+	}
+	return c2t_packet.Header{
+		ErrorCode: ec,
+	}, &c2t_obj.RspTradePropose_data{}, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqTradeAmend(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqTradeAmend_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ec, err := tw.api_trade2floor(me, robj)
+	if err != nil {
+		return hd, nil, err
+	}
+	return c2t_packet.Header{
+		ErrorCode: ec,
+	}, &c2t_obj.RspTradeAmend_data{}, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqTradeAccept(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqTradeAccept_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ec, err := tw.api_trade2floor(me, robj)
+	if err != nil {
+		return hd, nil, err
+	}
+	return c2t_packet.Header{
+		ErrorCode: ec,
+	}, &c2t_obj.RspTradeAccept_data{}, nil
+}
+
+func (tw *Tower) bytesAPIFn_ReqTradeCancel(
+	me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+
+	r, err := c2t_gob.UnmarshalPacket(hd, rbody)
+	if err != nil {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", rbody)
+	}
+	robj, ok := r.(*c2t_obj.ReqTradeCancel_data)
+	if !ok {
+		return hd, nil, fmt.Errorf("Packet type miss match %v", r)
+	}
+	ec, err := tw.api_trade2floor(me, robj)
+	if err != nil {
+		return hd, nil, err
+	}
+	return c2t_packet.Header{
+		ErrorCode: ec,
+	}, &c2t_obj.RspTradeCancel_data{}, nil
+}
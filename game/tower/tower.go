@@ -22,17 +22,28 @@ import (
 	"github.com/kasworld/actpersec"
 	"github.com/kasworld/g2rand"
 	"github.com/kasworld/goguelike/config/authdata"
+	"github.com/kasworld/goguelike/config/craftdata"
 	"github.com/kasworld/goguelike/config/dataversion"
 	"github.com/kasworld/goguelike/config/gamedata"
+	"github.com/kasworld/goguelike/config/questdata"
+	"github.com/kasworld/goguelike/config/skilldata"
 	"github.com/kasworld/goguelike/config/towerconfig"
+	"github.com/kasworld/goguelike/enum/aotype"
 	"github.com/kasworld/goguelike/enum/towerachieve_vector"
 	"github.com/kasworld/goguelike/game/activeobject"
 	"github.com/kasworld/goguelike/game/aoexpsort"
 	"github.com/kasworld/goguelike/game/aoid2activeobject"
 	"github.com/kasworld/goguelike/game/aoid2floor"
+	"github.com/kasworld/goguelike/game/aopersistent"
+	"github.com/kasworld/goguelike/game/diplomacy"
 	"github.com/kasworld/goguelike/game/floormanager"
 	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/identify"
+	"github.com/kasworld/goguelike/game/spectatorman"
 	"github.com/kasworld/goguelike/game/towerscript"
+	"github.com/kasworld/goguelike/game/towersnapshot"
+	"github.com/kasworld/goguelike/game/turnrecorder"
+	"github.com/kasworld/goguelike/lib/cmdratelimit"
 	"github.com/kasworld/goguelike/lib/g2log"
 	"github.com/kasworld/goguelike/lib/loadlines"
 	"github.com/kasworld/goguelike/lib/sessionmanager"
@@ -85,6 +96,33 @@ type Tower struct {
 	aoExpRankingSuspended aoexpsort.ByExp                             `prettystring:"simple"`
 	aoExpRanking          aoexpsort.ByExp                             `prettystring:"simple"`
 
+	// save/load user ao, nil if disabled
+	aoStore aopersistent.StoreI `prettystring:"simple"`
+
+	// token bucket of each command, shared by client connections
+	cmdBudgetList []cmdratelimit.Budget `prettystring:"simple"`
+
+	// connection follow ao without control
+	spectatorMan *spectatorman.SpectatorManager `prettystring:"simple"`
+
+	// record floor turn, nil if disabled
+	turnRecorder *turnrecorder.Recorder `prettystring:"simple"`
+	// valid in ReplayTurnRecord, fix tower bias to recorded turn
+	replayTurnTime time.Time `prettystring:"simple"`
+
+	// potion, scroll name shown before identified
+	appearance *identify.Appearance `prettystring:"simple"`
+	// seed of appearance, random at new tower, kept in snapshot
+	appearanceSeed int64
+
+	// quest of this tower, read only after ServiceInit
+	questList []*questdata.Quest `prettystring:"simple"`
+
+	// faction relation by tower script, changed by admin
+	diplomacy *diplomacy.Diplomacy `prettystring:"simple"`
+	// diplomacy lines of tower script, to check snapshot diplomacy
+	diplomacyScript []string
+
 	serviceInfo *c2t_obj.ServiceInfo
 	towerInfo   *c2t_obj.TowerInfo
 	conn2ground *Conn2Ground `prettystring:"simple"`
@@ -124,6 +162,7 @@ func New(config *towerconfig.TowerConfig, log *g2log.LogBase) *Tower {
 		uuid:         uuidstr.New(),
 		id2ao:        aoid2activeobject.New("ActiveObject working"),
 		id2aoSuspend: aoid2activeobject.New("ActiveObject suspended"),
+		spectatorMan: spectatorman.New(),
 		recvRequestCh: make(chan interface{},
 			int(float64(config.ConcurrentConnections*2)*config.TurnPerSec)),
 
@@ -140,6 +179,8 @@ func New(config *towerconfig.TowerConfig, log *g2log.LogBase) *Tower {
 		towerCmdActStat:     actpersec.New(),
 		towerAchieveStat:    new(towerachieve_vector.TowerAchieveVector),
 	}
+	tw.appearanceSeed = tw.rnd.Int63()
+	tw.appearance = identify.NewAppearanceBySeed(tw.appearanceSeed)
 	tw.connManager = c2t_connbytemanager.New()
 
 	tw.sessionManager = sessionmanager.New("",
@@ -180,19 +221,21 @@ func (tw *Tower) ServiceInit() error {
 
 	var err error
 
-	gamedata.ActiveObjNameList, err = loadlines.LoadLineList(
-		filepath.Join(tw.Config().DataFolder, "ainames.txt"),
-	)
-	if err != nil {
-		tw.log.Fatal("load ainame fail %v", err)
+	if err := tw.loadGameData(); err != nil {
 		return err
 	}
 
-	gamedata.ChatData, err = loadlines.LoadLineList(
-		filepath.Join(tw.Config().DataFolder, "chatdata.txt"),
+	tw.cmdBudgetList, err = cmdratelimit.ParseBudgetList(
+		cmdratelimit.Budget{
+			RatePerSec: tw.sconfig.CmdRatePerSec,
+			Burst:      tw.sconfig.CmdRateBurst,
+		},
+		c2t_idcmd.CommandID_Count,
+		func(i int) string { return c2t_idcmd.CommandID(i).String() },
+		tw.sconfig.CmdRateBudget,
 	)
 	if err != nil {
-		tw.log.Fatal("load chatdata fail %v", err)
+		tw.log.Fatal("invalid CmdRateBudget %v", err)
 		return err
 	}
 
@@ -202,14 +245,67 @@ func (tw *Tower) ServiceInit() error {
 	if err != nil {
 		return err
 	}
+	tScript, diplomacyLines := tScript.SplitCmd(diplomacy.IsCmdline)
+	tw.diplomacy = diplomacy.New()
+	tw.diplomacyScript = diplomacyLines
+	if err := tw.diplomacy.ExecCmdlineList(diplomacyLines); err != nil {
+		tw.log.Fatal("invalid diplomacy %v", err)
+		return err
+	}
 
 	tw.ao2Floor = aoid2floor.New(tw)
 	tw.biasFactor = tw.NewRandFactor()
 
 	tw.floorMan = floormanager.New(tScript, tw)
-	if err := tw.floorMan.Init(tw.rnd); err != nil {
+	var snapshot *towersnapshot.TowerSnapshot
+	if tw.sconfig.UseSnapshot {
+		snapshot, err = towersnapshot.Load(tw.sconfig.MakeSnapshotFileFullpath())
+		if err != nil {
+			tw.log.Warn("fail to load snapshot, make new floors %v", err)
+			snapshot = nil
+		}
+	}
+	if snapshot != nil {
+		tw.log.TraceService("restore floors from %v", snapshot)
+		tw.appearanceSeed = snapshot.AppearanceSeed
+		tw.appearance = identify.NewAppearanceBySeed(tw.appearanceSeed)
+		if ds, ok := snapshot.GetDiplomacyByScript(tw.diplomacyScript); ok {
+			tw.diplomacy.SetSnapshot(ds)
+		} else {
+			tw.log.TraceService("diplomacy script changed, snapshot diplomacy ignored")
+		}
+		if err := tw.floorMan.InitFromSnapshot(tw.rnd, snapshot); err != nil {
+			return err
+		}
+	} else {
+		if err := tw.floorMan.Init(tw.rnd); err != nil {
+			return err
+		}
+	}
+
+	questLines, err := loadlines.LoadLineList(
+		filepath.Join(tw.Config().DataFolder, "quest.txt"),
+	)
+	if err != nil {
+		tw.log.Fatal("load quest fail %v", err)
+		return err
+	}
+	questList, err := questdata.ParseQuestList(questLines)
+	if err != nil {
+		tw.log.Fatal("invalid quest %v", err)
 		return err
 	}
+	tw.questList = tw.selectQuestByFloor(questList)
+
+	if tw.aoStore == nil && tw.sconfig.AOPersistentDir != "" {
+		tw.aoStore, err = aopersistent.NewFileStore(
+			tw.sconfig.MakeAOPersistentDirFullpath(),
+		)
+		if err != nil {
+			tw.log.Fatal("fail to make aopersistent store %v", err)
+			return err
+		}
+	}
 	tw.startTime = time.Now()
 	tw.towerInfo = &c2t_obj.TowerInfo{
 		StartTime:     tw.startTime,
@@ -219,6 +315,25 @@ func (tw *Tower) ServiceInit() error {
 		TotalFloorNum: tw.floorMan.GetFloorCount(),
 		TurnPerSec:    tw.sconfig.TurnPerSec,
 	}
+	for _, v := range gamedata.CraftRecipeList {
+		tw.towerInfo.CraftRecipeList = append(tw.towerInfo.CraftRecipeList, v.Name)
+	}
+
+	if tw.sconfig.RecordTurn {
+		tw.turnRecorder, err = turnrecorder.New(
+			tw.sconfig.MakeTurnRecordFileFullpath(),
+			turnrecorder.Header{
+				TowerName:  tw.sconfig.TowerName,
+				StartTime:  tw.startTime,
+				BiasFactor: tw.biasFactor,
+				Snapshot:   tw.makeSnapshot(),
+			},
+		)
+		if err != nil {
+			tw.log.Fatal("fail to make turn recorder %v", err)
+			return err
+		}
+	}
 
 	tw.conn2ground = NewConn2Ground(tw.sconfig.GroundRPC)
 
@@ -236,10 +351,34 @@ func (tw *Tower) ServiceInit() error {
 	return nil
 }
 
+// makeSnapshot floor snapshot with tower state
+func (tw *Tower) makeSnapshot() *towersnapshot.TowerSnapshot {
+	ts := tw.floorMan.ToSnapshot()
+	ts.AppearanceSeed = tw.appearanceSeed
+	ts.DiplomacyScript = tw.diplomacyScript
+	ts.Diplomacy = tw.diplomacy.ToSnapshot()
+	return ts
+}
+
 func (tw *Tower) ServiceCleanup() {
 	tw.log.TraceService("Start ServiceCleanup %v", tw)
 	defer func() { tw.log.TraceService("End ServiceCleanup %v", tw) }()
 
+	for _, ao := range tw.id2ao.GetAllList() {
+		if ao.GetActiveObjType() == aotype.User {
+			tw.saveAOPersistent(ao)
+		}
+	}
+	if tw.sconfig.UseSnapshot {
+		if err := tw.makeSnapshot().Save(tw.sconfig.MakeSnapshotFileFullpath()); err != nil {
+			tw.log.Error("fail to save snapshot %v", err)
+		}
+	}
+	if tw.turnRecorder != nil {
+		if err := tw.turnRecorder.Close(); err != nil {
+			tw.log.Error("fail to close turn recorder %v", err)
+		}
+	}
 	tw.id2ao.Cleanup()
 	tw.ao2Floor.Cleanup()
 	for _, f := range tw.floorMan.GetFloorList() {
@@ -278,6 +417,17 @@ func (tw *Tower) ServiceMain(mainctx context.Context) {
 			}
 		}
 		totalaocount += f.GetTerrain().GetActiveObjCount()
+		for _, bs := range f.GetTerrain().GetBossList() {
+			ao := activeobject.NewBossActiveObj(tw.rnd.Int63(), f, bs, tw.log, tw.towerAchieveStat)
+			if err := tw.ao2Floor.ActiveObjEnterTower(f, ao); err != nil {
+				tw.log.Error("%v", err)
+				continue
+			}
+			if err := tw.id2ao.Add(ao); err != nil {
+				tw.log.Error("%v", err)
+			}
+			totalaocount++
+		}
 	}
 	tw.log.Monitor("Total system ActiveObj in tower %v", totalaocount)
 
@@ -286,6 +436,9 @@ func (tw *Tower) ServiceMain(mainctx context.Context) {
 
 	go retrylistenandserve.RetryListenAndServe(tw.adminWeb, tw.log, "serveAdminWeb")
 	go retrylistenandserve.RetryListenAndServe(tw.clientWeb, tw.log, "serveServiceWeb")
+	if tw.sconfig.ServiceTCPPort != 0 {
+		go tw.listenTCPClient(ctx)
+	}
 loop:
 	for {
 		select {
@@ -353,6 +506,7 @@ loop:
 						fmt.Sprintf("Connection: %v", tw.connManager.Len()),
 						fmt.Sprintf("Pause: %v", tw.listenClientPaused),
 						fmt.Sprintf("Session: %v", tw.sessionManager.Count()),
+						fmt.Sprintf("Spectator: %v", tw.spectatorMan.Count()),
 					})
 				}
 			}
@@ -366,12 +520,19 @@ func (tw *Tower) makeActiveObjExpRank() {
 		v.UpdateExpCopy()
 	}
 	aoexpsort.ByExp(rtn).Sort()
+	tw.mutex.Lock()
 	tw.aoExpRanking = rtn
+	tw.mutex.Unlock()
+	if len(rtn) > 0 {
+		tw.updateSpectatorAutoFollow(rtn[0])
+	}
 }
 func (tw *Tower) makeActiveObjExpRankSuspended() {
 	rtn := tw.id2aoSuspend.GetAllList()
 	aoexpsort.ByExp(rtn).Sort()
+	tw.mutex.Lock()
 	tw.aoExpRankingSuspended = rtn
+	tw.mutex.Unlock()
 }
 
 func (tw *Tower) Ground_Register() {
@@ -428,6 +589,129 @@ func (tw *Tower) Ground_HighScore(ao gamei.ActiveObjectI) {
 	)
 }
 
+// SetAOPersistentStore replace default FileStore, call before ServiceInit
+func (tw *Tower) SetAOPersistentStore(st aopersistent.StoreI) {
+	tw.aoStore = st
+}
+
+// loadGameData load data files of DataFolder to gamedata
+// used in ServiceInit and ReplayTurnRecord
+func (tw *Tower) loadGameData() error {
+	var err error
+	gamedata.ActiveObjNameList, err = loadlines.LoadLineList(
+		filepath.Join(tw.Config().DataFolder, "ainames.txt"),
+	)
+	if err != nil {
+		tw.log.Fatal("load ainame fail %v", err)
+		return err
+	}
+
+	gamedata.ChatData, err = loadlines.LoadLineList(
+		filepath.Join(tw.Config().DataFolder, "chatdata.txt"),
+	)
+	if err != nil {
+		tw.log.Fatal("load chatdata fail %v", err)
+		return err
+	}
+
+	recipeLines, err := loadlines.LoadLineList(
+		filepath.Join(tw.Config().DataFolder, "craftrecipe.txt"),
+	)
+	if err != nil {
+		tw.log.Fatal("load craftrecipe fail %v", err)
+		return err
+	}
+	gamedata.CraftRecipeList, err = craftdata.ParseRecipeList(recipeLines)
+	if err != nil {
+		tw.log.Fatal("invalid craftrecipe %v", err)
+		return err
+	}
+
+	skillLines, err := loadlines.LoadLineList(
+		filepath.Join(tw.Config().DataFolder, "skilldata.txt"),
+	)
+	if err != nil {
+		tw.log.Fatal("load skilldata fail %v", err)
+		return err
+	}
+	gamedata.SkillList, err = skilldata.ParseSkillList(skillLines)
+	if err != nil {
+		tw.log.Fatal("invalid skilldata %v", err)
+		return err
+	}
+	return nil
+}
+
+// findSessionActiveObj find user ao of session in tower
+// by ao uuid of session or by client session uuid (session deleted, reissued)
+// return ao, suspended
+func (tw *Tower) findSessionActiveObj(
+	aouuid string, clientSessionUUID string) (gamei.ActiveObjectI, bool) {
+
+	if ao, exist := tw.id2ao.GetByUUID(aouuid); exist {
+		return ao, false
+	}
+	if ao, exist := tw.id2aoSuspend.GetByUUID(aouuid); exist {
+		return ao, true
+	}
+	if clientSessionUUID == "" {
+		return nil, false
+	}
+	for _, ao := range tw.id2ao.GetAllList() {
+		if ao.GetSessionUUID() == clientSessionUUID {
+			return ao, false
+		}
+	}
+	for _, ao := range tw.id2aoSuspend.GetAllList() {
+		if ao.GetSessionUUID() == clientSessionUUID {
+			return ao, true
+		}
+	}
+	return nil, false
+}
+
+// takeAOPersistent load saved ao of session, held by store not to restore twice
+// must returnAOPersistent or commitAOPersistent after enter tower
+func (tw *Tower) takeAOPersistent(sessionuuid string) *aopersistent.AOPersistent {
+	if tw.aoStore == nil || sessionuuid == "" {
+		return nil
+	}
+	aop, err := tw.aoStore.Take(sessionuuid)
+	if err != nil {
+		tw.log.Debug("no aopersistent %v %v", sessionuuid, err)
+		return nil
+	}
+	return aop
+}
+
+// returnAOPersistent put back taken ao, fail to enter tower
+func (tw *Tower) returnAOPersistent(sessionuuid string) {
+	if err := tw.aoStore.Return(sessionuuid); err != nil {
+		tw.log.Error("fail to return aopersistent %v %v", sessionuuid, err)
+	}
+}
+
+// commitAOPersistent save entered ao with its current session then drop taken
+// keep taken on save fail, restored by next run
+func (tw *Tower) commitAOPersistent(takenSessionUUID string, aop *aopersistent.AOPersistent) {
+	if err := tw.aoStore.Save(aop); err != nil {
+		tw.log.Error("fail to save aopersistent %v %v", aop, err)
+		return
+	}
+	if err := tw.aoStore.Commit(takenSessionUUID); err != nil {
+		tw.log.Error("fail to commit aopersistent %v %v", takenSessionUUID, err)
+	}
+}
+
+func (tw *Tower) saveAOPersistent(ao gamei.ActiveObjectI) {
+	if tw.aoStore == nil {
+		return
+	}
+	if err := tw.aoStore.Save(ao.ToPersistent()); err != nil {
+		tw.log.Error("fail to save aopersistent %v %v", ao, err)
+	}
+}
+
 func (tw *Tower) NewRandFactor() [3]int64 {
 	// st := 0
 	lenPrimes := len(gamedata.Primes)
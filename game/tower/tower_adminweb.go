@@ -63,6 +63,9 @@ func (tw *Tower) initAdminWeb() {
 	webMux.HandleFuncAuth("/StatNotification", tw.web_NotiStat)
 	webMux.HandleFuncAuth("/StatAPIError", tw.web_ErrorStat)
 	webMux.HandleFuncAuth("/towerStat", tw.web_towerStat)
+	webMux.HandleFuncAuth("/QuestList", tw.web_QuestList)
+	webMux.HandleFuncAuth("/Quest", tw.web_Quest)
+	webMux.HandleFuncAuth("/Diplomacy", tw.web_Diplomacy)
 
 	webMux.HandleFuncAuth("/terrain", tw.web_TerrainInfo)
 	webMux.HandleFuncAuth("/terrainimagezoom", tw.web_TerrainImageZoom)
@@ -215,6 +218,10 @@ func (tw *Tower) web_TowerInfo(w http.ResponseWriter, r *http.Request) {
     <br/>
     <a href="/ActiveObjSuspendedList?page=0" target="_blank">{{.GetID2ActiveObjSuspend}}</a>
     <br/>
+    <a href="/QuestList" target="_blank">Quest:{{len .GetQuestList}}</a>
+    <br/>
+    <a href="/Diplomacy" target="_blank">{{.GetDiplomacy}}</a>
+    <br/>
     <a href="/ConnectionList?page=0" target="_blank">Connections:{{.GetConnManager}}</a>
     <br/>
     <a href="/SessionList?page=0" target="_blank">{{.GetSessionManager}}</a>
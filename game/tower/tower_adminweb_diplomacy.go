@@ -0,0 +1,99 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tower
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/relation"
+	"github.com/kasworld/goguelike/game/diplomacy"
+	"github.com/kasworld/weblib"
+)
+
+// diplomacyRow relation of a faction, for web
+type diplomacyRow struct {
+	Faction  factiontype.FactionType
+	Relation []relation.Relation
+}
+
+// web_Diplomacy show relation matrix, exec diplomacy cmd if cmd arg exist
+func (tw *Tower) web_Diplomacy(w http.ResponseWriter, r *http.Request) {
+	cmdline := weblib.GetStringByName("cmd", "", w, r)
+	result := ""
+	if cmdline != "" {
+		if !diplomacy.IsCmdline(cmdline) {
+			result = "not diplomacy cmd " + cmdline
+		} else if err := tw.diplomacy.ExecCmdline(cmdline); err != nil {
+			result = err.Error()
+		} else {
+			result = "done " + cmdline
+			tw.log.Monitor("web diplomacy %v", cmdline)
+		}
+	}
+	if err := weblib.SetFresh(w, r); err != nil {
+		tw.log.Error("%v", err)
+	}
+
+	rows := make([]diplomacyRow, factiontype.FactionType_Count)
+	for i := range rows {
+		ft := factiontype.FactionType(i)
+		rows[i].Faction = ft
+		for j := 0; j < factiontype.FactionType_Count; j++ {
+			rows[i].Relation = append(rows[i].Relation,
+				tw.diplomacy.Get(ft, factiontype.FactionType(j)))
+		}
+	}
+	attackRate := make([]float64, relation.Relation_Count)
+	for i := range attackRate {
+		attackRate[i] = tw.diplomacy.GetAttackRate(relation.Relation(i))
+	}
+
+	weblib.WebFormBegin("diplomacy", w, r)
+	tplIndex, err := template.New("index").Parse(`
+	{{.Diplomacy}} {{.Result}}
+	<br/>
+	<form action="/Diplomacy">
+		<input type="text" name="cmd" value="Diplomacy faction1= faction2= relation=" size="64">
+		<input type="submit" value="Exec">
+	</form>
+	AttackRate Hostile {{index .AttackRate 0}} Neutral {{index .AttackRate 1}} Ally {{index .AttackRate 2}}
+	<table border=1 style="border-collapse:collapse;">
+	{{range $i, $v := .Rows}}
+	<tr>
+	<td>{{$v.Faction}}</td>
+	{{range $j, $rl := $v.Relation}}
+	<td>{{$rl}}</td>
+	{{end}}
+	</tr>
+	{{end}}
+	</table>
+	`)
+	if err != nil {
+		tw.log.Error("%v", err)
+	}
+	if err := tplIndex.Execute(w, struct {
+		Diplomacy  *diplomacy.Diplomacy
+		Result     string
+		AttackRate []float64
+		Rows       []diplomacyRow
+	}{
+		tw.diplomacy,
+		result,
+		attackRate,
+		rows,
+	}); err != nil {
+		tw.log.Error("%v", err)
+	}
+	weblib.WebFormEnd(w, r)
+}
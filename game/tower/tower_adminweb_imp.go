@@ -17,6 +17,7 @@ import (
 	"net/http"
 
 	"github.com/kasworld/goguelike/game/activeobject"
+	"github.com/kasworld/goguelike/game/aoexpsort"
 	"github.com/kasworld/goguelike/game/cmd2tower"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
@@ -163,7 +164,7 @@ func (tw *Tower) web_ActiveObjVisitFloorImage(w http.ResponseWriter, r *http.Req
 }
 
 func (tw *Tower) web_ActiveObjRankingList(w http.ResponseWriter, r *http.Request) {
-	allActiveObj := tw.aoExpRanking
+	allActiveObj := aoexpsort.ByExp(tw.GetExpRanking())
 	page := weblib.GetPage(w, r)
 	listActiveObj := allActiveObj.GetPage(page, 40)
 	weblib.WebFormBegin("activeobject list", w, r)
@@ -188,7 +189,7 @@ func (tw *Tower) web_ActiveObjRankingList(w http.ResponseWriter, r *http.Request
 }
 
 func (tw *Tower) web_ActiveObjSuspendedList(w http.ResponseWriter, r *http.Request) {
-	allActiveObj := tw.aoExpRankingSuspended
+	allActiveObj := aoexpsort.ByExp(tw.getExpRankingSuspended())
 	page := weblib.GetPage(w, r)
 	listActiveObj := allActiveObj.GetPage(page, 40)
 	weblib.WebFormBegin("activeobject suspended list", w, r)
@@ -0,0 +1,138 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tower
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/kasworld/goguelike/config/questdata"
+	"github.com/kasworld/goguelike/game/aoquest"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/weblib"
+)
+
+// questAOProgress progress of an ao, for web
+type questAOProgress struct {
+	ActiveObj gamei.ActiveObjectI
+	Suspended bool
+	Progress  *aoquest.Progress
+}
+
+// questStat accepted, completed ao count of quest, for web
+type questStat struct {
+	Quest     *questdata.Quest
+	Accepted  int
+	Completed int
+	AOList    []questAOProgress
+}
+
+// makeQuestStat include suspended ao
+func (tw *Tower) makeQuestStat(q *questdata.Quest) *questStat {
+	qs := &questStat{
+		Quest: q,
+	}
+	add := func(aoList []gamei.ActiveObjectI, suspended bool) {
+		for _, ao := range aoList {
+			p := aoquest.Find(ao.GetQuestProgressList(), q.Name)
+			if p == nil {
+				continue
+			}
+			qs.Accepted++
+			if p.Completed {
+				qs.Completed++
+			}
+			qs.AOList = append(qs.AOList, questAOProgress{ao, suspended, p})
+		}
+	}
+	add(tw.id2ao.GetAllList(), false)
+	add(tw.id2aoSuspend.GetAllList(), true)
+	return qs
+}
+
+func (tw *Tower) web_QuestList(w http.ResponseWriter, r *http.Request) {
+	var qsList []*questStat
+	for _, q := range tw.questList {
+		qsList = append(qsList, tw.makeQuestStat(q))
+	}
+	weblib.WebFormBegin("quest list", w, r)
+	tplIndex, err := template.New("index").Parse(`
+	<table border=1 style="border-collapse:collapse;">
+	<tr>
+	<td>Quest</td> <td>Giver</td> <td>Objective</td> <td>Reward</td>
+	<td>Accepted</td> <td>Completed</td>
+	</tr>
+	{{range $i, $v := .}}
+	<tr>
+	<td><a href="/Quest?name={{$v.Quest.Name}}" target="_blank">{{$v.Quest.Name}}</a></td>
+	<td>{{$v.Quest.GiverString}}</td>
+	<td>{{$v.Quest.Objective}}</td>
+	<td>{{$v.Quest.Reward}}</td>
+	<td>{{$v.Accepted}}</td>
+	<td>{{$v.Completed}}</td>
+	</tr>
+	{{end}}
+	</table>
+	`)
+	if err != nil {
+		tw.log.Error("%v", err)
+	}
+	if err := tplIndex.Execute(w, qsList); err != nil {
+		tw.log.Error("%v", err)
+	}
+	weblib.WebFormEnd(w, r)
+}
+
+func (tw *Tower) web_Quest(w http.ResponseWriter, r *http.Request) {
+	name := weblib.GetStringByName("name", "", w, r)
+	q := questdata.FindByName(tw.questList, name)
+	if q == nil {
+		tw.log.Warn("quest not found %v", name)
+		http.Error(w, "quest not found", 404)
+		return
+	}
+	if err := weblib.SetFresh(w, r); err != nil {
+		tw.log.Error("%v", err)
+	}
+	weblib.WebFormBegin("quest progress", w, r)
+	tplIndex, err := template.New("index").Parse(`
+	{{.Quest}}
+	<br/>
+	Accepted {{.Accepted}} Completed {{.Completed}}
+	<table border=1 style="border-collapse:collapse;">
+	<tr>
+	<td>ActiveObj</td> <td>Suspended</td> <td>Count</td> <td>Completed</td>
+	</tr>
+	{{range $i, $v := .AOList}}
+	<tr>
+	<td>
+	{{if $v.Suspended}}
+		{{$v.ActiveObj}}
+	{{else}}
+		<a href="/ActiveObj?aoid={{$v.ActiveObj.GetUUID}}" target="_blank">{{$v.ActiveObj}}</a>
+	{{end}}
+	</td>
+	<td>{{$v.Suspended}}</td>
+	<td>{{$v.Progress.Count}}</td>
+	<td>{{$v.Progress.Completed}}</td>
+	</tr>
+	{{end}}
+	</table>
+	`)
+	if err != nil {
+		tw.log.Error("%v", err)
+	}
+	if err := tplIndex.Execute(w, tw.makeQuestStat(q)); err != nil {
+		tw.log.Error("%v", err)
+	}
+	weblib.WebFormEnd(w, r)
+}
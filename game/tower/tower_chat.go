@@ -0,0 +1,100 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tower
+
+import (
+	"github.com/kasworld/goguelike/enum/chattype"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+)
+
+// sendChannelChat send chat noti to connected ao in channel of ct
+// Near chat is not channel, shown by ObjectList
+func (tw *Tower) sendChannelChat(
+	ao gamei.ActiveObjectI, ct chattype.ChatType, to string, chat string) c2t_error.ErrorCode {
+
+	if chat == "" {
+		return c2t_error.ActionProhibited
+	}
+	switch ct {
+	default:
+		tw.log.Error("invalid chattype %v %v", ao, ct)
+		return c2t_error.ActionProhibited
+
+	case chattype.Whisper:
+		dstAO := tw.findActiveObjByNickName(to)
+		if dstAO == nil || dstAO.GetClientConn() == nil {
+			return c2t_error.ObjectNotFound
+		}
+		tw.sendChatNoti(dstAO, c2t_idnoti.ChatWhisper,
+			&c2t_obj.NotiChatWhisper_data{
+				SenderUUID: ao.GetUUID(),
+				SenderName: ao.GetNickName(),
+				Chat:       chat,
+			})
+
+	case chattype.Floor:
+		f := ao.GetCurrentFloor()
+		if f == nil {
+			return c2t_error.ActionProhibited
+		}
+		noti := &c2t_obj.NotiChatFloor_data{
+			FloorName:  f.GetName(),
+			SenderUUID: ao.GetUUID(),
+			SenderName: ao.GetNickName(),
+			Chat:       chat,
+		}
+		for _, dstAO := range tw.id2ao.GetAllList() {
+			if dstAO.GetCurrentFloor() == f {
+				tw.sendChatNoti(dstAO, c2t_idnoti.ChatFloor, noti)
+			}
+		}
+
+	case chattype.Tower:
+		noti := &c2t_obj.NotiChatTower_data{
+			SenderUUID: ao.GetUUID(),
+			SenderName: ao.GetNickName(),
+			Chat:       chat,
+		}
+		for _, dstAO := range tw.id2ao.GetAllList() {
+			tw.sendChatNoti(dstAO, c2t_idnoti.ChatTower, noti)
+		}
+
+	case chattype.Faction:
+		ft := ao.GetBias().NearFaction()
+		noti := &c2t_obj.NotiChatFaction_data{
+			Faction:    ft,
+			SenderUUID: ao.GetUUID(),
+			SenderName: ao.GetNickName(),
+			Chat:       chat,
+		}
+		for _, dstAO := range tw.id2ao.GetAllList() {
+			if dstAO.GetBias().NearFaction() == ft {
+				tw.sendChatNoti(dstAO, c2t_idnoti.ChatFaction, noti)
+			}
+		}
+	}
+	return c2t_error.None
+}
+
+func (tw *Tower) sendChatNoti(dstAO gamei.ActiveObjectI,
+	idnoti c2t_idnoti.NotiID, body interface{}) {
+	aoconn := dstAO.GetClientConn()
+	if aoconn == nil {
+		return
+	}
+	if err := aoconn.SendNotiPacket(idnoti, body); err != nil {
+		tw.log.Error("%v %v %v", tw, dstAO, err)
+	}
+}
@@ -13,7 +13,14 @@ package tower
 
 import (
 	"github.com/kasworld/goguelike/enum/achievetype"
+	"github.com/kasworld/goguelike/enum/aotype"
+	"github.com/kasworld/goguelike/enum/petorder"
+	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/enum/towerachieve"
+	"github.com/kasworld/goguelike/game/activeobject"
+	"github.com/kasworld/goguelike/game/carryingobject"
 	"github.com/kasworld/goguelike/game/cmd2tower"
+	"github.com/kasworld/goguelike/game/diplomacy"
 	"github.com/kasworld/goguelike/game/fieldobject"
 	"github.com/kasworld/goguelike/game/gamei"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
@@ -55,6 +62,12 @@ func (tw *Tower) processCmd2Tower(data interface{}) {
 
 	case *cmd2tower.ActiveObjRebirth:
 		tw.Call_ActiveObjRebirth(pk.ActiveObj)
+
+	case *cmd2tower.BossKilled:
+		tw.Call_BossKilled(pk.Boss, pk.Floor, pk.KillerName)
+
+	case *cmd2tower.SummonPet:
+		tw.Call_SummonPet(pk.Owner, pk.Floor, pk.X, pk.Y)
 	}
 }
 
@@ -142,6 +155,7 @@ func (tw *Tower) Call_ActiveObjSuspendFromTower(ao gamei.ActiveObjectI) error {
 	if err := tw.id2aoSuspend.Add(ao); err != nil {
 		tw.log.Fatal("%v", err)
 	}
+	tw.saveAOPersistent(ao)
 	return nil
 }
 
@@ -152,6 +166,11 @@ func (tw *Tower) Call_ActiveObjLeaveTower(ActiveObjUUID string) error {
 		return nil
 	}
 	tw.ao2Floor.ActiveObjLeaveFloor(ao)
+	tw.removeSummonedPet(ao)
+	ao.ReleaseAllPet()
+	if ao.GetActiveObjType() == aotype.User {
+		tw.saveAOPersistent(ao)
+	}
 	return nil
 }
 
@@ -172,7 +191,7 @@ func (tw *Tower) Call_AdminFloorMove(
 		if err != nil {
 			tw.log.Error("fail to find rand pos %v %v %v", dstFloor, ActiveObj, err)
 		}
-		if err := tw.ao2Floor.ActiveObjMoveToFloor(dstFloor, ActiveObj, x, y); err != nil {
+		if err := tw.aoMoveToFloorWithPet(dstFloor, ActiveObj, x, y); err != nil {
 			tw.log.Fatal("%v", err)
 			return c2t_error.ActionProhibited
 		}
@@ -190,7 +209,7 @@ func (tw *Tower) Call_AdminFloorMove(
 		if err != nil {
 			tw.log.Error("fail to find rand pos %v %v %v", dstFloor, ActiveObj, err)
 		}
-		if err := tw.ao2Floor.ActiveObjMoveToFloor(dstFloor, ActiveObj, x, y); err != nil {
+		if err := tw.aoMoveToFloorWithPet(dstFloor, ActiveObj, x, y); err != nil {
 			tw.log.Fatal("%v", err)
 			return c2t_error.ActionProhibited
 		}
@@ -206,7 +225,7 @@ func (tw *Tower) Call_AdminFloorMove(
 		if err != nil {
 			tw.log.Error("fail to find rand pos %v %v %v", dstFloor, ActiveObj, err)
 		}
-		if err := tw.ao2Floor.ActiveObjMoveToFloor(dstFloor, ActiveObj, x, y); err != nil {
+		if err := tw.aoMoveToFloorWithPet(dstFloor, ActiveObj, x, y); err != nil {
 			tw.log.Fatal("%v", err)
 			return c2t_error.ActionProhibited
 		}
@@ -227,7 +246,7 @@ func (tw *Tower) Call_FloorMove(
 	if err != nil {
 		tw.log.Error("fail to find rand pos %v %v %v", dstFloor, ActiveObj, err)
 	}
-	if err := tw.ao2Floor.ActiveObjMoveToFloor(dstFloor, ActiveObj, x, y); err != nil {
+	if err := tw.aoMoveToFloorWithPet(dstFloor, ActiveObj, x, y); err != nil {
 		tw.log.Fatal("%v", err)
 		return c2t_error.ActionProhibited
 	}
@@ -237,6 +256,13 @@ func (tw *Tower) Call_FloorMove(
 func (tw *Tower) Call_AdminTowerCmd(ActiveObj gamei.ActiveObjectI,
 	RecvPacket *c2t_obj.ReqAdminTowerCmd_data) c2t_error.ErrorCode {
 	tw.log.Debug("%v %v", ActiveObj, RecvPacket)
+	if diplomacy.IsCmdline(RecvPacket.Cmd) {
+		cmdline := RecvPacket.Cmd + " " + RecvPacket.Arg
+		if err := tw.diplomacy.ExecCmdline(cmdline); err != nil {
+			tw.log.Warn("%v %v", ActiveObj, err)
+			return c2t_error.ActionProhibited
+		}
+	}
 	return c2t_error.None
 }
 
@@ -267,7 +293,7 @@ func (tw *Tower) Call_ActiveObjUsePortal(
 		return
 	}
 
-	if err := tw.ao2Floor.ActiveObjMoveToFloor(dstFloor, ActiveObj, x, y); err != nil {
+	if err := tw.aoMoveToFloorWithPet(dstFloor, ActiveObj, x, y); err != nil {
 		tw.log.Fatal("%v", err)
 	}
 }
@@ -287,7 +313,7 @@ func (tw *Tower) Call_ActiveObjTrapTeleport(
 	if err != nil {
 		tw.log.Error("fail to find rand pos %v %v %v", dstFloor, ActiveObj, err)
 	}
-	if err := tw.ao2Floor.ActiveObjMoveToFloor(dstFloor, ActiveObj, x, y); err != nil {
+	if err := tw.aoMoveToFloorWithPet(dstFloor, ActiveObj, x, y); err != nil {
 		tw.log.Fatal("%v", err)
 	}
 }
@@ -299,7 +325,10 @@ func (tw *Tower) Call_ActiveObjRebirth(ao gamei.ActiveObjectI) {
 	}
 	var dstFloor gamei.FloorI
 	// system ao respawn to home floor == not user ao
-	if aoconn := ao.GetClientConn(); aoconn == nil {
+	// pet respawn to floor of owner
+	if owner := ao.GetPetOwner(); owner != nil && owner.GetCurrentFloor() != nil {
+		dstFloor = owner.GetCurrentFloor()
+	} else if aoconn := ao.GetClientConn(); aoconn == nil {
 		dstFloor = ao.GetHomeFloor()
 	} else {
 		dstFloor = ao.GetCurrentFloor()
@@ -310,3 +339,98 @@ func (tw *Tower) Call_ActiveObjRebirth(ao gamei.ActiveObjectI) {
 	}
 	tw.log.Debug("ActiveObjRebirth %v to %v", ao, dstFloor)
 }
+
+// Call_BossKilled announce to all ao in tower
+func (tw *Tower) Call_BossKilled(boss gamei.ActiveObjectI, f gamei.FloorI, killerName string) {
+	tw.towerAchieveStat.Inc(towerachieve.BossKill)
+	tw.log.Monitor("BossKilled %v in %v by %v", boss, f, killerName)
+	noti := &c2t_obj.NotiBossKilled_data{
+		BossName:   boss.GetNickName(),
+		FloorName:  f.GetName(),
+		KillerName: killerName,
+	}
+	for _, dstAO := range tw.id2ao.GetAllList() {
+		tw.sendChatNoti(dstAO, c2t_idnoti.BossKilled, noti)
+	}
+}
+
+// aoMoveToFloorWithPet Follow order pet in same floor move to near ao
+func (tw *Tower) aoMoveToFloorWithPet(
+	dstFloor gamei.FloorI, ao gamei.ActiveObjectI, x, y int) error {
+
+	srcFloor := tw.ao2Floor.GetFloorByActiveObjID(ao.GetUUID())
+	if err := tw.ao2Floor.ActiveObjMoveToFloor(dstFloor, ao, x, y); err != nil {
+		return err
+	}
+	if srcFloor == nil || srcFloor == dstFloor {
+		return nil
+	}
+	for _, pet := range ao.GetPetList() {
+		if order, _ := pet.GetPetOrder(); order != petorder.Follow || !pet.IsAlive() {
+			continue
+		}
+		if tw.ao2Floor.GetFloorByActiveObjID(pet.GetUUID()) != srcFloor {
+			continue
+		}
+		if err := tw.ao2Floor.ActiveObjMoveToFloorNear(dstFloor, pet, x, y); err != nil {
+			tw.log.Error("%v %v", pet, err)
+		}
+	}
+	return nil
+}
+
+// removeSummonedPet summoned pet not left in tower without owner
+func (tw *Tower) removeSummonedPet(owner gamei.ActiveObjectI) {
+	for _, pet := range owner.GetPetList() {
+		if !pet.IsSummoned() {
+			continue
+		}
+		if err := owner.RemovePet(pet); err != nil {
+			tw.log.Error("%v", err)
+		}
+		if _, err := tw.id2ao.DelByUUID(pet.GetUUID()); err != nil {
+			tw.log.Error("%v", err)
+			continue
+		}
+		tw.ao2Floor.ActiveObjLeaveFloor(pet)
+	}
+}
+
+// Call_SummonPet make system ao bound to owner
+// scroll read in floor is given back if fail
+func (tw *Tower) Call_SummonPet(owner gamei.ActiveObjectI, f gamei.FloorI, x, y int) {
+	if _, exist := tw.id2ao.GetByUUID(owner.GetUUID()); !exist {
+		tw.log.Warn("owner left tower before summon %v", owner)
+		return
+	}
+	pet := activeobject.NewSummonedActiveObj(tw.rnd.Int63(), f, tw.log, tw.towerAchieveStat)
+	if err := owner.AddPet(pet); err != nil {
+		tw.log.Warn("fail to summon pet %v", err)
+		tw.refundSummonPetScroll(owner)
+		return
+	}
+	if err := tw.ao2Floor.ActiveObjMoveToFloorNear(f, pet, x, y); err != nil {
+		tw.log.Error("%v", err)
+		if err := owner.RemovePet(pet); err != nil {
+			tw.log.Error("%v", err)
+		}
+		tw.refundSummonPetScroll(owner)
+		return
+	}
+	if err := tw.id2ao.Add(pet); err != nil {
+		tw.log.Error("%v", err)
+		tw.ao2Floor.ActiveObjLeaveFloor(pet)
+		if err := owner.RemovePet(pet); err != nil {
+			tw.log.Error("%v", err)
+		}
+		tw.refundSummonPetScroll(owner)
+		return
+	}
+	tw.log.Debug("SummonPet %v of %v in %v", pet, owner, f)
+}
+
+func (tw *Tower) refundSummonPetScroll(owner gamei.ActiveObjectI) {
+	if err := owner.GetInven().AddToBag(carryingobject.NewScroll(scrolltype.SummonPet)); err != nil {
+		tw.log.Error("fail to refund SummonPet scroll %v %v", owner, err)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tower
+
+import (
+	"testing"
+
+	"github.com/kasworld/goguelike/enum/petorder"
+	"github.com/kasworld/goguelike/game/activeobject"
+	"github.com/kasworld/goguelike/game/aoid2floor"
+	"github.com/kasworld/goguelike/game/cmd2floor"
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/lib/g2log"
+)
+
+// testFloor keep req to floor
+type testFloor struct {
+	gamei.FloorI
+	name  string
+	reqCh chan interface{}
+}
+
+func newTestFloor(name string) *testFloor {
+	return &testFloor{name: name, reqCh: make(chan interface{}, 100)}
+}
+
+func (f *testFloor) GetName() string {
+	return f.name
+}
+
+func (f *testFloor) GetReqCh() chan<- interface{} {
+	return f.reqCh
+}
+
+// enteredList ao uuid of ReqEnterFloor sent to floor
+func (f *testFloor) enteredList() []string {
+	var rtn []string
+	for {
+		select {
+		case v := <-f.reqCh:
+			if rq, ok := v.(*cmd2floor.ReqEnterFloor); ok {
+				rtn = append(rtn, rq.ActiveObj.GetUUID())
+			}
+		default:
+			return rtn
+		}
+	}
+}
+
+func TestAOMoveToFloorWithPet(t *testing.T) {
+	tw := &Tower{log: g2log.GlobalLogger}
+	tw.ao2Floor = aoid2floor.New(tw)
+	srcFloor := newTestFloor("src")
+	dstFloor := newTestFloor("dst")
+	otherFloor := newTestFloor("other")
+
+	owner := activeobject.NewReplayActiveObj("owner", "owner", srcFloor, tw.log)
+	follow := activeobject.NewReplayActiveObj("follow", "follow", srcFloor, tw.log)
+	stay := activeobject.NewReplayActiveObj("stay", "stay", srcFloor, tw.log)
+	away := activeobject.NewReplayActiveObj("away", "away", otherFloor, tw.log)
+	for _, v := range []struct {
+		ao *activeobject.ActiveObject
+		f  gamei.FloorI
+	}{
+		{owner, srcFloor}, {follow, srcFloor}, {stay, srcFloor}, {away, otherFloor},
+	} {
+		if err := tw.ao2Floor.ActiveObjMoveToFloor(v.f, v.ao, 1, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, v := range []*activeobject.ActiveObject{follow, stay, away} {
+		if err := owner.AddPet(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stay.SetPetOrder(petorder.Stay, "")
+	srcFloor.enteredList()
+	otherFloor.enteredList()
+
+	// in same floor pet not moved
+	if err := tw.aoMoveToFloorWithPet(srcFloor, owner, 2, 2); err != nil {
+		t.Fatal(err)
+	}
+	if got := srcFloor.enteredList(); len(got) != 1 || got[0] != "owner" {
+		t.Errorf("entered in same floor %v", got)
+	}
+
+	if err := tw.aoMoveToFloorWithPet(dstFloor, owner, 3, 3); err != nil {
+		t.Fatal(err)
+	}
+	if got := dstFloor.enteredList(); len(got) != 2 || got[0] != "owner" || got[1] != "follow" {
+		t.Errorf("entered to dst %v", got)
+	}
+	for _, v := range []struct {
+		ao *activeobject.ActiveObject
+		f  gamei.FloorI
+	}{
+		{owner, dstFloor}, {follow, dstFloor}, {stay, srcFloor}, {away, otherFloor},
+	} {
+		if got := tw.ao2Floor.GetFloorByActiveObjID(v.ao.GetUUID()); got != v.f {
+			t.Errorf("%v in %v, want %v", v.ao.GetUUID(), got, v.f)
+		}
+	}
+}
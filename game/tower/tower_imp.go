@@ -15,9 +15,14 @@ import (
 	"time"
 
 	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/config/questdata"
 	"github.com/kasworld/goguelike/config/towerconfig"
 	"github.com/kasworld/goguelike/game/bias"
+	"github.com/kasworld/goguelike/game/diplomacy"
 	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/game/identify"
+	"github.com/kasworld/goguelike/game/spectatorman"
+	"github.com/kasworld/goguelike/game/turnrecorder"
 	"github.com/kasworld/goguelike/lib/g2log"
 )
 
@@ -27,12 +32,56 @@ func (tw *Tower) GetFloorManager() gamei.FloorManagerI {
 	return tw.floorMan
 }
 
+// GetExpRanking ranking list is replaced not modified, safe to read after return
 func (tw *Tower) GetExpRanking() []gamei.ActiveObjectI {
+	tw.mutex.RLock()
+	defer tw.mutex.RUnlock()
 	return tw.aoExpRanking
 }
 
+func (tw *Tower) getExpRankingSuspended() []gamei.ActiveObjectI {
+	tw.mutex.RLock()
+	defer tw.mutex.RUnlock()
+	return tw.aoExpRankingSuspended
+}
+
+func (tw *Tower) GetSpectatorManager() *spectatorman.SpectatorManager {
+	return tw.spectatorMan
+}
+
 // attribute get/set
 
+func (tw *Tower) GetAppearance() *identify.Appearance {
+	return tw.appearance
+}
+
+func (tw *Tower) GetQuestList() []*questdata.Quest {
+	return tw.questList
+}
+
+func (tw *Tower) GetDiplomacy() *diplomacy.Diplomacy {
+	return tw.diplomacy
+}
+
+// selectQuestByFloor skip quest refer floor not in tower
+func (tw *Tower) selectQuestByFloor(questList []*questdata.Quest) []*questdata.Quest {
+	var rtn []*questdata.Quest
+	for _, q := range questList {
+		valid := true
+		for _, name := range q.FloorNameList() {
+			if tw.floorMan.GetFloorByName(name) == nil {
+				tw.log.Warn("skip quest %v, floor not found %v", q.Name, name)
+				valid = false
+				break
+			}
+		}
+		if valid {
+			rtn = append(rtn, q)
+		}
+	}
+	return rtn
+}
+
 func (tw *Tower) GetReqCh() chan<- interface{} {
 	return tw.recvRequestCh
 }
@@ -42,6 +91,9 @@ func (tw *Tower) GetUUID() string {
 }
 
 func (tw *Tower) GetRunDur() time.Duration {
+	if !tw.replayTurnTime.IsZero() {
+		return tw.replayTurnTime.Sub(tw.startTime)
+	}
 	return time.Now().Sub(tw.startTime)
 }
 
@@ -50,6 +102,10 @@ func (tw *Tower) GetBias() bias.Bias {
 	return rtn
 }
 
+func (tw *Tower) GetTurnRecorder() *turnrecorder.Recorder {
+	return tw.turnRecorder
+}
+
 func (tw *Tower) Config() *towerconfig.TowerConfig {
 	return tw.sconfig
 }
@@ -0,0 +1,96 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tower
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/kasworld/goguelike/config/gameconst"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_gob"
+)
+
+// listenTCPClient serve client by raw tcp, same packet as websocket
+// for bot client, less cpu than websocket framing
+func (tw *Tower) listenTCPClient(ctx context.Context) {
+	tw.log.TraceService("Start listenTCPClient %v", tw)
+	defer func() { tw.log.TraceService("End listenTCPClient %v", tw) }()
+
+	tcpaddr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf(":%v", tw.sconfig.ServiceTCPPort))
+	if err != nil {
+		tw.log.Error("%v", err)
+		return
+	}
+	listener, err := net.ListenTCP("tcp", tcpaddr)
+	if err != nil {
+		tw.log.Error("%v", err)
+		return
+	}
+	defer listener.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			listener.SetDeadline(time.Now().Add(time.Duration(1 * time.Second)))
+			conn, err := listener.AcceptTCP()
+			if err != nil {
+				operr, ok := err.(*net.OpError)
+				if ok && operr.Timeout() {
+					continue
+				}
+				tw.log.Error("%v", err)
+			} else {
+				go tw.serveTCPClient(ctx, conn)
+			}
+		}
+	}
+}
+
+func (tw *Tower) serveTCPClient(ctx context.Context, conn *net.TCPConn) {
+	if tw.IsListenClientPaused() {
+		tw.log.Warn("ListenClientPaused %v", conn.RemoteAddr())
+		conn.Close()
+		return
+	}
+
+	if !tw.clientConnLimitStat.Inc() {
+		tw.log.Fatal(
+			"Over limit connect made, cancel %v, continue %v",
+			conn.RemoteAddr(),
+			tw.clientConnLimitStat)
+	}
+
+	tw.log.TraceClient("Start serveTCPClient %v", conn.RemoteAddr())
+	defer func() {
+		tw.log.TraceClient("End serveTCPClient %v", conn.RemoteAddr())
+	}()
+	// recover like net/http does for web client, keep tower running
+	defer func() {
+		if r := recover(); r != nil {
+			tw.log.Error("panic serveTCPClient %v %v", conn.RemoteAddr(), r)
+			conn.Close()
+		}
+	}()
+
+	connData, c2sc := tw.newClientConn(conn.RemoteAddr().String())
+
+	c2sc.StartServeTCP(ctx, conn,
+		gameconst.ServerPacketReadTimeOutSec*time.Second,
+		gameconst.ServerPacketWriteTimeoutSec*time.Second,
+		c2t_gob.MarshalBodyFn,
+	)
+
+	tw.endClientConn(connData, conn)
+}
@@ -14,6 +14,7 @@ package tower
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/kasworld/goguelike/game/aoscore"
 	"github.com/kasworld/goguelike/game/cmd2tower"
 	"github.com/kasworld/goguelike/game/towerlist4client"
+	"github.com/kasworld/goguelike/lib/cmdratelimit"
 	"github.com/kasworld/goguelike/lib/conndata"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_gob"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
@@ -63,6 +65,14 @@ func (tw *Tower) initServiceWeb(ctx context.Context) {
 		c2t_idcmd.Heartbeat:         tw.bytesAPIFn_ReqHeartbeat,         // Heartbeat
 		c2t_idcmd.Chat:              tw.bytesAPIFn_ReqChat,              // Chat
 		c2t_idcmd.AchieveInfo:       tw.bytesAPIFn_ReqAchieveInfo,       // AchieveInfo
+		c2t_idcmd.AckObjectList:     tw.bytesAPIFn_ReqAckObjectList,     // AckObjectList client has ObjectList of seq, base of next delta
+		c2t_idcmd.Spectate:          tw.bytesAPIFn_ReqSpectate,          // Spectate follow ao without control, spectator login only
+		c2t_idcmd.TradePropose:      tw.bytesAPIFn_ReqTradePropose,      // TradePropose start trade with near ao
+		c2t_idcmd.TradeAmend:        tw.bytesAPIFn_ReqTradeAmend,        // TradeAmend change my offer
+		c2t_idcmd.TradeAccept:       tw.bytesAPIFn_ReqTradeAccept,       // TradeAccept exchange when both accepted
+		c2t_idcmd.TradeCancel:       tw.bytesAPIFn_ReqTradeCancel,       // TradeCancel
+		c2t_idcmd.ListShop:          tw.bytesAPIFn_ReqListShop,          // ListShop carryobj for sale at Shop
+		c2t_idcmd.ListQuest:         tw.bytesAPIFn_ReqListQuest,         // ListQuest quest offered at giver and accepted
 		c2t_idcmd.Rebirth:           tw.bytesAPIFn_ReqRebirth,           // Rebirth
 		c2t_idcmd.MoveFloor:         tw.bytesAPIFn_ReqMoveFloor,         // MoveFloor tower cmd
 		c2t_idcmd.AIPlay:            tw.bytesAPIFn_ReqAIPlay,            // AIPlay
@@ -72,6 +82,8 @@ func (tw *Tower) initServiceWeb(ctx context.Context) {
 		c2t_idcmd.Attack:            tw.bytesAPIFn_ReqAttack,            // Attack turn act
 		c2t_idcmd.AttackWide:        tw.bytesAPIFn_ReqAttackWide,        // Attack turn act
 		c2t_idcmd.AttackLong:        tw.bytesAPIFn_ReqAttackLong,        // Attack turn act
+		c2t_idcmd.Shoot:             tw.bytesAPIFn_ReqShoot,             // Shoot turn act
+		c2t_idcmd.Cast:              tw.bytesAPIFn_ReqCast,              // Cast turn act
 		c2t_idcmd.Pickup:            tw.bytesAPIFn_ReqPickup,            // Pickup turn act
 		c2t_idcmd.Drop:              tw.bytesAPIFn_ReqDrop,              // Drop turn act
 		c2t_idcmd.Equip:             tw.bytesAPIFn_ReqEquip,             // Equip turn act
@@ -79,8 +91,16 @@ func (tw *Tower) initServiceWeb(ctx context.Context) {
 		c2t_idcmd.DrinkPotion:       tw.bytesAPIFn_ReqDrinkPotion,       // DrinkPotion turn act
 		c2t_idcmd.ReadScroll:        tw.bytesAPIFn_ReqReadScroll,        // ReadScroll turn act
 		c2t_idcmd.Recycle:           tw.bytesAPIFn_ReqRecycle,           // Recycle turn act
+		c2t_idcmd.Craft:             tw.bytesAPIFn_ReqCraft,             // Craft turn act
+		c2t_idcmd.Buy:               tw.bytesAPIFn_ReqBuy,               // Buy turn act
+		c2t_idcmd.Repair:            tw.bytesAPIFn_ReqRepair,            // Repair turn act
+		c2t_idcmd.AcceptQuest:       tw.bytesAPIFn_ReqAcceptQuest,       // AcceptQuest turn act
+		c2t_idcmd.CompleteQuest:     tw.bytesAPIFn_ReqCompleteQuest,     // CompleteQuest turn act
 		c2t_idcmd.EnterPortal:       tw.bytesAPIFn_ReqEnterPortal,       // EnterPortal turn act
 		c2t_idcmd.ActTeleport:       tw.bytesAPIFn_ReqActTeleport,       // ActTeleport turn act
+		c2t_idcmd.OpenDoor:          tw.bytesAPIFn_ReqOpenDoor,          // OpenDoor turn act
+		c2t_idcmd.CloseDoor:         tw.bytesAPIFn_ReqCloseDoor,         // CloseDoor turn act
+		c2t_idcmd.CommandPet:        tw.bytesAPIFn_ReqCommandPet,        // CommandPet turn act
 		c2t_idcmd.AdminTowerCmd:     tw.bytesAPIFn_ReqAdminTowerCmd,     // AdminTowerCmd generic cmd
 		c2t_idcmd.AdminFloorCmd:     tw.bytesAPIFn_ReqAdminFloorCmd,     // AdminFloorCmd generic cmd
 		c2t_idcmd.AdminActiveObjCmd: tw.bytesAPIFn_ReqAdminActiveObjCmd, // AdminActiveObjCmd generic cmd
@@ -97,6 +117,9 @@ func (tw *Tower) initServiceWeb(ctx context.Context) {
 		c2t_idcmd.AdminForgetFloor:  tw.bytesAPIFn_ReqAdminForgetFloor,  // AdminForgetFloor forget current floor map
 		c2t_idcmd.AdminFloorMap:     tw.bytesAPIFn_ReqAdminFloorMap,     // AdminFloorMap complete current floor map
 	}
+	for i, fn := range tw.demuxReq2BytesAPIFnMap {
+		tw.demuxReq2BytesAPIFnMap[i] = tw.rateLimitAPIFn(c2t_idcmd.CommandID(i), fn)
+	}
 }
 
 func CheckOrigin(r *http.Request) bool {
@@ -133,9 +156,30 @@ func (tw *Tower) serveWebSocketClient(ctx context.Context,
 		tw.log.TraceClient("End serveWebSocketClient %v", r.RemoteAddr)
 	}()
 
+	connData, c2sc := tw.newClientConn(r.RemoteAddr)
+
+	c2sc.StartServeWS(ctx, wsConn,
+		gameconst.ServerPacketReadTimeOutSec*time.Second,
+		gameconst.ServerPacketWriteTimeoutSec*time.Second,
+		c2t_gob.MarshalBodyFn,
+	)
+
+	// connected user play
+
+	// end play
+
+	tw.endClientConn(connData, wsConn)
+}
+
+// newClientConn make client connection and add to conn manager
+// used by websocket and tcp
+func (tw *Tower) newClientConn(remoteAddr string) (
+	*conndata.ConnData, *c2t_serveconnbyte.ServeConnByte) {
+
 	connData := &conndata.ConnData{
 		UUID:       uuidstr.New(),
-		RemoteAddr: r.RemoteAddr,
+		RemoteAddr: remoteAddr,
+		CmdLimiter: cmdratelimit.New(tw.cmdBudgetList),
 	}
 	c2sc := c2t_serveconnbyte.NewWithStats(
 		connData,
@@ -149,33 +193,35 @@ func (tw *Tower) serveWebSocketClient(ctx context.Context,
 	)
 	// add to conn manager
 	tw.connManager.Add(connData.UUID, c2sc)
+	return connData, c2sc
+}
 
-	c2sc.StartServeWS(ctx, wsConn,
-		gameconst.ServerPacketReadTimeOutSec*time.Second,
-		gameconst.ServerPacketWriteTimeoutSec*time.Second,
-		c2t_gob.MarshalBodyFn,
-	)
-
-	// connected user play
-
-	// end play
-
+// endClientConn suspend ao of ended connection and close it
+func (tw *Tower) endClientConn(connData *conndata.ConnData, conn io.Closer) {
 	// connData changed in user play
-	ao, exist := tw.id2ao.GetByUUID(connData.Session.ActiveObjUUID)
-	if !exist {
-		panic(fmt.Sprintf("ao not found %v", connData))
-	}
-	if ao != nil && ao.GetActiveObjType() == aotype.User {
-		go tw.Ground_HighScore(ao)
-		ao.Suspend()
-		rspCh := make(chan error, 1)
-		tw.GetReqCh() <- &cmd2tower.ActiveObjSuspendFromTower{
-			ActiveObj: ao,
-			RspCh:     rspCh,
+	switch {
+	case connData.Spectator:
+		tw.spectatorMan.Del(connData.UUID)
+	case connData.Session == nil:
+		// closed before login, no ao to suspend
+	default:
+		ao, exist := tw.id2ao.GetByUUID(connData.Session.ActiveObjUUID)
+		if !exist {
+			tw.log.Warn("ao not found %v", connData)
+			break
+		}
+		if ao.GetActiveObjType() == aotype.User {
+			go tw.Ground_HighScore(ao)
+			ao.Suspend()
+			rspCh := make(chan error, 1)
+			tw.GetReqCh() <- &cmd2tower.ActiveObjSuspendFromTower{
+				ActiveObj: ao,
+				RspCh:     rspCh,
+			}
+			<-rspCh
 		}
-		<-rspCh
 	}
-	wsConn.Close()
+	conn.Close()
 
 	if !tw.clientConnLimitStat.Dec() {
 		tw.log.Fatal("Under limit connection delete, continue %v",
@@ -205,7 +251,7 @@ func (tw *Tower) json_TowerList(w http.ResponseWriter, r *http.Request) {
 }
 
 func (tw *Tower) json_HighScore(w http.ResponseWriter, r *http.Request) {
-	allActiveObj := aoexpsort.ByExp(tw.aoExpRanking)
+	allActiveObj := aoexpsort.ByExp(tw.GetExpRanking())
 	aoLen := len(allActiveObj)
 	if aoLen >= groundconst.HighScoreLen {
 		aoLen = groundconst.HighScoreLen
@@ -0,0 +1,122 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tower
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kasworld/goguelike/lib/conndata"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_packet"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_serveconnbyte"
+)
+
+// rateLimitAPIFn wrap api fn with token bucket of connection
+// over budget request is not run, return TooFrequent and counted in errorStat
+// connection over CmdRateKickCount in a minute is kicked
+func (tw *Tower) rateLimitAPIFn(
+	cmd c2t_idcmd.CommandID,
+	fn func(me interface{}, hd c2t_packet.Header, rbody []byte) (
+		c2t_packet.Header, interface{}, error),
+) func(me interface{}, hd c2t_packet.Header, rbody []byte) (
+	c2t_packet.Header, interface{}, error) {
+
+	return func(me interface{}, hd c2t_packet.Header, rbody []byte) (
+		c2t_packet.Header, interface{}, error) {
+
+		c2sc, ok := me.(*c2t_serveconnbyte.ServeConnByte)
+		if !ok {
+			panic(fmt.Sprintf("invalid me not c2t_serveconnbyte.ServeConnByte %#v", me))
+		}
+		connData := c2sc.GetConnData().(*conndata.ConnData)
+		wait, violationCount := connData.CmdLimiter.Take(int(cmd), time.Now())
+		if wait > 0 {
+			tw.errorStat.Inc(cmd, c2t_error.TooFrequent)
+			if kickCount := tw.sconfig.CmdRateKickCount; kickCount > 0 && violationCount >= kickCount {
+				tw.log.Warn("kick over rate limit %v %v %v", connData.RemoteAddr, cmd, violationCount)
+				if conn := tw.connManager.Get(connData.UUID); conn != nil {
+					conn.Disconnect()
+				}
+				return hd, nil, fmt.Errorf("over rate limit %v %v", cmd, violationCount)
+			}
+			// keep FlowType, PacketID of request for client to match rsp
+			rhd := hd
+			rhd.ErrorCode = c2t_error.TooFrequent
+			return rhd, emptyRspObjMap[cmd](), nil
+		}
+		return fn(me, hd, rbody)
+	}
+}
+
+// emptyRspObjMap make typed empty rsp body of cmd
+// client decode rsp body by cmd, same type as api fn return
+var emptyRspObjMap = [c2t_idcmd.CommandID_Count]func() interface{}{
+	c2t_idcmd.Invalid:           func() interface{} { return &c2t_obj.RspInvalid_data{} },
+	c2t_idcmd.Login:             func() interface{} { return &c2t_obj.RspLogin_data{} },
+	c2t_idcmd.Heartbeat:         func() interface{} { return &c2t_obj.RspHeartbeat_data{} },
+	c2t_idcmd.Chat:              func() interface{} { return &c2t_obj.RspChat_data{} },
+	c2t_idcmd.AchieveInfo:       func() interface{} { return &c2t_obj.RspAchieveInfo_data{} },
+	c2t_idcmd.AckObjectList:     func() interface{} { return &c2t_obj.RspAckObjectList_data{} },
+	c2t_idcmd.Spectate:          func() interface{} { return &c2t_obj.RspSpectate_data{} },
+	c2t_idcmd.TradePropose:      func() interface{} { return &c2t_obj.RspTradePropose_data{} },
+	c2t_idcmd.TradeAmend:        func() interface{} { return &c2t_obj.RspTradeAmend_data{} },
+	c2t_idcmd.TradeAccept:       func() interface{} { return &c2t_obj.RspTradeAccept_data{} },
+	c2t_idcmd.TradeCancel:       func() interface{} { return &c2t_obj.RspTradeCancel_data{} },
+	c2t_idcmd.ListShop:          func() interface{} { return &c2t_obj.RspListShop_data{} },
+	c2t_idcmd.ListQuest:         func() interface{} { return &c2t_obj.RspListQuest_data{} },
+	c2t_idcmd.Rebirth:           func() interface{} { return &c2t_obj.RspRebirth_data{} },
+	c2t_idcmd.MoveFloor:         func() interface{} { return &c2t_obj.RspMoveFloor_data{} },
+	c2t_idcmd.AIPlay:            func() interface{} { return &c2t_obj.RspAIPlay_data{} },
+	c2t_idcmd.Meditate:          func() interface{} { return &c2t_obj.RspMeditate_data{} },
+	c2t_idcmd.KillSelf:          func() interface{} { return &c2t_obj.RspKillSelf_data{} },
+	c2t_idcmd.Move:              func() interface{} { return &c2t_obj.RspMove_data{} },
+	c2t_idcmd.Attack:            func() interface{} { return &c2t_obj.RspAttack_data{} },
+	c2t_idcmd.AttackWide:        func() interface{} { return &c2t_obj.RspAttackWide_data{} },
+	c2t_idcmd.AttackLong:        func() interface{} { return &c2t_obj.RspAttackLong_data{} },
+	c2t_idcmd.Shoot:             func() interface{} { return &c2t_obj.RspShoot_data{} },
+	c2t_idcmd.Cast:              func() interface{} { return &c2t_obj.RspCast_data{} },
+	c2t_idcmd.Pickup:            func() interface{} { return &c2t_obj.RspPickup_data{} },
+	c2t_idcmd.Drop:              func() interface{} { return &c2t_obj.RspDrop_data{} },
+	c2t_idcmd.Equip:             func() interface{} { return &c2t_obj.RspEquip_data{} },
+	c2t_idcmd.UnEquip:           func() interface{} { return &c2t_obj.RspUnEquip_data{} },
+	c2t_idcmd.DrinkPotion:       func() interface{} { return &c2t_obj.RspDrinkPotion_data{} },
+	c2t_idcmd.ReadScroll:        func() interface{} { return &c2t_obj.RspReadScroll_data{} },
+	c2t_idcmd.Recycle:           func() interface{} { return &c2t_obj.RspRecycle_data{} },
+	c2t_idcmd.Craft:             func() interface{} { return &c2t_obj.RspCraft_data{} },
+	c2t_idcmd.Buy:               func() interface{} { return &c2t_obj.RspBuy_data{} },
+	c2t_idcmd.Repair:            func() interface{} { return &c2t_obj.RspRepair_data{} },
+	c2t_idcmd.AcceptQuest:       func() interface{} { return &c2t_obj.RspAcceptQuest_data{} },
+	c2t_idcmd.CompleteQuest:     func() interface{} { return &c2t_obj.RspCompleteQuest_data{} },
+	c2t_idcmd.EnterPortal:       func() interface{} { return &c2t_obj.RspEnterPortal_data{} },
+	c2t_idcmd.ActTeleport:       func() interface{} { return &c2t_obj.RspActTeleport_data{} },
+	c2t_idcmd.OpenDoor:          func() interface{} { return &c2t_obj.RspOpenDoor_data{} },
+	c2t_idcmd.CloseDoor:         func() interface{} { return &c2t_obj.RspCloseDoor_data{} },
+	c2t_idcmd.CommandPet:        func() interface{} { return &c2t_obj.RspCommandPet_data{} },
+	c2t_idcmd.AdminTowerCmd:     func() interface{} { return &c2t_obj.RspAdminTowerCmd_data{} },
+	c2t_idcmd.AdminFloorCmd:     func() interface{} { return &c2t_obj.RspAdminFloorCmd_data{} },
+	c2t_idcmd.AdminActiveObjCmd: func() interface{} { return &c2t_obj.RspAdminActiveObjCmd_data{} },
+	c2t_idcmd.AdminFloorMove:    func() interface{} { return &c2t_obj.RspAdminFloorMove_data{} },
+	c2t_idcmd.AdminTeleport:     func() interface{} { return &c2t_obj.RspAdminTeleport_data{} },
+	c2t_idcmd.AdminAddExp:       func() interface{} { return &c2t_obj.RspAdminAddExp_data{} },
+	c2t_idcmd.AdminPotionEffect: func() interface{} { return &c2t_obj.RspAdminPotionEffect_data{} },
+	c2t_idcmd.AdminScrollEffect: func() interface{} { return &c2t_obj.RspAdminScrollEffect_data{} },
+	c2t_idcmd.AdminCondition:    func() interface{} { return &c2t_obj.RspAdminCondition_data{} },
+	c2t_idcmd.AdminAddPotion:    func() interface{} { return &c2t_obj.RspAdminAddPotion_data{} },
+	c2t_idcmd.AdminAddScroll:    func() interface{} { return &c2t_obj.RspAdminAddScroll_data{} },
+	c2t_idcmd.AdminAddMoney:     func() interface{} { return &c2t_obj.RspAdminAddMoney_data{} },
+	c2t_idcmd.AdminAddEquip:     func() interface{} { return &c2t_obj.RspAdminAddEquip_data{} },
+	c2t_idcmd.AdminForgetFloor:  func() interface{} { return &c2t_obj.RspAdminForgetFloor_data{} },
+	c2t_idcmd.AdminFloorMap:     func() interface{} { return &c2t_obj.RspAdminFloorMap_data{} },
+}
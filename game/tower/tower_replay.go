@@ -0,0 +1,96 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tower
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kasworld/goguelike/game/aoid2floor"
+	"github.com/kasworld/goguelike/game/floormanager"
+	"github.com/kasworld/goguelike/game/identify"
+	"github.com/kasworld/goguelike/game/turnrecorder"
+)
+
+// ReplayTurnRecord make floors from record header and replay turn one by one
+// call fn with not matched result of each turn, fn return false to stop
+// use instead of ServiceInit/ServiceMain
+func (tw *Tower) ReplayTurnRecord(filename string,
+	fn func(tr *turnrecorder.TurnRecord, mismatch []string) bool) error {
+
+	rd, err := turnrecorder.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+	tw.log.TraceService("replay %v %v", filename, rd.Header)
+	if rd.Header.Snapshot == nil {
+		return fmt.Errorf("no floor snapshot in record %v", rd.Header)
+	}
+
+	if err := tw.loadGameData(); err != nil {
+		return err
+	}
+	tw.ao2Floor = aoid2floor.New(tw)
+	tw.biasFactor = rd.Header.BiasFactor
+	tw.startTime = rd.Header.StartTime
+	tw.appearanceSeed = rd.Header.Snapshot.AppearanceSeed
+	tw.appearance = identify.NewAppearanceBySeed(tw.appearanceSeed)
+	tw.floorMan = floormanager.New(nil, tw)
+	if err := tw.floorMan.InitFromRecord(rd.Header.Snapshot); err != nil {
+		return err
+	}
+	defer func() {
+		for _, f := range tw.floorMan.GetFloorList() {
+			f.Cleanup()
+		}
+		tw.floorMan.Cleanup()
+	}()
+
+	// no tower loop in replay, discard floor request to tower
+	ctx, closeCtx := context.WithCancel(context.Background())
+	defer closeCtx()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tw.recvRequestCh:
+			}
+		}
+	}()
+
+	for {
+		tr, err := rd.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		f := tw.floorMan.GetFloorByName(tr.FloorName)
+		if f == nil {
+			return fmt.Errorf("floor not found %v", tr)
+		}
+		tw.replayTurnTime = tr.TurnTime
+		mismatch, err := f.ReplayTurn(tr)
+		tw.replayTurnTime = time.Time{}
+		if err != nil {
+			return err
+		}
+		if !fn(tr, mismatch) {
+			return nil
+		}
+	}
+}
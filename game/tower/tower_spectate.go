@@ -0,0 +1,54 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tower
+
+import (
+	"github.com/kasworld/goguelike/game/gamei"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_idnoti"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_serveconnbyte"
+)
+
+func (tw *Tower) findActiveObjByNickName(nickname string) gamei.ActiveObjectI {
+	for _, ao := range tw.id2ao.GetAllList() {
+		if ao.GetNickName() == nickname {
+			return ao
+		}
+	}
+	return nil
+}
+
+// startSpectate send current floor of ao to spectator
+// VPTiles, ObjectList follow in next turn of floor
+func (tw *Tower) startSpectate(conn *c2t_serveconnbyte.ServeConnByte, ao gamei.ActiveObjectI) {
+	f := ao.GetCurrentFloor()
+	if f == nil {
+		return
+	}
+	if err := conn.SendNotiPacket(
+		c2t_idnoti.EnterFloor,
+		&c2t_obj.NotiEnterFloor_data{
+			FI: f.ToPacket_FloorInfo(),
+		},
+	); err != nil {
+		tw.log.Error("%v %v", tw, err)
+		return
+	}
+	ao.SetNeedTANoti()
+}
+
+// updateSpectatorAutoFollow change auto follow spectator to new top of exp ranking
+func (tw *Tower) updateSpectatorAutoFollow(top gamei.ActiveObjectI) {
+	for _, conn := range tw.spectatorMan.UpdateAutoFollow(top.GetUUID()) {
+		tw.startSpectate(conn, top)
+	}
+}
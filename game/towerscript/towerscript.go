@@ -52,3 +52,21 @@ func LoadJSON(filename string) (TowerScript, error) {
 	}
 	return m, nil
 }
+
+// SplitCmd remove lines of isCmd from all script, removed lines returned in order
+func (m TowerScript) SplitCmd(isCmd func(line string) bool) (TowerScript, []string) {
+	rtn := make(TowerScript, 0, len(m))
+	var cmdList []string
+	for _, script := range m {
+		remain := make([]string, 0, len(script))
+		for _, line := range script {
+			if isCmd(line) {
+				cmdList = append(cmdList, line)
+			} else {
+				remain = append(remain, line)
+			}
+		}
+		rtn = append(rtn, remain)
+	}
+	return rtn, cmdList
+}
@@ -0,0 +1,229 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package towersnapshot runtime floor state to restart tower without regenerate floor
+// terrain remade by same seed and script then runtime state overwrite
+// floor of changed script is made new, script in snapshot is used only to compare
+// included : carryobj on floor, resource ageing, bias, appearance seed, diplomacy,
+// fieldobj state (door, boulder, rubble, rotate line attack, mine, shop stock)
+// activeobject is not included (system ao remade, user ao in aopersistent)
+// dangerobject: only projectile in flight is included
+// other dangerobject live only 1 turn, remade from fieldobj state
+package towersnapshot
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kasworld/goguelike/enum/carryingobjecttype"
+	"github.com/kasworld/goguelike/enum/equipslottype"
+	"github.com/kasworld/goguelike/enum/factiontype"
+	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/enum/potiontype"
+	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/enum/skilltype"
+	"github.com/kasworld/goguelike/enum/tile_flag"
+	"github.com/kasworld/goguelike/enum/way9type"
+	"github.com/kasworld/goguelike/game/bias"
+	"github.com/kasworld/goguelike/game/diplomacy"
+	"github.com/kasworld/goguelike/game/terrain/resourcetilearea"
+)
+
+// Version increase when snapshot format change, old version snapshot is ignored
+const Version = 9
+
+func (ts TowerSnapshot) String() string {
+	return fmt.Sprintf("TowerSnapshot[v%v %v %v floor:%v]",
+		ts.Version, ts.TowerName, ts.SaveTime.Format(time.RFC3339), len(ts.FloorList))
+}
+
+type TowerSnapshot struct {
+	Version   int
+	SaveTime  time.Time
+	TowerName string
+	// seed of potion, scroll appearance, keep unidentified name across restart
+	AppearanceSeed int64
+	// diplomacy changed by admin, restored if DiplomacyScript not changed
+	DiplomacyScript []string
+	Diplomacy       diplomacy.Snapshot
+	FloorList       []*FloorSnapshot
+}
+
+type FloorSnapshot struct {
+	Name   string
+	Seed   int64
+	Script []string // terrain script used to make floor
+	Bias   bias.Bias
+
+	Terrain        TerrainSnapshot
+	CarryObjList   []CarryObjSnapshot
+	ShopList       []ShopSnapshot
+	ProjectileList []ProjectileSnapshot // in add order
+}
+
+// ProjectileSnapshot projectile in flight at x,y
+// owner ao is not in snapshot, found by uuid when hit
+type ProjectileSnapshot struct {
+	X, Y           int
+	OwnerUUID      string
+	OwnerX, OwnerY int
+	Skill          skilltype.SkillType
+	Dir            way9type.Way9Type
+	Speed          int
+	RemainRange    int
+}
+
+// ShopSnapshot stock of Shop fieldobj at x,y
+type ShopSnapshot struct {
+	X, Y          int
+	RemainRestock int
+	ItemList      []CarryObjSnapshot // x,y not used
+}
+
+type TerrainSnapshot struct {
+	AgeingCount      int64
+	ResourceTileArea resourcetilearea.ResourceTileArea
+	DoorList         []DoorSnapshot
+	RubbleList       []RubbleSnapshot
+	WallDamageList   []WallDamageSnapshot
+	BoulderList      [][2]int
+	FieldObjList     []FieldObjSnapshot
+}
+
+// FieldObjSnapshot changing state of RotateLineAttack, Mine fieldobj
+type FieldObjSnapshot struct {
+	X, Y    int
+	ActType fieldobjacttype.FieldObjActType
+	Degree  int
+	Radius  float64
+}
+
+// RubbleSnapshot collapsed wall
+type RubbleSnapshot struct {
+	X, Y int
+	Tile tile_flag.TileFlag
+}
+
+// WallDamageSnapshot damaged not collapsed wall
+type WallDamageSnapshot struct {
+	X, Y   int
+	Damage float64
+}
+
+type DoorSnapshot struct {
+	X, Y   int
+	Closed bool
+	Locked bool
+}
+
+type CarryObjSnapshot struct {
+	X, Y               int
+	UUID               string
+	CarryingObjectType carryingobjecttype.CarryingObjectType
+
+	// equip
+	Name       string
+	Material   string
+	EquipType  equipslottype.EquipSlotType
+	Faction    factiontype.FactionType
+	BiasLen    float64
+	Durability int
+
+	PotionType potiontype.PotionType
+	ScrollType scrolltype.ScrollType
+	Value      float64 // money
+	KeyID      string  // key
+}
+
+// Save write gzip gob to temp file then rename
+func (ts *TowerSnapshot) Save(filename string) error {
+	tmpname := filename + ".tmp"
+	fd, err := os.Create(tmpname)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(fd)
+	if err := gob.NewEncoder(zw).Encode(ts); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpname, filename)
+}
+
+func Load(filename string) (*TowerSnapshot, error) {
+	fd, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+	zr, err := gzip.NewReader(fd)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	ts := &TowerSnapshot{}
+	if err := gob.NewDecoder(zr).Decode(ts); err != nil {
+		return nil, err
+	}
+	if ts.Version != Version {
+		return nil, fmt.Errorf("snapshot version mismatch %v != %v", ts.Version, Version)
+	}
+	return ts, nil
+}
+
+func (ts *TowerSnapshot) GetFloorByName(name string) *FloorSnapshot {
+	for _, v := range ts.FloorList {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// GetFloorByScript find floor made by same script
+func (ts *TowerSnapshot) GetFloorByScript(script []string) *FloorSnapshot {
+	for _, v := range ts.FloorList {
+		if isSameScript(v.Script, script) {
+			return v
+		}
+	}
+	return nil
+}
+
+// GetDiplomacyByScript false if diplomacy script changed
+func (ts *TowerSnapshot) GetDiplomacyByScript(script []string) (diplomacy.Snapshot, bool) {
+	if !isSameScript(ts.DiplomacyScript, script) {
+		return diplomacy.Snapshot{}, false
+	}
+	return ts.Diplomacy, true
+}
+
+func isSameScript(s1, s2 []string) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,245 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package turnrecorder record floor turn to replay
+// each turn floor rnd reseeded by recorded TurnSeed
+// so same act request with same ao state make same result
+package turnrecorder
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kasworld/goguelike/enum/skilltype"
+	"github.com/kasworld/goguelike/enum/skilltype_vector"
+	"github.com/kasworld/goguelike/game/activeobject/activebuff"
+	"github.com/kasworld/goguelike/game/activeobject/aoturndata"
+	"github.com/kasworld/goguelike/game/aoactreqrsp"
+	"github.com/kasworld/goguelike/game/bias"
+	"github.com/kasworld/goguelike/game/towersnapshot"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
+)
+
+// Version increase when record format change
+const Version = 6
+
+func (h Header) String() string {
+	return fmt.Sprintf("Header[v%v %v %v]",
+		h.Version, h.TowerName, h.StartTime.Format(time.RFC3339))
+}
+
+type Header struct {
+	Version    int
+	TowerName  string
+	StartTime  time.Time // tower start, for tower bias
+	BiasFactor [3]int64
+
+	// floor state at record start, replay make floor from this
+	Snapshot *towersnapshot.TowerSnapshot
+}
+
+type AOState struct {
+	UUID     string
+	NickName string
+	X, Y     int
+	HP       float64
+	AP       float64
+	Alive    bool
+}
+
+// AODetail restored to replay ao at turn start, not compared
+type AODetail struct {
+	SP        float64
+	BattleExp float64
+	Bias      bias.Bias
+	TurnData  aoturndata.ActiveObjTurnData // level, condition, ... at turn start
+	BuffList  []activebuff.ActiveBuff
+
+	LearnedSkill  []skilltype.SkillType
+	SkillCoolTurn skilltype_vector.SkillTypeVector
+
+	Wallet    float64
+	Ammo      int
+	KeyList   []string
+	EquipSlot []towersnapshot.CarryObjSnapshot // equipped, x,y not used
+	Bag       []towersnapshot.CarryObjSnapshot // x,y not used
+}
+
+// AOStartState ao state at turn start
+type AOStartState struct {
+	AOState
+	Detail AODetail
+}
+
+type ActRecord struct {
+	AOUUID string
+	Act    aoactreqrsp.Act
+}
+
+type ActResult struct {
+	AOState
+	Done  aoactreqrsp.Act
+	Error c2t_error.ErrorCode
+}
+
+func (tr TurnRecord) String() string {
+	return fmt.Sprintf("TurnRecord[%v %v ao:%v act:%v]",
+		tr.FloorName, tr.TurnTime.Format(time.RFC3339Nano), len(tr.AOList), len(tr.ActList))
+}
+
+type TurnRecord struct {
+	FloorName  string
+	TurnTime   time.Time
+	TurnSeed   int64
+	AOList     []AOStartState // at turn start, sorted by uuid
+	ActList    []ActRecord    // requested act
+	ResultList []ActResult    // at turn end, sorted by uuid
+}
+
+func (rc *Recorder) String() string {
+	return fmt.Sprintf("Recorder[%v %v]", rc.filename, rc.turnCount)
+}
+
+// Recorder write Header then TurnRecord stream, safe for multiple floor
+type Recorder struct {
+	mutex     sync.Mutex `prettystring:"hide"`
+	filename  string
+	fd        *os.File
+	zw        *gzip.Writer
+	enc       *gob.Encoder
+	turnCount int
+}
+
+func New(filename string, hd Header) (*Recorder, error) {
+	fd, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	rc := &Recorder{
+		filename: filename,
+		fd:       fd,
+		zw:       gzip.NewWriter(fd),
+	}
+	rc.enc = gob.NewEncoder(rc.zw)
+	hd.Version = Version
+	if err := rc.enc.Encode(hd); err != nil {
+		rc.zw.Close()
+		fd.Close()
+		return nil, err
+	}
+	return rc, nil
+}
+
+func (rc *Recorder) Write(tr *TurnRecord) error {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	if rc.enc == nil {
+		return fmt.Errorf("recorder closed %v", rc)
+	}
+	rc.turnCount++
+	return rc.enc.Encode(tr)
+}
+
+func (rc *Recorder) Close() error {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	if rc.enc == nil {
+		return nil
+	}
+	rc.enc = nil
+	if err := rc.zw.Close(); err != nil {
+		rc.fd.Close()
+		return err
+	}
+	return rc.fd.Close()
+}
+
+// Reader read recorded file
+type Reader struct {
+	fd     *os.File
+	zr     *gzip.Reader
+	dec    *gob.Decoder
+	Header Header
+}
+
+func Open(filename string) (*Reader, error) {
+	fd, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(fd)
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+	rd := &Reader{
+		fd:  fd,
+		zr:  zr,
+		dec: gob.NewDecoder(zr),
+	}
+	if err := rd.dec.Decode(&rd.Header); err != nil {
+		rd.Close()
+		return nil, err
+	}
+	if rd.Header.Version != Version {
+		rd.Close()
+		return nil, fmt.Errorf("record version mismatch %v != %v",
+			rd.Header.Version, Version)
+	}
+	return rd, nil
+}
+
+// Next return io.EOF at end
+func (rd *Reader) Next() (*TurnRecord, error) {
+	tr := &TurnRecord{}
+	if err := rd.dec.Decode(tr); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, io.EOF // not closed record
+		}
+		return nil, err
+	}
+	return tr, nil
+}
+
+func (rd *Reader) Close() error {
+	rd.zr.Close()
+	return rd.fd.Close()
+}
+
+// CompareResult return not matched result
+func CompareResult(recorded, replayed []ActResult) []string {
+	rtn := make([]string, 0)
+	id2replay := make(map[string]ActResult, len(replayed))
+	for _, v := range replayed {
+		id2replay[v.UUID] = v
+	}
+	for _, v := range recorded {
+		r, exist := id2replay[v.UUID]
+		if !exist {
+			rtn = append(rtn, fmt.Sprintf("%v %v not in replay", v.UUID, v.NickName))
+			continue
+		}
+		delete(id2replay, v.UUID)
+		if r != v {
+			rtn = append(rtn, fmt.Sprintf("%v %v recorded %+v replayed %+v",
+				v.UUID, v.NickName, v, r))
+		}
+	}
+	for _, r := range id2replay {
+		rtn = append(rtn, fmt.Sprintf("%v %v not in record", r.UUID, r.NickName))
+	}
+	return rtn
+}
@@ -66,6 +66,17 @@ func (i2v *ID2VisitArea) Add(fi floorI) *VisitArea {
 	return va
 }
 
+func (i2v *ID2VisitArea) Restore(fi floorI, discovered int, bitsList []BitContainder) (*VisitArea, error) {
+	va, err := NewVisitAreaFromBits(fi, discovered, bitsList)
+	if err != nil {
+		return nil, err
+	}
+	i2v.mutex.Lock()
+	defer i2v.mutex.Unlock()
+	i2v.id2visit[fi.GetName()] = va
+	return va, nil
+}
+
 func (i2v *ID2VisitArea) Del(id string) {
 	i2v.mutex.Lock()
 	defer i2v.mutex.Unlock()
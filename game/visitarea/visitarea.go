@@ -68,6 +68,19 @@ func NewVisitArea(fi floorI) *VisitArea {
 	return va
 }
 
+// NewVisitAreaFromBits restore saved visit data
+func NewVisitAreaFromBits(fi floorI, discovered int, bitsList []BitContainder) (*VisitArea, error) {
+	va := NewVisitArea(fi)
+	if len(va.bitsList) != len(bitsList) {
+		return nil, fmt.Errorf("bitsList size mismatch %v %v %v",
+			fi.GetName(), len(va.bitsList), len(bitsList))
+	}
+	copy(va.bitsList, bitsList)
+	va.discoveredTileCount = discovered
+	va.updateExp()
+	return va, nil
+}
+
 func (va *VisitArea) Cleanup() {
 	va.mutex.Lock()
 	defer va.mutex.Unlock()
@@ -104,6 +117,23 @@ func (va *VisitArea) GetUUID() string {
 	return va.uuid
 }
 
+func (va *VisitArea) GetWidth() int {
+	return va.w
+}
+
+func (va *VisitArea) GetHeight() int {
+	return va.h
+}
+
+// GetBitsListCopy for save
+func (va *VisitArea) GetBitsListCopy() []BitContainder {
+	va.mutex.RLock()
+	defer va.mutex.RUnlock()
+	rtn := make([]BitContainder, len(va.bitsList))
+	copy(rtn, va.bitsList)
+	return rtn
+}
+
 func (va *VisitArea) GetDiscoveredTileCount() int {
 	va.mutex.RLock()
 	defer va.mutex.RUnlock()
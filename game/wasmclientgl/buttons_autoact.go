@@ -169,6 +169,27 @@ func tryAutoBattle(app *WasmClient, v *htmlbutton.HTMLButton) bool {
 		}
 	}
 
+	// shoot
+	if app.olNotiData.ActiveObj == nil || app.olNotiData.ActiveObj.Ammo < 1 {
+		return false
+	}
+	for _, ao := range app.olNotiData.ActiveObjList {
+		if !ao.Alive {
+			continue
+		}
+		if ao.UUID == gInitData.AccountInfo.ActiveObjUUID {
+			continue
+		}
+		attackdir, canAttack := attackcheck.CanShootTo(
+			cf.Tiles, playerX, playerY, ao.X, ao.Y)
+		if canAttack {
+			go app.sendPacket(c2t_idcmd.Shoot,
+				&c2t_obj.ReqShoot_data{Dir: attackdir},
+			)
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -344,6 +365,11 @@ func (app *WasmClient) needUnEquipCarryObj(PoBias bias.Bias) bool {
 }
 
 func (app *WasmClient) needUseScroll(po *c2t_obj.ScrollClient) bool {
+	if !po.Identified() {
+		// read to identify when safe
+		pao := app.olNotiData.ActiveObj
+		return pao.HPMax/2 < pao.HP
+	}
 	cf := app.currentFloor()
 	switch po.ScrollType {
 	case scrolltype.FloorMap:
@@ -356,6 +382,10 @@ func (app *WasmClient) needUseScroll(po *c2t_obj.ScrollClient) bool {
 
 func (app *WasmClient) needUsePotion(po *c2t_obj.PotionClient) bool {
 	pao := app.olNotiData.ActiveObj
+	if !po.Identified() {
+		// drink to identify when safe
+		return pao.HPMax/2 < pao.HP
+	}
 	switch po.PotionType {
 	case potiontype.RecoverHP10:
 		return pao.HPMax-pao.HP > 10
@@ -415,7 +445,7 @@ func (app *WasmClient) recycleEqbag() bool {
 
 func (app *WasmClient) recycleUselessPotion() bool {
 	for _, po := range app.olNotiData.ActiveObj.PotionBag {
-		if potiontype.AIRecycleMap[po.PotionType] {
+		if po.Identified() && potiontype.AIRecycleMap[po.PotionType] {
 			go app.sendPacket(c2t_idcmd.Recycle,
 				&c2t_obj.ReqRecycle_data{UUID: po.UUID},
 			)
@@ -427,7 +457,7 @@ func (app *WasmClient) recycleUselessPotion() bool {
 
 func (app *WasmClient) recycleUselessScroll() bool {
 	for _, po := range app.olNotiData.ActiveObj.ScrollBag {
-		if scrolltype.AIRecycleMap[po.ScrollType] {
+		if po.Identified() && scrolltype.AIRecycleMap[po.ScrollType] {
 			go app.sendPacket(c2t_idcmd.Recycle,
 				&c2t_obj.ReqRecycle_data{UUID: po.UUID},
 			)
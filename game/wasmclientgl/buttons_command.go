@@ -15,8 +15,10 @@ import (
 	"github.com/kasworld/goguelike/enum/achievetype"
 	"github.com/kasworld/goguelike/enum/condition"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/enum/petorder"
 	"github.com/kasworld/goguelike/enum/potiontype"
 	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/enum/way9type"
 	"github.com/kasworld/goguelike/lib/htmlbutton"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
@@ -29,6 +31,8 @@ var commandButtons = htmlbutton.NewButtonGroup("Commands",
 	[]*htmlbutton.HTMLButton{
 		htmlbutton.New("a", "KillSelf", []string{"KillSelf"}, "Kill self", cmdKillSelf, 0),
 		htmlbutton.New("s", "EnterPortal", []string{"EnterPortal"}, "Enter portal", cmdEnterPortal, 0),
+		htmlbutton.New("o", "CloseDoor", []string{"CloseDoor"}, "Close near open door", cmdCloseDoor, 0),
+		htmlbutton.New("p", "PetStay", []string{"PetStay"}, "Toggle pet stay, follow", cmdPetStay, 0),
 		htmlbutton.New("d", "Teleport", []string{"Teleport"}, "Teleport random in floor", cmdTeleport, 0),
 		htmlbutton.New("f", "Rebirth", []string{"Rebirth"}, "Rebirth", cmdRebirth, 0),
 		htmlbutton.New("g", "ShowAchieve", []string{"ShowAchieve"}, "Show Achievement", cmdShowAchieve, 0),
@@ -51,6 +55,53 @@ func cmdKillSelf(obj interface{}, v *htmlbutton.HTMLButton) {
 	v.Blur()
 }
 
+func cmdCloseDoor(obj interface{}, v *htmlbutton.HTMLButton) {
+	app, ok := obj.(*WasmClient)
+	if !ok {
+		jslog.Errorf("obj not app %v", obj)
+		return
+	}
+	cf := app.currentFloor()
+	playerX, playerY := app.GetPlayerXY()
+	for dir := way9type.Way9Type(1); dir < way9type.Way9Type_Count; dir++ {
+		if isDoor, closed := cf.IsDoorAt(cf.PosAddDir(playerX, playerY, dir)); isDoor && !closed {
+			go app.sendPacket(c2t_idcmd.CloseDoor,
+				&c2t_obj.ReqCloseDoor_data{Dir: dir},
+			)
+			break
+		}
+	}
+	v.Blur()
+}
+
+// cmdPetStay change order of one pet, Follow to Stay, other to Follow
+func cmdPetStay(obj interface{}, v *htmlbutton.HTMLButton) {
+	app, ok := obj.(*WasmClient)
+	if !ok {
+		jslog.Errorf("obj not app %v", obj)
+		return
+	}
+	petList := app.olNotiData.ActiveObj.PetList
+	if len(petList) == 0 {
+		app.systemMessage.Append("no pet")
+		v.Blur()
+		return
+	}
+	pet := petList[0]
+	order := petorder.Follow
+	for _, p := range petList {
+		if p.Order == petorder.Follow {
+			pet = p
+			order = petorder.Stay
+			break
+		}
+	}
+	go app.sendPacket(c2t_idcmd.CommandPet,
+		&c2t_obj.ReqCommandPet_data{PetUUID: pet.UUID, Order: order},
+	)
+	v.Blur()
+}
+
 func cmdShowAchieve(obj interface{}, v *htmlbutton.HTMLButton) {
 	app, ok := obj.(*WasmClient)
 	if !ok {
@@ -20,6 +20,7 @@ import (
 	"github.com/kasworld/goguelike/enum/carryingobjecttype"
 	"github.com/kasworld/goguelike/enum/equipslottype"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
+	"github.com/kasworld/htmlcolors"
 )
 
 func NewCarryObj3DGeo(str string) js.Value {
@@ -56,6 +57,10 @@ func CarryObj2StrColor(o *c2t_obj.CarryObjClientOnFloor) (string, string) {
 		}
 		v := moneycolor.Attrib[len(moneycolor.Attrib)-1]
 		return "$", v.Color.ToHTMLColorString()
+	case carryingobjecttype.Ammo:
+		return "|", htmlcolors.Silver.ToHTMLColorString()
+	case carryingobjecttype.Key:
+		return "F", htmlcolors.DarkGoldenrod.ToHTMLColorString()
 	case carryingobjecttype.Potion:
 		return o.PotionType.Rune(), o.PotionType.Color24().ToHTMLColorString()
 	case carryingobjecttype.Scroll:
@@ -209,4 +214,6 @@ var otherCarryObjShift = [carryingobjecttype.CarryingObjectType_Count]ShiftInfo{
 	carryingobjecttype.Money:  {DstCellSize * 0.33, DstCellSize * 0.0, DstCellSize * 0.33},
 	carryingobjecttype.Potion: {DstCellSize * 0.33, DstCellSize * 0.33, DstCellSize * 0.33},
 	carryingobjecttype.Scroll: {DstCellSize * 0.33, DstCellSize * 0.66, DstCellSize * 0.33},
+	carryingobjecttype.Ammo:   {DstCellSize * 0.66, DstCellSize * 0.0, DstCellSize * 0.33},
+	carryingobjecttype.Key:    {DstCellSize * 0.66, DstCellSize * 0.33, DstCellSize * 0.33},
 }
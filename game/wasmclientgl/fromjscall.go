@@ -34,6 +34,13 @@ func (app *WasmClient) registerJSButton() {
 	js.Global().Set("drinkpotion", js.FuncOf(app.jsDrinkPotion))
 	js.Global().Set("readscroll", js.FuncOf(app.jsReadScroll))
 	js.Global().Set("recycle", js.FuncOf(app.jsRecycleCarryObj))
+	js.Global().Set("craft", js.FuncOf(app.jsCraftCarryObj))
+	js.Global().Set("listshop", js.FuncOf(app.jsListShop))
+	js.Global().Set("buy", js.FuncOf(app.jsBuyCarryObj))
+	js.Global().Set("repair", js.FuncOf(app.jsRepairEquip))
+	js.Global().Set("listquest", js.FuncOf(app.jsListQuest))
+	js.Global().Set("acceptquest", js.FuncOf(app.jsAcceptQuest))
+	js.Global().Set("completequest", js.FuncOf(app.jsCompleteQuest))
 }
 
 func (app *WasmClient) jsUnequipCarryObj(this js.Value, args []js.Value) interface{} {
@@ -78,6 +85,56 @@ func (app *WasmClient) jsRecycleCarryObj(this js.Value, args []js.Value) interfa
 	GetElementById(id).Call("blur")
 	return nil
 }
+func (app *WasmClient) jsCraftCarryObj(this js.Value, args []js.Value) interface{} {
+	recipe := strings.TrimSpace(args[0].String())
+	go app.sendPacket(c2t_idcmd.Craft,
+		&c2t_obj.ReqCraft_data{Recipe: recipe},
+	)
+	GetElementById(recipe).Call("blur")
+	return nil
+}
+func (app *WasmClient) jsListShop(this js.Value, args []js.Value) interface{} {
+	go app.reqListShop()
+	GetElementById("listshop").Call("blur")
+	return nil
+}
+func (app *WasmClient) jsBuyCarryObj(this js.Value, args []js.Value) interface{} {
+	id := strings.TrimSpace(args[0].String())
+	go app.sendPacket(c2t_idcmd.Buy,
+		&c2t_obj.ReqBuy_data{UUID: id},
+	)
+	GetElementById(id).Call("blur")
+	return nil
+}
+func (app *WasmClient) jsRepairEquip(this js.Value, args []js.Value) interface{} {
+	id := strings.TrimSpace(args[0].String())
+	go app.sendPacket(c2t_idcmd.Repair,
+		&c2t_obj.ReqRepair_data{UUID: id},
+	)
+	GetElementById(id).Call("blur")
+	return nil
+}
+func (app *WasmClient) jsListQuest(this js.Value, args []js.Value) interface{} {
+	go app.reqListQuest()
+	GetElementById("listquest").Call("blur")
+	return nil
+}
+func (app *WasmClient) jsAcceptQuest(this js.Value, args []js.Value) interface{} {
+	name := strings.TrimSpace(args[0].String())
+	go app.sendPacket(c2t_idcmd.AcceptQuest,
+		&c2t_obj.ReqAcceptQuest_data{Name: name},
+	)
+	GetElementById("accept" + name).Call("blur")
+	return nil
+}
+func (app *WasmClient) jsCompleteQuest(this js.Value, args []js.Value) interface{} {
+	name := strings.TrimSpace(args[0].String())
+	go app.sendPacket(c2t_idcmd.CompleteQuest,
+		&c2t_obj.ReqCompleteQuest_data{Name: name},
+	)
+	GetElementById("complete" + name).Call("blur")
+	return nil
+}
 func (app *WasmClient) jsDropCarryObj(this js.Value, args []js.Value) interface{} {
 	id := strings.TrimSpace(args[0].String())
 	go app.sendPacket(c2t_idcmd.Drop,
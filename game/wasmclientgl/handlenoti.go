@@ -21,7 +21,9 @@ import (
 	"github.com/kasworld/goguelike/config/leveldata"
 	"github.com/kasworld/goguelike/enum/clientcontroltype"
 	"github.com/kasworld/goguelike/enum/condition"
+	"github.com/kasworld/goguelike/enum/equipslottype"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/enum/skilltype"
 	"github.com/kasworld/goguelike/enum/turnresulttype"
 	"github.com/kasworld/goguelike/enum/way9type"
 	"github.com/kasworld/goguelike/game/aoactreqrsp"
@@ -36,21 +38,32 @@ import (
 )
 
 var ProcessRecvObjNotiFnMap = [...]func(recvobj interface{}, header c2t_packet.Header, body interface{}) error{
-	c2t_idnoti.EnterTower:     objRecvNotiFn_EnterTower,
-	c2t_idnoti.LeaveTower:     objRecvNotiFn_LeaveTower,
-	c2t_idnoti.EnterFloor:     objRecvNotiFn_EnterFloor,
-	c2t_idnoti.LeaveFloor:     objRecvNotiFn_LeaveFloor,
-	c2t_idnoti.Ageing:         objRecvNotiFn_Ageing,
-	c2t_idnoti.Death:          objRecvNotiFn_Death,
-	c2t_idnoti.ReadyToRebirth: objRecvNotiFn_ReadyToRebirth,
-	c2t_idnoti.Rebirthed:      objRecvNotiFn_Rebirthed,
-	c2t_idnoti.Broadcast:      objRecvNotiFn_Broadcast,
-	c2t_idnoti.VPTiles:        objRecvNotiFn_VPTiles,
-	c2t_idnoti.ObjectList:     objRecvNotiFn_ObjectList,
-	c2t_idnoti.FloorTiles:     objRecvNotiFn_FloorTiles,
-	c2t_idnoti.FoundFieldObj:  objRecvNotiFn_FoundFieldObj,
-	c2t_idnoti.ForgetFloor:    objRecvNotiFn_ForgetFloor,
-	c2t_idnoti.ActivateTrap:   objRecvNotiFn_ActivateTrap,
+	c2t_idnoti.EnterTower:      objRecvNotiFn_EnterTower,
+	c2t_idnoti.LeaveTower:      objRecvNotiFn_LeaveTower,
+	c2t_idnoti.EnterFloor:      objRecvNotiFn_EnterFloor,
+	c2t_idnoti.LeaveFloor:      objRecvNotiFn_LeaveFloor,
+	c2t_idnoti.Ageing:          objRecvNotiFn_Ageing,
+	c2t_idnoti.Death:           objRecvNotiFn_Death,
+	c2t_idnoti.ReadyToRebirth:  objRecvNotiFn_ReadyToRebirth,
+	c2t_idnoti.Rebirthed:       objRecvNotiFn_Rebirthed,
+	c2t_idnoti.Broadcast:       objRecvNotiFn_Broadcast,
+	c2t_idnoti.ChatWhisper:     objRecvNotiFn_ChatWhisper,
+	c2t_idnoti.ChatFloor:       objRecvNotiFn_ChatFloor,
+	c2t_idnoti.ChatTower:       objRecvNotiFn_ChatTower,
+	c2t_idnoti.ChatFaction:     objRecvNotiFn_ChatFaction,
+	c2t_idnoti.TradeState:      objRecvNotiFn_TradeState,
+	c2t_idnoti.Craft:           objRecvNotiFn_Craft,
+	c2t_idnoti.Quest:           objRecvNotiFn_Quest,
+	c2t_idnoti.BossKilled:      objRecvNotiFn_BossKilled,
+	c2t_idnoti.Diplomacy:       objRecvNotiFn_Diplomacy,
+	c2t_idnoti.HeardNoise:      objRecvNotiFn_HeardNoise,
+	c2t_idnoti.VPTiles:         objRecvNotiFn_VPTiles,
+	c2t_idnoti.ObjectList:      objRecvNotiFn_ObjectList,
+	c2t_idnoti.ObjectListDelta: objRecvNotiFn_ObjectListDelta,
+	c2t_idnoti.FloorTiles:      objRecvNotiFn_FloorTiles,
+	c2t_idnoti.FoundFieldObj:   objRecvNotiFn_FoundFieldObj,
+	c2t_idnoti.ForgetFloor:     objRecvNotiFn_ForgetFloor,
+	c2t_idnoti.ActivateTrap:    objRecvNotiFn_ActivateTrap,
 }
 
 func objRecvNotiFn_EnterTower(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
@@ -241,6 +254,210 @@ func objRecvNotiFn_Broadcast(recvobj interface{}, header c2t_packet.Header, obj
 	return nil
 }
 
+func objRecvNotiFn_ChatWhisper(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiChatWhisper_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	app.systemMessage.Appendf("Whisper %v : %v", robj.SenderName, robj.Chat)
+	return nil
+}
+
+func objRecvNotiFn_ChatFloor(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiChatFloor_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	app.systemMessage.Appendf("Floor %v : %v", robj.SenderName, robj.Chat)
+	return nil
+}
+
+func objRecvNotiFn_ChatTower(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiChatTower_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	app.systemMessage.Appendf("Tower %v : %v", robj.SenderName, robj.Chat)
+	return nil
+}
+
+func objRecvNotiFn_ChatFaction(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiChatFaction_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	app.systemMessage.Appendf("Faction %v %v : %v", robj.Faction, robj.SenderName, robj.Chat)
+	return nil
+}
+
+func objRecvNotiFn_TradeState(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiTradeState_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	app.systemMessage.Appendf("Trade %v with %v", robj.State, robj.Other.NickName)
+	app.NotiMessage.AppendTf(tcsInfo,
+		"Trade %v with %v", robj.State, robj.Other.NickName)
+	return nil
+}
+
+func objRecvNotiFn_Craft(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiCraft_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	for _, v := range robj.EquipList {
+		app.systemMessage.Appendf("Craft %v made %v", robj.Recipe, v.Name)
+	}
+	for _, v := range robj.PotionList {
+		app.systemMessage.Appendf("Craft %v made %v", robj.Recipe, v.Name())
+	}
+	for _, v := range robj.ScrollList {
+		app.systemMessage.Appendf("Craft %v made %v", robj.Recipe, v.Name())
+	}
+	app.NotiMessage.AppendTf(tcsInfo, "Craft %v", robj.Recipe)
+	return nil
+}
+
+func objRecvNotiFn_Quest(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiQuest_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	q := robj.Quest
+	switch {
+	case q.Completed:
+		app.NotiMessage.AppendTf(tcsInfo, "Quest %v completed", q.Name)
+	case q.CanComplete():
+		app.NotiMessage.AppendTf(tcsInfo, "Quest %v ready to complete", q.Name)
+	}
+	for _, v := range q.Objective {
+		app.systemMessage.Appendf("Quest %v %v %v/%v", q.Name, v.Objective, v.Count, v.Need)
+	}
+	if ql := app.questList; ql != nil {
+		ql.QuestList = updateQuestClientList(ql.QuestList, q)
+	}
+	return nil
+}
+
+func objRecvNotiFn_BossKilled(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiBossKilled_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	if robj.KillerName == "" {
+		app.NotiMessage.AppendTf(tcsInfo, "Boss %v died in %v", robj.BossName, robj.FloorName)
+	} else {
+		app.NotiMessage.AppendTf(tcsInfo, "Boss %v killed by %v in %v",
+			robj.BossName, robj.KillerName, robj.FloorName)
+	}
+	return nil
+}
+
+func objRecvNotiFn_Diplomacy(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiDiplomacy_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	app.systemMessage.Appendf("%v Ally %v Hostile %v",
+		robj.Faction, robj.AllyList, robj.HostileList)
+	if robj.AllyAttackRate <= 0 {
+		app.systemMessage.Appendf("can not attack ally")
+	}
+	return nil
+}
+
+func objRecvNotiFn_HeardNoise(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiHeardNoise_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	for _, v := range robj.NoiseList {
+		app.systemMessage.Appendf("heard %v at [%v %v]",
+			v.NoiseType, v.X, v.Y)
+	}
+	return nil
+}
+
+// updateQuestClientList replace or append by name, remove completed
+func updateQuestClientList(list []*c2t_obj.QuestClient, q *c2t_obj.QuestClient) []*c2t_obj.QuestClient {
+	rtn := make([]*c2t_obj.QuestClient, 0, len(list)+1)
+	found := false
+	for _, v := range list {
+		if v.Name == q.Name {
+			found = true
+			if !q.Completed {
+				rtn = append(rtn, q)
+			}
+			continue
+		}
+		rtn = append(rtn, v)
+	}
+	if !found && !q.Completed {
+		rtn = append(rtn, q)
+	}
+	return rtn
+}
+
+func objRecvNotiFn_ObjectListDelta(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
+	robj, ok := obj.(*c2t_obj.NotiObjectListDelta_data)
+	if !ok {
+		return fmt.Errorf("packet mismatch %v", obj)
+	}
+	app, ok := recvobj.(*WasmClient)
+	if !ok {
+		return fmt.Errorf("recvobj type mismatch %v", recvobj)
+	}
+	ol, err := app.olReceiver.Apply(robj)
+	if err != nil {
+		// wait next keyframe
+		jslog.Warnf("%v", err)
+		app.waitObjList = false
+		return nil
+	}
+	return objRecvNotiFn_ObjectList(recvobj, header, ol)
+}
+
 func objRecvNotiFn_ObjectList(recvobj interface{}, header c2t_packet.Header, obj interface{}) error {
 	robj, ok := obj.(*c2t_obj.NotiObjectList_data)
 	if !ok {
@@ -259,6 +476,11 @@ func objRecvNotiFn_ObjectList(recvobj interface{}, header c2t_packet.Header, obj
 		}
 	}()
 
+	if robj.Seq != 0 {
+		app.olReceiver.AddKeyframe(robj)
+		go app.reqAckObjectList(robj.Seq)
+	}
+
 	app.ServerClientTimeDiff = robj.Time.Sub(time.Now())
 	app.olNotiHeader = header
 
@@ -402,7 +624,7 @@ func objRecvNotiFn_ObjectList(recvobj interface{}, header c2t_packet.Header, obj
 		return nil
 	}
 	for _, v := range newOLNotiData.FieldObjList {
-		cf.FieldObjPosMan.AddToXY(v, v.X, v.Y)
+		cf.FieldObjPosMan.AddOrUpdateToXY(v, v.X, v.Y) // boulder moved
 	}
 
 	playerX, playerY := app.GetPlayerXY()
@@ -536,6 +758,14 @@ func (app *WasmClient) processTurnResult(v c2t_obj.TurnResultClient) {
 		}
 		app.systemMessage.Appendf("Contagion to %v", aostr)
 		app.NotiMessage.AppendTf(tcsInfo, "Contagion to %v", nickname)
+	case turnresulttype.LearnSkill:
+		st := skilltype.SkillType(v.Arg)
+		app.systemMessage.Appendf("Learn skill %v", st)
+		app.NotiMessage.AppendTf(tcsInfo, "Learn skill %v", st)
+	case turnresulttype.EquipBroken:
+		eqslot := equipslottype.EquipSlotType(v.Arg)
+		app.systemMessage.Appendf("%v broken", eqslot)
+		app.NotiMessage.AppendTf(tcsInfo, "%v broken", eqslot)
 	case turnresulttype.ContagionFromFail:
 		dstao, exist := app.AOUUID2AOClient[v.DstUUID]
 		aostr := "??"
@@ -25,6 +25,7 @@ import (
 	"github.com/kasworld/goguelike/game/clientcookie"
 	"github.com/kasworld/goguelike/lib/jsobj"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_connwasm"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_error"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_gob"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_idcmd"
 	"github.com/kasworld/goguelike/protocol_c2t/c2t_obj"
@@ -155,6 +156,48 @@ func (app *WasmClient) reqAIPlay(onoff bool) error {
 	)
 }
 
+func (app *WasmClient) reqAckObjectList(seq int) error {
+	return app.ReqWithRspFnWithAuth(
+		c2t_idcmd.AckObjectList,
+		&c2t_obj.ReqAckObjectList_data{
+			Seq: seq,
+		},
+		func(hd c2t_packet.Header, rsp interface{}) error {
+			return nil
+		},
+	)
+}
+
+func (app *WasmClient) reqListShop() error {
+	return app.ReqWithRspFnWithAuth(
+		c2t_idcmd.ListShop,
+		&c2t_obj.ReqListShop_data{},
+		func(hd c2t_packet.Header, rsp interface{}) error {
+			if hd.ErrorCode != c2t_error.None {
+				app.shopList = nil
+				return nil
+			}
+			app.shopList = rsp.(*c2t_obj.RspListShop_data)
+			return nil
+		},
+	)
+}
+
+func (app *WasmClient) reqListQuest() error {
+	return app.ReqWithRspFnWithAuth(
+		c2t_idcmd.ListQuest,
+		&c2t_obj.ReqListQuest_data{},
+		func(hd c2t_packet.Header, rsp interface{}) error {
+			if hd.ErrorCode != c2t_error.None {
+				app.questList = nil
+				return nil
+			}
+			app.questList = rsp.(*c2t_obj.RspListQuest_data)
+			return nil
+		},
+	)
+}
+
 func (app *WasmClient) reqHeartbeat() error {
 	return app.ReqWithRspFnWithAuth(
 		c2t_idcmd.Heartbeat,
@@ -185,6 +228,24 @@ func (app *WasmClient) sendPacket(cmd c2t_idcmd.CommandID, arg interface{}) {
 func (app *WasmClient) sendMovePacketByInput(tryDir way9type.Way9Type) bool {
 	cf := app.currentFloor()
 	playerX, playerY := app.GetPlayerXY()
+	if tryDir != way9type.Center {
+		// bump to closed door, try open
+		if isDoor, closed := cf.IsDoorAt(cf.PosAddDir(playerX, playerY, tryDir)); isDoor && closed {
+			atomic.AddInt32(&app.movePacketPerTurn, 1)
+			go app.sendPacket(c2t_idcmd.OpenDoor,
+				&c2t_obj.ReqOpenDoor_data{Dir: tryDir},
+			)
+			return true
+		}
+		// bump to boulder, try push
+		if cf.IsBoulderAt(cf.PosAddDir(playerX, playerY, tryDir)) {
+			atomic.AddInt32(&app.movePacketPerTurn, 1)
+			go app.sendPacket(c2t_idcmd.Move,
+				&c2t_obj.ReqMove_data{Dir: tryDir},
+			)
+			return true
+		}
+	}
 	moveDir := cf.FindMovableDir(playerX, playerY, tryDir)
 	if moveDir != way9type.Center {
 		atomic.AddInt32(&app.movePacketPerTurn, 1)
@@ -198,6 +198,14 @@ func (app *WasmClient) makeBaseInfoHTML() string {
 	fmt.Fprintf(&buf, "Equip %v Bag %v<br/>", len(pao.EquippedPo), len(pao.EquipBag))
 	fmt.Fprintf(&buf, "Potion %v Scroll %v<br/>", len(pao.PotionBag), len(pao.ScrollBag))
 	fmt.Fprintf(&buf, "Wallet %v<br/>", makeMoneyColor(pao.Wallet))
+	fmt.Fprintf(&buf, "Ammo %v Key %v<br/>", pao.Ammo, pao.Key)
+	for _, v := range pao.SkillList {
+		fmt.Fprintf(&buf, "Skill %v cool %v<br/>", v.Skill, v.RemainCool)
+	}
+	for _, v := range pao.PetList {
+		fmt.Fprintf(&buf, "Pet %v Lv%v %v HP %v/%v<br/>",
+			v.NickName, v.Level, v.Order, v.HP, v.HPMax)
+	}
 	return buf.String()
 }
 
@@ -238,12 +246,29 @@ func (app *WasmClient) getDefenceBias() bias.Bias {
 }
 
 var makeRecycleButton = `<button style="font-size: %vpx" onclick="recycle('%s')" id="%s" >Recycle</button> `
+var makeCraftButton = `<button style="font-size: %vpx" onclick="craft('%s')" id="%s" >%s</button> `
+var makeListShopButton = `<button style="font-size: %vpx" onclick="listshop()" id="listshop" >ListShop</button> `
+var makeBuyButton = `<button style="font-size: %vpx" onclick="buy('%s')" id="%s" >Buy %.0f</button> `
+var makeRepairButton = `<button style="font-size: %vpx" onclick="repair('%s')" id="%s" >Repair %.0f</button> `
+var makeListQuestButton = `<button style="font-size: %vpx" onclick="listquest()" id="listquest" >ListQuest</button> `
+var makeAcceptQuestButton = `<button style="font-size: %vpx" onclick="acceptquest('%s')" id="accept%s" >Accept</button> `
+var makeCompleteQuestButton = `<button style="font-size: %vpx" onclick="completequest('%s')" id="complete%s" >Complete</button> `
 var makeUnequipButton = `<button style="font-size: %vpx" onclick="unequip('%s')" id="%s" >Unequip</button> `
 var makeEquipButton = `<button style="font-size: %vpx" onclick="equip('%s')" id="%s" >Equip</button> `
 var makeDropButton = `<button style="font-size: %vpx" onclick="drop('%s')" id="%s" >Drop</button> `
 var makeDrinkPotionButton = `<button style="font-size: %vpx" onclick="drinkpotion('%s')" id="%s" >DrinkPotion</button> `
 var makeReadScrollButton = `<button style="font-size: %vpx" onclick="readscroll('%s')" id="%s" >ReadScroll</button> `
 
+// questObjectiveString objective with progress and reward of quest
+func questObjectiveString(q *c2t_obj.QuestClient) string {
+	var buf bytes.Buffer
+	for _, v := range q.Objective {
+		fmt.Fprintf(&buf, "%v %v/%v ", v.Objective, v.Count, v.Need)
+	}
+	fmt.Fprintf(&buf, "%v", q.Reward)
+	return buf.String()
+}
+
 func (app *WasmClient) makeInvenInfoHTML() string {
 
 	var buf bytes.Buffer
@@ -255,13 +280,86 @@ func (app *WasmClient) makeInvenInfoHTML() string {
 	if app.onFieldObj != nil && app.onFieldObj.ActType == fieldobjacttype.RecycleCarryObj {
 		canRecycle = true
 	}
+	canRepair := false
+	if app.onFieldObj != nil && app.onFieldObj.ActType == fieldobjacttype.RepairEquip {
+		canRepair = true
+	}
 	displayedLine := 4 // text not in loop
 
+	if app.onFieldObj != nil && app.onFieldObj.ActType == fieldobjacttype.CraftCarryObj {
+		buf.WriteString("Craft ")
+		for _, v := range gInitData.TowerInfo.CraftRecipeList {
+			fmt.Fprintf(&buf, makeCraftButton, ftSize, v, v, v)
+		}
+		buf.WriteString("<br/>")
+		displayedLine++
+	}
+	if app.onFieldObj != nil && app.onFieldObj.ActType == fieldobjacttype.Shop {
+		fmt.Fprintf(&buf, makeListShopButton, ftSize)
+		buf.WriteString("<br/>")
+		displayedLine++
+		if sl := app.shopList; sl != nil && sl.ShopID == app.onFieldObj.ID {
+			for _, v := range sl.ItemList {
+				if displayedLine > DisplayLineLimit {
+					break
+				}
+				displayedLine++
+				switch {
+				case v.Equip != nil:
+					fmt.Fprintf(&buf, "%s ", v.Equip.Name)
+					buf.WriteString(wrapspan.THCSTextf(v.Equip.GetBias(), "%v%v%.0f",
+						v.Equip.EquipType.Rune(), v.Equip.Faction.Rune(), v.Equip.BiasLen))
+				case v.Potion != nil:
+					pt := v.Potion.PotionType
+					buf.WriteString(wrapspan.THCSTextf(pt.Color24(), "%v %v", v.Potion.Name(), pt.Rune()))
+				case v.Scroll != nil:
+					st := v.Scroll.ScrollType
+					buf.WriteString(wrapspan.THCSTextf(st.Color24(), "%v %v", v.Scroll.Name(), st.Rune()))
+				}
+				fmt.Fprintf(&buf, makeBuyButton, ftSize, v.UUID, v.UUID, v.Price)
+				buf.WriteString("<br/>")
+			}
+		}
+	}
+	if app.onFieldObj != nil && app.onFieldObj.ActType == fieldobjacttype.QuestGiver {
+		fmt.Fprintf(&buf, makeListQuestButton, ftSize)
+		buf.WriteString("<br/>")
+		displayedLine++
+		if ql := app.questList; ql != nil {
+			for _, v := range ql.OfferList {
+				if displayedLine > DisplayLineLimit {
+					break
+				}
+				displayedLine++
+				fmt.Fprintf(&buf, "%v(%v) %v ", v.Name, v.Giver, questObjectiveString(v))
+				fmt.Fprintf(&buf, makeAcceptQuestButton, ftSize, v.Name, v.Name)
+				buf.WriteString("<br/>")
+			}
+			for _, v := range ql.QuestList {
+				if displayedLine > DisplayLineLimit {
+					break
+				}
+				displayedLine++
+				fmt.Fprintf(&buf, "%v %v ", v.Name, questObjectiveString(v))
+				if v.CanComplete() {
+					fmt.Fprintf(&buf, makeCompleteQuestButton, ftSize, v.Name, v.Name)
+				}
+				buf.WriteString("<br/>")
+			}
+		}
+	}
+
 	potionType2info := make([]struct {
 		UUID  string
 		Count int
 	}, potiontype.PotionType_Count)
+	unknownPotion := make(map[string]*bagItemInfo)
+	var unknownPotionNames []string
 	for _, v := range pao.PotionBag {
+		if !v.Identified() {
+			unknownPotionNames = addBagItemInfo(unknownPotion, unknownPotionNames, v.Appearance, v.UUID)
+			continue
+		}
 		potionType2info[v.PotionType].UUID = v.UUID
 		potionType2info[v.PotionType].Count++
 	}
@@ -285,11 +383,28 @@ func (app *WasmClient) makeInvenInfoHTML() string {
 		fmt.Fprintf(&buf, makeDropButton, ftSize, v.UUID, v.UUID)
 		buf.WriteString("<br/>")
 	}
+	for _, name := range unknownPotionNames {
+		if displayedLine > DisplayLineLimit {
+			break
+		}
+		v := unknownPotion[name]
+		displayedLine++
+		fmt.Fprintf(&buf, "%v(%v)", name, v.Count)
+		fmt.Fprintf(&buf, makeDrinkPotionButton, ftSize, v.UUID, v.UUID)
+		fmt.Fprintf(&buf, makeDropButton, ftSize, v.UUID, v.UUID)
+		buf.WriteString("<br/>")
+	}
 	scrollType2info := make([]struct {
 		UUID  string
 		Count int
 	}, scrolltype.ScrollType_Count)
+	unknownScroll := make(map[string]*bagItemInfo)
+	var unknownScrollNames []string
 	for _, v := range pao.ScrollBag {
+		if !v.Identified() {
+			unknownScrollNames = addBagItemInfo(unknownScroll, unknownScrollNames, v.Appearance, v.UUID)
+			continue
+		}
 		scrollType2info[v.ScrollType].UUID = v.UUID
 		scrollType2info[v.ScrollType].Count++
 	}
@@ -314,6 +429,17 @@ func (app *WasmClient) makeInvenInfoHTML() string {
 		fmt.Fprintf(&buf, makeDropButton, ftSize, v.UUID, v.UUID)
 		buf.WriteString("<br/>")
 	}
+	for _, name := range unknownScrollNames {
+		if displayedLine > DisplayLineLimit {
+			break
+		}
+		v := unknownScroll[name]
+		displayedLine++
+		fmt.Fprintf(&buf, "%v(%v)", name, v.Count)
+		fmt.Fprintf(&buf, makeReadScrollButton, ftSize, v.UUID, v.UUID)
+		fmt.Fprintf(&buf, makeDropButton, ftSize, v.UUID, v.UUID)
+		buf.WriteString("<br/>")
+	}
 
 	fmt.Fprintf(&buf, "Equip %v<br/>", len(pao.EquippedPo))
 	for _, v := range pao.EquippedPo {
@@ -325,9 +451,13 @@ func (app *WasmClient) makeInvenInfoHTML() string {
 		poStr := wrapspan.THCSTextf(v.GetBias(), "%v%v%.0f",
 			v.EquipType.Rune(), v.Faction.Rune(), v.BiasLen)
 		buf.WriteString(poStr)
+		fmt.Fprintf(&buf, " %v/%v", v.Durability, gameconst.EquipDurabilityMax)
 		if canRecycle {
 			fmt.Fprintf(&buf, makeRecycleButton, ftSize, v.UUID, v.UUID)
 		}
+		if canRepair && v.Durability < gameconst.EquipDurabilityMax {
+			fmt.Fprintf(&buf, makeRepairButton, ftSize, v.UUID, v.UUID, v.RepairCost())
+		}
 		fmt.Fprintf(&buf, makeUnequipButton, ftSize, v.UUID, v.UUID)
 		fmt.Fprintf(&buf, makeDropButton, ftSize, v.UUID, v.UUID)
 		buf.WriteString("<br/>")
@@ -343,9 +473,13 @@ func (app *WasmClient) makeInvenInfoHTML() string {
 		poStr := wrapspan.THCSTextf(v.GetBias(), "%v%v%.0f",
 			v.EquipType.Rune(), v.Faction.Rune(), v.BiasLen)
 		buf.WriteString(poStr)
+		fmt.Fprintf(&buf, " %v/%v", v.Durability, gameconst.EquipDurabilityMax)
 		if canRecycle {
 			fmt.Fprintf(&buf, makeRecycleButton, ftSize, v.UUID, v.UUID)
 		}
+		if canRepair && v.Durability < gameconst.EquipDurabilityMax {
+			fmt.Fprintf(&buf, makeRepairButton, ftSize, v.UUID, v.UUID, v.RepairCost())
+		}
 		fmt.Fprintf(&buf, makeEquipButton, ftSize, v.UUID, v.UUID)
 		fmt.Fprintf(&buf, makeDropButton, ftSize, v.UUID, v.UUID)
 		buf.WriteString("<br/>")
@@ -560,6 +694,10 @@ func obj2ColorStr(v interface{}) string {
 		return wrapspan.THCSTextf(o.ActType.Color24(),
 			"%v", o.ActType.String())
 	case *c2t_obj.ActiveObjClient:
+		if o.OwnerUUID != "" {
+			return wrapspan.THCSTextf(o.Faction.Color24(),
+				"%v(pet)", o.NickName)
+		}
 		return wrapspan.THCSTextf(o.Faction.Color24(),
 			"%v", o.NickName)
 	case *c2t_obj.PlayerActiveObjInfo:
@@ -574,10 +712,20 @@ func obj2ColorStr(v interface{}) string {
 				"%v%v", o.EquipType.Rune(), o.Faction.Rune())
 		case carryingobjecttype.Money:
 			return makeMoneyColor(o.Value)
+		case carryingobjecttype.Ammo:
+			return fmt.Sprintf("Ammo %v", o.Value)
+		case carryingobjecttype.Key:
+			return "Key"
 		case carryingobjecttype.Potion:
+			if o.Appearance != "" {
+				return o.Appearance
+			}
 			return wrapspan.THCSTextf(o.PotionType.Color24(),
 				"%v%v", o.PotionType.Rune(), o.PotionType.String())
 		case carryingobjecttype.Scroll:
+			if o.Appearance != "" {
+				return o.Appearance
+			}
 			return wrapspan.THCSTextf(o.ScrollType.Color24(),
 				"%v%v", o.ScrollType.Rune(), o.ScrollType.String())
 		}
@@ -587,11 +735,29 @@ func obj2ColorStr(v interface{}) string {
 			"%v%v%.0f", o.EquipType.Rune(), o.Faction.Rune(), o.BiasLen)
 	case *c2t_obj.PotionClient:
 		return wrapspan.THCSTextf(o.PotionType.Color24(),
-			"%v%v", o.PotionType.Rune(), o.PotionType.String())
+			"%v%v", o.PotionType.Rune(), o.Name())
 	case *c2t_obj.ScrollClient:
 		return wrapspan.THCSTextf(o.ScrollType.Color24(),
-			"%v%v", o.ScrollType.Rune(), o.ScrollType.String())
+			"%v%v", o.ScrollType.Rune(), o.Name())
+	}
+}
+
+// bagItemInfo unidentified potion, scroll grouped by appearance
+type bagItemInfo struct {
+	UUID  string
+	Count int
+}
+
+func addBagItemInfo(m map[string]*bagItemInfo, names []string, name, uuid string) []string {
+	bi, exist := m[name]
+	if !exist {
+		bi = &bagItemInfo{}
+		m[name] = bi
+		names = append(names, name)
 	}
+	bi.UUID = uuid
+	bi.Count++
+	return names
 }
 
 func makeMoneyColor(mo int) string {
@@ -27,6 +27,7 @@ import (
 	"github.com/kasworld/goguelike/game/clientcookie"
 	"github.com/kasworld/goguelike/game/clientfloor"
 	"github.com/kasworld/goguelike/game/clientinitdata"
+	"github.com/kasworld/goguelike/game/objlistdelta"
 	"github.com/kasworld/goguelike/game/soundmap"
 	"github.com/kasworld/goguelike/lib/canvastext"
 	"github.com/kasworld/goguelike/lib/jskeypressmap"
@@ -81,11 +82,14 @@ type WasmClient struct {
 	taNotiData     *c2t_obj.NotiVPTiles_data
 	olNotiData     *c2t_obj.NotiObjectList_data
 	lastOLNotiData *c2t_obj.NotiObjectList_data
+	olReceiver     *objlistdelta.Receiver
 
 	movePacketPerTurn int32
 	actPacketPerTurn  int32
 	lastEffBias       bias.Bias
 	onFieldObj        *c2t_obj.FieldObjClient
+	shopList          *c2t_obj.RspListShop_data  // last ListShop result
+	questList         *c2t_obj.RspListQuest_data // last ListQuest result, updated by noti
 	OverLoadRate      float64
 	HPdiff            int
 	SPdiff            int
@@ -126,8 +130,9 @@ func InitPage() {
 		DispInterDur:       intervalduration.New("Display"),
 		ClientJitter:       actjitter.New("Client"),
 
-		pid2recv: c2t_pid2rspfn.New(),
-		DoClose:  func() { jslog.Errorf("Too early DoClose call") },
+		pid2recv:   c2t_pid2rspfn.New(),
+		olReceiver: objlistdelta.NewReceiver(),
+		DoClose:    func() { jslog.Errorf("Too early DoClose call") },
 	}
 	app.titlescene = NewTitleScene()
 	app.vp = NewGameScene()
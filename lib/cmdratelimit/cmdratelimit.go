@@ -0,0 +1,133 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cmdratelimit token bucket rate limit of each command of a connection
+package cmdratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ViolationWindow violation count reset period
+const ViolationWindow = time.Minute
+
+// Budget token bucket of a command, RatePerSec <= 0 : no limit
+type Budget struct {
+	RatePerSec float64
+	Burst      float64
+}
+
+// ParseBudgetList make budget of each command
+// s : "CmdName=RatePerSec/Burst,..." override defaultBudget
+// cmdName : return name of command index
+func ParseBudgetList(defaultBudget Budget, cmdCount int,
+	cmdName func(i int) string, s string) ([]Budget, error) {
+
+	rtn := make([]Budget, cmdCount)
+	name2i := make(map[string]int, cmdCount)
+	for i := range rtn {
+		rtn[i] = defaultBudget
+		name2i[cmdName(i)] = i
+	}
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		nameBudget := strings.SplitN(v, "=", 2)
+		if len(nameBudget) != 2 {
+			return nil, fmt.Errorf("invalid budget %v", v)
+		}
+		i, exist := name2i[nameBudget[0]]
+		if !exist {
+			return nil, fmt.Errorf("unknown command %v", v)
+		}
+		rateBurst := strings.SplitN(nameBudget[1], "/", 2)
+		if len(rateBurst) != 2 {
+			return nil, fmt.Errorf("invalid budget %v", v)
+		}
+		rate, err := strconv.ParseFloat(rateBurst[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid budget %v %v", v, err)
+		}
+		burst, err := strconv.ParseFloat(rateBurst[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid budget %v %v", v, err)
+		}
+		rtn[i] = Budget{rate, burst}
+	}
+	return rtn, nil
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func (l *Limiter) String() string {
+	return fmt.Sprintf("Limiter[violation:%v]", l.violationCount)
+}
+
+// Limiter per connection, budgetList shared by connections
+type Limiter struct {
+	mutex          sync.Mutex `prettystring:"hide"`
+	budgetList     []Budget
+	bucketList     []bucket
+	violationCount int
+	windowStart    time.Time
+}
+
+func New(budgetList []Budget) *Limiter {
+	return &Limiter{
+		budgetList: budgetList,
+		bucketList: make([]bucket, len(budgetList)),
+	}
+}
+
+// Take use a token of cmd, over budget take use no token (no debt)
+// return wait duration until token ready, 0 if in budget (token used)
+// and violation count in ViolationWindow
+func (l *Limiter) Take(cmd int, now time.Time) (time.Duration, int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	bg := l.budgetList[cmd]
+	if bg.RatePerSec <= 0 {
+		return 0, 0
+	}
+	bk := &l.bucketList[cmd]
+	if bk.last.IsZero() {
+		bk.tokens = bg.Burst
+	} else {
+		bk.tokens += now.Sub(bk.last).Seconds() * bg.RatePerSec
+		if bk.tokens > bg.Burst {
+			bk.tokens = bg.Burst
+		}
+		if bk.tokens < 0 {
+			bk.tokens = 0
+		}
+	}
+	bk.last = now
+	if bk.tokens >= 1 {
+		bk.tokens--
+		return 0, 0
+	}
+	if now.Sub(l.windowStart) > ViolationWindow {
+		l.windowStart = now
+		l.violationCount = 0
+	}
+	l.violationCount++
+	wait := time.Duration((1 - bk.tokens) / bg.RatePerSec * float64(time.Second))
+	return wait, l.violationCount
+}
@@ -0,0 +1,60 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Take(t *testing.T) {
+	l := New([]Budget{{0, 0}, {1, 3}})
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		if wait, _ := l.Take(0, now); wait != 0 {
+			t.Fatalf("no limit cmd wait %v", wait)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if wait, _ := l.Take(1, now); wait != 0 {
+			t.Fatalf("wait in burst %v %v", i, wait)
+		}
+	}
+	if wait, vc := l.Take(1, now); wait != time.Second || vc != 1 {
+		t.Fatalf("over burst %v %v", wait, vc)
+	}
+	// rejected take make no debt
+	if wait, vc := l.Take(1, now); wait != time.Second || vc != 2 {
+		t.Fatalf("over burst %v %v", wait, vc)
+	}
+	if wait, _ := l.Take(1, now.Add(3*time.Second)); wait != 0 {
+		t.Fatalf("wait after refill %v", wait)
+	}
+}
+
+func TestParseBudgetList(t *testing.T) {
+	nameList := []string{"Invalid", "Chat", "Move"}
+	bl, err := ParseBudgetList(Budget{10, 20}, len(nameList),
+		func(i int) string { return nameList[i] },
+		"Chat=0.5/2, Move=4/8")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if fmt.Sprint(bl) != "[{10 20} {0.5 2} {4 8}]" {
+		t.Fatalf("%v", bl)
+	}
+	if _, err := ParseBudgetList(Budget{}, len(nameList),
+		func(i int) string { return nameList[i] }, "Attack=1/1"); err == nil {
+		t.Fatalf("unknown command parsed")
+	}
+}
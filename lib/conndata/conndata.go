@@ -12,6 +12,7 @@
 package conndata
 
 import (
+	"github.com/kasworld/goguelike/lib/cmdratelimit"
 	"github.com/kasworld/goguelike/lib/session"
 )
 
@@ -19,4 +20,6 @@ type ConnData struct {
 	UUID       string
 	RemoteAddr string
 	Session    *session.Session
+	Spectator  bool // logined as spectator, Session is nil
+	CmdLimiter *cmdratelimit.Limiter
 }
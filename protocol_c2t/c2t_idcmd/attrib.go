@@ -29,25 +29,44 @@ var attrib = [CommandID_Count]struct {
 	Chat:        {false, 0},
 	AchieveInfo: {false, 0},
 
+	AckObjectList: {false, 0},
+	Spectate:      {false, 0},
+	TradePropose:  {false, 0},
+	TradeAmend:    {false, 0},
+	TradeAccept:   {false, 0},
+	TradeCancel:   {false, 0},
+	ListShop:      {false, 0},
+	ListQuest:     {false, 0},
+
 	Rebirth:   {false, 0},
 	MoveFloor: {false, 1}, // need check need turn
 	AIPlay:    {false, 0},
 
-	Meditate:    {false, 1},
-	KillSelf:    {false, 1},
-	Move:        {true, 1},
-	Attack:      {true, 1.5},
-	AttackWide:  {true, 3},
-	AttackLong:  {true, 3},
-	Pickup:      {true, 1},
-	Drop:        {true, 1},
-	Equip:       {true, 1},
-	UnEquip:     {true, 1},
-	DrinkPotion: {true, 1},
-	ReadScroll:  {true, 1},
-	Recycle:     {true, 1},
-	EnterPortal: {true, 1},
-	ActTeleport: {false, 1},
+	Meditate:      {false, 1},
+	KillSelf:      {false, 1},
+	Move:          {true, 1},
+	Attack:        {true, 1.5},
+	AttackWide:    {true, 3},
+	AttackLong:    {true, 3},
+	Shoot:         {true, 2},
+	Cast:          {true, 2},
+	Pickup:        {true, 1},
+	Drop:          {true, 1},
+	Equip:         {true, 1},
+	UnEquip:       {true, 1},
+	DrinkPotion:   {true, 1},
+	ReadScroll:    {true, 1},
+	Recycle:       {true, 1},
+	Craft:         {true, 2},
+	Buy:           {true, 1},
+	Repair:        {true, 1},
+	AcceptQuest:   {true, 1},
+	CompleteQuest: {true, 1},
+	EnterPortal:   {true, 1},
+	ActTeleport:   {false, 1},
+	OpenDoor:      {true, 1},
+	CloseDoor:     {true, 1},
+	CommandPet:    {true, 1},
 
 	AdminTowerCmd:     {false, 0},
 	AdminFloorCmd:     {false, 0},
@@ -12,6 +12,8 @@
 package c2t_obj
 
 import (
+	"github.com/kasworld/goguelike/enum/petorder"
+	"github.com/kasworld/goguelike/enum/skilltype"
 	"github.com/kasworld/goguelike/enum/way9type"
 )
 
@@ -57,6 +59,22 @@ type RspAttackLong_data struct {
 	Dummy uint8
 }
 
+type ReqShoot_data struct {
+	Dir way9type.Way9Type
+}
+type RspShoot_data struct {
+	Dummy uint8
+}
+
+type ReqCast_data struct {
+	Skill skilltype.SkillType
+	Dir   way9type.Way9Type // for skilldata.TargetDir
+	X, Y  int               // for skilldata.TargetPos
+}
+type RspCast_data struct {
+	Dummy uint8
+}
+
 type ReqPickup_data struct {
 	UUID string
 }
@@ -106,6 +124,41 @@ type RspRecycle_data struct {
 	Dummy uint8
 }
 
+type ReqCraft_data struct {
+	Recipe string // name in TowerInfo.CraftRecipeList
+}
+type RspCraft_data struct {
+	Dummy uint8
+}
+
+type ReqBuy_data struct {
+	UUID string // ShopItem uuid
+}
+type RspBuy_data struct {
+	Dummy uint8
+}
+
+type ReqRepair_data struct {
+	UUID string // equip uuid in inventory
+}
+type RspRepair_data struct {
+	Dummy uint8
+}
+
+type ReqAcceptQuest_data struct {
+	Name string // quest name
+}
+type RspAcceptQuest_data struct {
+	Dummy uint8
+}
+
+type ReqCompleteQuest_data struct {
+	Name string // quest name
+}
+type RspCompleteQuest_data struct {
+	Dummy uint8
+}
+
 type ReqEnterPortal_data struct {
 	Dummy uint8
 }
@@ -119,3 +172,27 @@ type ReqActTeleport_data struct {
 type RspActTeleport_data struct {
 	Dummy uint8
 }
+
+type ReqOpenDoor_data struct {
+	Dir way9type.Way9Type
+}
+type RspOpenDoor_data struct {
+	Dummy uint8
+}
+
+type ReqCloseDoor_data struct {
+	Dir way9type.Way9Type
+}
+type RspCloseDoor_data struct {
+	Dummy uint8
+}
+
+// ReqCommandPet Feed : PetUUID is ao to feed, TargetUUID is potion in bag
+type ReqCommandPet_data struct {
+	PetUUID    string
+	Order      petorder.PetOrder
+	TargetUUID string // ao to attack, potion to feed
+}
+type RspCommandPet_data struct {
+	Dummy uint8
+}
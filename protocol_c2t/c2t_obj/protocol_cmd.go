@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/kasworld/goguelike/enum/achievetype_vector"
+	"github.com/kasworld/goguelike/enum/chattype"
 	"github.com/kasworld/goguelike/enum/condition_vector"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype_vector"
 	"github.com/kasworld/goguelike/enum/potiontype_vector"
@@ -33,6 +34,7 @@ type ReqLogin_data struct {
 	SessionUUID string
 	NickName    string
 	AuthKey     string
+	Spectate    bool // login as spectator, no ao made
 }
 type RspLogin_data struct {
 	ServiceInfo *ServiceInfo
@@ -47,7 +49,9 @@ type RspHeartbeat_data struct {
 }
 
 type ReqChat_data struct {
-	Chat string
+	Chat     string
+	ChatType chattype.ChatType
+	To       string // nickname to Whisper
 }
 type RspChat_data struct {
 	Dummy uint8
@@ -65,6 +69,72 @@ type RspAchieveInfo_data struct {
 	ConditionStat condition_vector.ConditionVector             `prettystring:"simple"`
 }
 
+type ReqAckObjectList_data struct {
+	Seq int
+}
+type RspAckObjectList_data struct {
+	Dummy uint8
+}
+
+// follow ao by UUID or NickName, follow top of exp ranking if both empty
+type ReqSpectate_data struct {
+	UUID     string
+	NickName string
+}
+type RspSpectate_data struct {
+	ActiveObjUUID string
+	NickName      string
+	AutoFollow    bool
+}
+
+type ReqTradePropose_data struct {
+	DstUUID          string // near ao to trade
+	CarryObjUUIDList []string
+	Money            float64
+}
+type RspTradePropose_data struct {
+	Dummy uint8
+}
+
+type ReqTradeAmend_data struct {
+	CarryObjUUIDList []string
+	Money            float64
+}
+type RspTradeAmend_data struct {
+	Dummy uint8
+}
+
+type ReqTradeAccept_data struct {
+	Dummy uint8
+}
+type RspTradeAccept_data struct {
+	Dummy uint8
+}
+
+type ReqTradeCancel_data struct {
+	Dummy uint8
+}
+type RspTradeCancel_data struct {
+	Dummy uint8
+}
+
+type ReqListShop_data struct {
+	Dummy uint8
+}
+type RspListShop_data struct {
+	ShopID        string
+	RemainRestock int // turn to restock
+	ItemList      []*ShopItem
+}
+
+type ReqListQuest_data struct {
+	Dummy uint8
+}
+type RspListQuest_data struct {
+	OfferList []*QuestClient // offered by giver at pos, not accepted
+	QuestList []*QuestClient // accepted
+}
+
 type ReqRebirth_data struct {
 	Dummy uint8
 }
@@ -15,7 +15,10 @@ import (
 	"time"
 
 	"github.com/kasworld/goguelike/config/viewportdata"
+	"github.com/kasworld/goguelike/enum/factiontype"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype"
+	"github.com/kasworld/goguelike/enum/tradestate"
+	"github.com/kasworld/goguelike/game/noise"
 	"github.com/kasworld/goguelike/game/tilearea"
 )
 
@@ -56,9 +59,74 @@ type NotiBroadcast_data struct {
 	Msg string
 }
 
+type NotiChatWhisper_data struct {
+	SenderUUID string
+	SenderName string
+	Chat       string
+}
+type NotiChatFloor_data struct {
+	FloorName  string
+	SenderUUID string
+	SenderName string
+	Chat       string
+}
+type NotiChatTower_data struct {
+	SenderUUID string
+	SenderName string
+	Chat       string
+}
+type NotiChatFaction_data struct {
+	Faction    factiontype.FactionType
+	SenderUUID string
+	SenderName string
+	Chat       string
+}
+
+// NotiTradeState_data send to both side of trade
+type NotiTradeState_data struct {
+	TradeID string
+	State   tradestate.TradeState
+	Self    *TradeOffer
+	Other   *TradeOffer
+}
+
+// NotiCraft_data send to ao crafted at Crafter
+type NotiCraft_data struct {
+	Recipe     string
+	EquipList  []*EquipClient
+	PotionList []*PotionClient
+	ScrollList []*ScrollClient
+}
+
+// NotiQuest_data send to ao on quest accepted, progressed, completed
+type NotiQuest_data struct {
+	Quest *QuestClient
+}
+
+// NotiBossKilled_data send to all ao in tower
+type NotiBossKilled_data struct {
+	BossName   string
+	FloorName  string
+	KillerName string // empty if not killed by ao
+}
+
+// NotiDiplomacy_data relation of ao current faction, faction itself included
+type NotiDiplomacy_data struct {
+	Faction        factiontype.FactionType
+	AllyList       []factiontype.FactionType
+	HostileList    []factiontype.FactionType // not in list is neutral
+	AllyAttackRate float64                   // 0 : can not attack ally
+}
+
+// NotiHeardNoise_data noise source not in sight, loud first
+type NotiHeardNoise_data struct {
+	NoiseList []noise.Heard
+}
+
 type NotiObjectList_data struct {
 	Time          time.Time `prettystring:"simple"`
 	FloorName     string
+	Seq           int // 0 if not delta mode, client ack to use as delta base
 	ActiveObj     *PlayerActiveObjInfo
 	ActiveObjList []*ActiveObjClient
 	CarryObjList  []*CarryObjClientOnFloor
@@ -66,6 +134,24 @@ type NotiObjectList_data struct {
 	DangerObjList []*DangerObjClient
 }
 
+// NotiObjectListDelta_data apply to ObjectList of BaseSeq make ObjectList of Seq
+// obj list has added or changed, del list has removed uuid
+type NotiObjectListDelta_data struct {
+	Time             time.Time `prettystring:"simple"`
+	FloorName        string
+	Seq              int
+	BaseSeq          int
+	ActiveObj        *PlayerActiveObjInfo
+	ActiveObjList    []*ActiveObjClient
+	ActiveObjDelList []string
+	CarryObjList     []*CarryObjClientOnFloor
+	CarryObjDelList  []string
+	FieldObjList     []*FieldObjClient
+	FieldObjDelList  []string
+	DangerObjList    []*DangerObjClient
+	DangerObjDelList []string
+}
+
 type NotiVPTiles_data struct {
 	FloorName string
 	VPX       int
@@ -24,8 +24,10 @@ import (
 	"github.com/kasworld/goguelike/enum/factiontype"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype"
 	"github.com/kasworld/goguelike/enum/fieldobjdisplaytype"
+	"github.com/kasworld/goguelike/enum/petorder"
 	"github.com/kasworld/goguelike/enum/potiontype"
 	"github.com/kasworld/goguelike/enum/scrolltype"
+	"github.com/kasworld/goguelike/enum/skilltype"
 	"github.com/kasworld/goguelike/enum/turnresulttype"
 	"github.com/kasworld/goguelike/game/aoactreqrsp"
 	"github.com/kasworld/goguelike/game/bias"
@@ -58,6 +60,8 @@ type TowerInfo struct {
 	TotalFloorNum int
 	StartTime     time.Time `prettystring:"simple"`
 	TurnPerSec    float64
+
+	CraftRecipeList []string // recipe name usable at Crafter
 }
 
 func (info *TowerInfo) StringForm() string {
@@ -102,6 +106,9 @@ type CarryObjClientOnFloor struct {
 	// for scroll
 	ScrollType scrolltype.ScrollType
 
+	// potion, scroll not identified, type is hidden
+	Appearance string
+
 	// for money
 	Value int
 }
@@ -138,6 +145,7 @@ type ActiveObjClient struct {
 	Y          int
 	Alive      bool
 	Chat       string
+	OwnerUUID  string // owner ao of pet, empty if not pet
 
 	// turn result
 	Act        c2t_idcmd.CommandID
@@ -166,14 +174,35 @@ type PlayerActiveObjInfo struct {
 	PotionBag  []*PotionClient
 	ScrollBag  []*ScrollClient
 	Wallet     int
+	Ammo       int
+	Key        int // key count
 	Wealth     int
 	ActiveBuff []*ActiveObjBuff
+	SkillList  []*SkillClient
+	PetList    []*PetClient
 	AP         float64
 
 	Act        *aoactreqrsp.ActReqRsp
 	TurnResult []TurnResultClient
 }
 
+type PetClient struct {
+	UUID      string
+	NickName  string
+	FloorName string
+	Order     petorder.PetOrder
+	Level     int
+	Exp       int
+	HP        int
+	HPMax     int
+	Alive     bool
+}
+
+type SkillClient struct {
+	Skill      skilltype.SkillType
+	RemainCool int // turn to cast again
+}
+
 type TurnResultClient struct {
 	ResultType turnresulttype.TurnResultType
 	DstUUID    string
@@ -186,15 +215,56 @@ type EquipClient struct {
 	EquipType equipslottype.EquipSlotType
 	Faction   factiontype.FactionType
 	BiasLen   float64
+
+	Durability int // 0 ~ gameconst.EquipDurabilityMax
 }
 
 type PotionClient struct {
 	UUID       string
 	PotionType potiontype.PotionType
+	Appearance string // not identified, PotionType is hidden
 }
 type ScrollClient struct {
 	UUID       string
 	ScrollType scrolltype.ScrollType
+	Appearance string // not identified, ScrollType is hidden
+}
+
+// ShopItem carryobj for sale, one of Equip, Potion, Scroll
+type ShopItem struct {
+	UUID   string
+	Price  float64
+	Equip  *EquipClient
+	Potion *PotionClient
+	Scroll *ScrollClient
+}
+
+// QuestObjective objective of quest with progress
+type QuestObjective struct {
+	Objective string
+	Count     int
+	Need      int
+}
+
+// QuestClient quest offered or accepted
+type QuestClient struct {
+	Name      string
+	Giver     string
+	Objective []QuestObjective
+	Reward    []string
+	Accepted  bool
+	Completed bool
+}
+
+// TradeOffer carryobj and money one side give in trade
+type TradeOffer struct {
+	ActiveObjUUID string
+	NickName      string
+	EquipList     []*EquipClient
+	PotionList    []*PotionClient
+	ScrollList    []*ScrollClient
+	Money         float64
+	Accepted      bool
 }
 
 type ActiveObjBuff struct {
@@ -32,9 +32,19 @@ func (po CarryObjClientOnFloor) String() string {
 		)
 	case carryingobjecttype.Money:
 		return fmt.Sprintf("$%v", po.Value)
+	case carryingobjecttype.Ammo:
+		return fmt.Sprintf("Ammo%v", po.Value)
+	case carryingobjecttype.Key:
+		return "Key"
 	case carryingobjecttype.Potion:
+		if po.Appearance != "" {
+			return po.Appearance
+		}
 		return fmt.Sprintf("Potion%v", po.PotionType.String())
 	case carryingobjecttype.Scroll:
+		if po.Appearance != "" {
+			return po.Appearance
+		}
 		return fmt.Sprintf("Scroll%v", po.ScrollType.String())
 	}
 }
@@ -50,6 +60,8 @@ func (pao PlayerActiveObjInfo) CalcWeight() float64 {
 	weight += float64(len(pao.PotionBag)) * gameconst.PotionGram
 	weight += float64(len(pao.ScrollBag)) * gameconst.ScrollGram
 	weight += float64(pao.Wallet) * gameconst.MoneyGram
+	weight += float64(pao.Ammo) * gameconst.AmmoGram
+	weight += float64(pao.Key) * gameconst.KeyGram
 	return weight
 }
 
@@ -128,8 +140,18 @@ func (po EquipClient) String() string {
 	)
 }
 
+// GetBias reduced by worn durability, same as server
 func (po EquipClient) GetBias() bias.Bias {
-	return bias.NewByFaction(po.Faction, po.BiasLen)
+	rate := gameconst.EquipWornBiasRate +
+		(1-gameconst.EquipWornBiasRate)*float64(po.Durability)/gameconst.EquipDurabilityMax
+	return bias.NewByFaction(po.Faction, po.BiasLen*rate)
+}
+
+// RepairCost money to make durability max, same as server
+func (po EquipClient) RepairCost() float64 {
+	worn := gameconst.EquipDurabilityMax - po.Durability
+	return po.BiasLen * gameconst.EquipABSValue * gameconst.EquipRepairCostRate *
+		float64(worn) / gameconst.EquipDurabilityMax
 }
 
 func (po EquipClient) Weight() float64 {
@@ -156,7 +178,19 @@ func (objList EquipClientByUUID) Sort() {
 }
 
 func (po PotionClient) String() string {
-	return fmt.Sprintf("Potion%v", po.PotionType.String())
+	return fmt.Sprintf("Potion%v", po.Name())
+}
+
+func (po PotionClient) Identified() bool {
+	return po.Appearance == ""
+}
+
+// Name true type or appearance if not identified
+func (po PotionClient) Name() string {
+	if po.Appearance != "" {
+		return po.Appearance
+	}
+	return po.PotionType.String()
 }
 
 func (po PotionClient) Weight() int {
@@ -179,7 +213,19 @@ func (objList PotionClientByUUID) Sort() {
 }
 
 func (po ScrollClient) String() string {
-	return fmt.Sprintf("Scroll%v", po.ScrollType.String())
+	return fmt.Sprintf("Scroll%v", po.Name())
+}
+
+func (po ScrollClient) Identified() bool {
+	return po.Appearance == ""
+}
+
+// Name true type or appearance if not identified
+func (po ScrollClient) Name() string {
+	if po.Appearance != "" {
+		return po.Appearance
+	}
+	return po.ScrollType.String()
 }
 
 func (po ScrollClient) Weight() int {
@@ -233,3 +279,16 @@ func (objList FieldObjByType) Less(i, j int) bool {
 func (objList FieldObjByType) Sort() {
 	sort.Stable(objList)
 }
+
+// CanComplete accepted and all objective done
+func (qc QuestClient) CanComplete() bool {
+	if !qc.Accepted || qc.Completed {
+		return false
+	}
+	for _, v := range qc.Objective {
+		if v.Count < v.Need {
+			return false
+		}
+	}
+	return true
+}
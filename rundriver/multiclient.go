@@ -86,6 +86,7 @@ func main() {
 		},
 		func(i int) interface{} {
 			return clientai.ClientAIConfig{
+				Net:               config.Net,
 				ConnectToTower:    config.ConnectToTower,
 				Nickname:          fmt.Sprintf("%s%d", config.PlayerNameBase, i),
 				SessionUUID:       "",
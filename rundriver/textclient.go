@@ -57,6 +57,7 @@ func main() {
 		config.LogLevel)
 
 	aiconfig := clientai.ClientAIConfig{
+		Net:               config.Net,
 		ConnectToTower:    config.ConnectToTower,
 		Nickname:          config.PlayerName,
 		SessionUUID:       "",
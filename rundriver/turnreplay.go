@@ -0,0 +1,99 @@
+// Copyright 2014,2015,2016,2017,2018,2019,2020 SeukWon Kang (kasworld@gmail.com)
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//    http://www.apache.org/licenses/LICENSE-2.0
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/kasworld/argdefault"
+	"github.com/kasworld/configutil"
+	"github.com/kasworld/goguelike/config/dataversion"
+	"github.com/kasworld/goguelike/config/towerconfig"
+	"github.com/kasworld/goguelike/game/tower"
+	"github.com/kasworld/goguelike/game/turnrecorder"
+	"github.com/kasworld/goguelike/lib/g2log"
+	"github.com/kasworld/goguelike/protocol_c2t/c2t_version"
+	"github.com/kasworld/log/logflags"
+	"github.com/kasworld/version"
+)
+
+var Ver = ""
+
+func init() {
+	version.Set(Ver)
+}
+
+func printVersion() {
+	fmt.Println("Goguelike turn replay")
+	fmt.Println("Build     ", version.GetVersion())
+	fmt.Println("Data      ", dataversion.DataVersion)
+	fmt.Println("Protocol  ", c2t_version.ProtocolVersion)
+	fmt.Println()
+}
+
+func main() {
+	printVersion()
+
+	configurl := flag.String("i", "", "server config file or url")
+	recordfile := flag.String("r", "", "turn record file, default from config")
+	stoponfail := flag.Bool("stoponfail", false, "stop at first not matched turn")
+
+	ads := argdefault.New(&towerconfig.TowerConfig{})
+	ads.RegisterFlag()
+	flag.Parse()
+	config := &towerconfig.TowerConfig{}
+	ads.SetDefaultToNonZeroField(config)
+	if *configurl != "" {
+		if err := configutil.LoadIni(*configurl, &config); err != nil {
+			g2log.Fatal("%v", err)
+		}
+	}
+	ads.ApplyFlagTo(config)
+	if *recordfile == "" {
+		*recordfile = config.MakeTurnRecordFileFullpath()
+	}
+
+	twlog, err := g2log.NewWithDstDir(
+		config.TowerName+"_replay",
+		config.MakeLogDir(),
+		logflags.DefaultValue(false).BitClear(logflags.LF_functionname),
+		config.LogLevel,
+		config.SplitLogLevel,
+	)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return
+	}
+	g2log.GlobalLogger = twlog
+
+	tw := tower.New(config, twlog)
+	turnCount := 0
+	failCount := 0
+	err = tw.ReplayTurnRecord(*recordfile,
+		func(tr *turnrecorder.TurnRecord, mismatch []string) bool {
+			turnCount++
+			if len(mismatch) == 0 {
+				return true
+			}
+			failCount++
+			fmt.Printf("%v\n", tr)
+			for _, v := range mismatch {
+				fmt.Printf("\t%v\n", v)
+			}
+			return !*stoponfail
+		})
+	if err != nil {
+		fmt.Printf("replay fail %v\n", err)
+	}
+	fmt.Printf("replay %v turn, %v not matched\n", turnCount, failCount)
+}
@@ -15,6 +15,7 @@ import (
 	"fmt"
 
 	"github.com/kasworld/goguelike/enum/decaytype"
+	"github.com/kasworld/goguelike/enum/factiontype"
 
 	"github.com/kasworld/g2rand"
 	"github.com/kasworld/goguelike/config/gameconst"
@@ -346,6 +347,10 @@ func New(name string) *towermake.Tower {
 		if recycleCount-roomCount > 0 {
 			fm.AddRecycler("Rand", recycleCount-roomCount)
 		}
+		fm.AddCrafter(suffix, 1+recycleCount/8)
+		fm.AddShop(suffix, 1, "Potion*4,Scroll*2,Equip*2", 2, 1000)
+		fm.AddRepairer(suffix, 1)
+		fm.AddQuestGiver(suffix, 1)
 		for j := 0; j < decaytype.DecayType_Count; j++ {
 			decay := decaytype.DecayType(j)
 			fm.Appendf(
@@ -363,5 +368,28 @@ func New(name string) *towermake.Tower {
 		}
 
 	}
+
+	tw.GetByName("ResourceMaze").AddBossInRoom("Minotaur", 20, factiontype.Maroon,
+		"Attack:3,Revenge:2,StrollAround", "Weapon,Helmet", "Potion*3,Equip", 500)
+	tw.GetByName("Ghost").AddBossInRoom("Banshee", 30, factiontype.DarkViolet,
+		"Attack:2,CastSkill:2,Revenge,StrollAround", "Ring,Amulet", "Scroll*3,Potion*2", 1000)
+	tw.GetByName("TileRooms").AddBossInRoom("Elemental", 40, factiontype.Red,
+		"", "Weapon,Shield,Armor", "Potion:RecoverHP100*2,Equip*2", 2000)
+
+	tw.GetByName("ResourceMaze").LootTable("Equip:3,Money,Potion,Scroll",
+		"Weapon:2,Armor:2,Shield,Helmet", "", "", 100, 50, 0.1, 0.5)
+	tw.GetByName("Ghost").LootTable("Scroll:3,Potion,Money",
+		"", "", "FloorMap,Teleport:2,Identify:2", 200, 100, 0.1, 0.3)
+
+	// lit by magma only, dark
+	tw.GetByName("SoilMagma").Light(0.2)
+	tw.GetByName("Ghost").Light(0)
+
+	// same faction ally, ally attack half damage
+	tw.DiplomacyAttackRate("Ally", 0.5)
+	for i := 0; i < factiontype.FactionType_Count; i++ {
+		ft := factiontype.FactionType(i)
+		tw.Diplomacy(ft, ft, "Ally")
+	}
 	return tw
 }
@@ -15,6 +15,7 @@ import (
 	"fmt"
 
 	"github.com/kasworld/g2rand"
+	"github.com/kasworld/goguelike/enum/factiontype"
 	"github.com/kasworld/goguelike/enum/fieldobjacttype"
 	"github.com/kasworld/goguelike/enum/terraincmd"
 	"github.com/kasworld/goguelike/game/terrain/paramconv"
@@ -147,6 +148,80 @@ func (fm *Floor) AddRecycler(suffix string, count int) *Floor {
 	return fm
 }
 
+// suffix "InRoom" or "Rand"
+func (fm *Floor) AddCrafter(suffix string, count int) *Floor {
+	if count <= 0 {
+		fmt.Printf("%v AddCrafter count %v\n", fm, count)
+		return fm
+	}
+	fm.Appendf(
+		"AddCrafter%[1]v display=Crafter count=%[2]v message=Craft",
+		suffix, count)
+	return fm
+}
+
+// suffix "InRoom" or "Rand"
+func (fm *Floor) AddRepairer(suffix string, count int) *Floor {
+	if count <= 0 {
+		fmt.Printf("%v AddRepairer count %v\n", fm, count)
+		return fm
+	}
+	fm.Appendf(
+		"AddRepairer%[1]v display=Repairer count=%[2]v message=Repair",
+		suffix, count)
+	return fm
+}
+
+// suffix "InRoom" or "Rand"
+// quest of giver defined in quest.txt
+func (fm *Floor) AddQuestGiver(suffix string, count int) *Floor {
+	if count <= 0 {
+		fmt.Printf("%v AddQuestGiver count %v\n", fm, count)
+		return fm
+	}
+	fm.Appendf(
+		"AddQuestGiver%[1]v display=QuestGiver count=%[2]v message=Quest",
+		suffix, count)
+	return fm
+}
+
+// suffix "InRoom" or "Rand"
+// stock, price, restock : see AddShop in towerscript.md
+func (fm *Floor) AddShop(suffix string, count int, stock string, price float64, restock int) *Floor {
+	if count <= 0 {
+		fmt.Printf("%v AddShop count %v\n", fm, count)
+		return fm
+	}
+	fm.Appendf(
+		"AddShop%[1]v display=Shop stock=%[3]v price=%[4]v restock=%[5]v count=%[2]v message=Shop",
+		suffix, count, stock, price, restock)
+	return fm
+}
+
+// aiplan, equip, loot : see AddBoss in towerscript.md
+func (fm *Floor) AddBossInRoom(name string, level int, faction factiontype.FactionType,
+	aiplan, equip, loot string, respawn int) *Floor {
+	fm.Appendf(
+		"AddBossInRoom name=%v level=%v faction=%v aiplan=%v equip=%v loot=%v respawn=%v",
+		name, level, faction, aiplan, equip, loot, respawn)
+	return fm
+}
+
+// typ, equip, potion, scroll : see LootTable in towerscript.md
+func (fm *Floor) LootTable(typ, equip, potion, scroll string,
+	moneyMean, moneyStdDev, depthScale, deathDrop float64) *Floor {
+	fm.Appendf(
+		"LootTable type=%v equip=%v potion=%v scroll=%v moneymean=%v moneystddev=%v depthscale=%v deathdrop=%v",
+		typ, equip, potion, scroll, moneyMean, moneyStdDev, depthScale, deathDrop)
+	return fm
+}
+
+// ambient 0 dark ~ 1 full lit
+func (fm *Floor) Light(ambient float64) *Floor {
+	fm.Appendf("Light ambient=%v", ambient)
+	return fm
+}
+
 // suffix "InRoom" or "Rand"
 func (fm *Floor) AddTrapTeleportTo(suffix string, dstFloor *Floor) *Floor {
 	fm.Appendf("AddTrapTeleports%[1]v DstFloor=%[2]v count=1 message=To%[2]v",
@@ -17,13 +17,15 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/kasworld/goguelike/enum/factiontype"
 	"github.com/kasworld/goguelike/game/towerscript"
 )
 
 type Tower struct {
-	name   string
-	byList []*Floor
-	byName map[string]*Floor
+	name     string
+	byList   []*Floor
+	byName   map[string]*Floor
+	towerCmd []string // not terrain cmd, saved in header script
 }
 
 func New(name string) *Tower {
@@ -58,12 +60,28 @@ func (tw *Tower) GetCount() int {
 	return len(tw.byList)
 }
 
+// Appendf add tower cmd like Diplomacy
+func (tw *Tower) Appendf(format string, arg ...interface{}) *Tower {
+	tw.towerCmd = append(tw.towerCmd, fmt.Sprintf(format, arg...))
+	return tw
+}
+
+// relation : see Diplomacy in towerscript.md
+func (tw *Tower) Diplomacy(ft1, ft2 factiontype.FactionType, relation string) *Tower {
+	return tw.Appendf("Diplomacy faction1=%v faction2=%v relation=%v", ft1, ft2, relation)
+}
+
+func (tw *Tower) DiplomacyAttackRate(relation string, rate float64) *Tower {
+	return tw.Appendf("DiplomacyAttackRate relation=%v rate=%v", relation, rate)
+}
+
 func (tw *Tower) Save() error {
 	twst := make(towerscript.TowerScript, 0)
 	twst = append(twst, []string{
 		fmt.Sprintf("# tower %v, made by tower maker", tw.name),
 		fmt.Sprintf("# %s %s", filepath.Base(os.Args[0]), strings.Join(os.Args[1:], " ")),
 	})
+	twst[0] = append(twst[0], tw.towerCmd...)
 	for _, fm := range tw.byList {
 		twst = append(twst, fm.Script)
 	}